@@ -17,8 +17,133 @@ limitations under the License.
 package v1
 
 import (
+	"encoding/json"
+	"path"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultServicePort is the TCP port nfd-master listens on when
+	// Operand.ServicePort is not set
+	DefaultServicePort = 12000
+
+	// DefaultImagePullPolicy is the pull policy of the operand images when
+	// Operand.ImagePullPolicy is not set
+	DefaultImagePullPolicy = corev1.PullAlways
+
+	// DefaultWorkerConfig is the nfd-worker configuration used when
+	// WorkerConfig.ConfigData is not set
+	DefaultWorkerConfig = "core:\n  sleepInterval: 60s\n"
+
+	// DefaultWorkerConfigMapKey is the key read from the ConfigMap
+	// referenced by WorkerConfig.ConfigMapRef when Key is not set
+	DefaultWorkerConfigMapKey = "nfd-worker.conf"
+
+	// DefaultMasterReplicas is the number of nfd-master replicas when
+	// Master.Replicas is not set
+	DefaultMasterReplicas = 1
+
+	// DefaultTopologyUpdaterSleepInterval is the interval between two
+	// resource scans of nfd-topology-updater when
+	// TopologyUpdater.SleepInterval is not set
+	DefaultTopologyUpdaterSleepInterval = 3 * time.Second
+
+	// DefaultKubeletStateDir is the kubelet root directory on the nodes
+	// when TopologyUpdater.KubeletStateDir is not set
+	DefaultKubeletStateDir = "/var/lib/kubelet"
+
+	// DefaultPruneTimeout is how long the prune job may run when
+	// PrunePolicy.Timeout is not set
+	DefaultPruneTimeout = 10 * time.Minute
+
+	// DefaultPruneRetries is the number of times a failed prune pod is
+	// retried when PrunePolicy.Retries is not set
+	DefaultPruneRetries = 3
+
+	// DefaultPruneTTLAfterFinished is how long a finished prune job is kept
+	// when PrunePolicy.TTLAfterFinished is not set
+	DefaultPruneTTLAfterFinished = time.Hour
+
+	// MinPruneTTLAfterFinished is the shortest PrunePolicy.TTLAfterFinished,
+	// a finished prune job must outlive the reconcile reading its outcome
+	MinPruneTTLAfterFinished = time.Minute
+
+	// DefaultPruneDelay is how long the prune job waits after the deletion
+	// of the CR with the PruneAfterDelay deletion policy when
+	// PrunePolicy.Delay is not set
+	DefaultPruneDelay = time.Hour
+
+	// RestoreSnapshotAnnotation is set on a CR to re-apply to the nodes the
+	// snapshot of the NFD labels held by the ConfigMap it names, in the
+	// namespace of the CR. The operator removes the annotation once the
+	// snapshot is restored
+	RestoreSnapshotAnnotation = "nfd.kubernetes.io/restore-snapshot"
+
+	// PruneRequestedAnnotation is set on a CR to remove the NFD labels from
+	// the nodes without deleting the CR. Its value identifies the request, a
+	// new value requests a new prune. The operator removes the annotation
+	// once the prune job completed
+	PruneRequestedAnnotation = "nfd.kubernetes.io/prune-requested"
+)
+
+// Default resources of the operand containers, used when the matching
+// Operand.Resources field is not set. They follow the upstream NFD
+// deployment manifests.
+var (
+	DefaultMasterResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}
+
+	DefaultWorkerResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("5m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+
+	DefaultTopologyUpdaterResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("40Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("60Mi"),
+		},
+	}
+
+	DefaultGCResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+
+	DefaultPruneResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
 )
 
 // NodeFeatureDiscoverySpec defines the desired state of NodeFeatureDiscovery
@@ -27,16 +152,31 @@ type NodeFeatureDiscoverySpec struct {
 	// +optional
 	Operand OperandSpec `json:"operand"`
 
+	// Master describes the nfd-master deployment
+	// +optional
+	Master MasterSpec `json:"master,omitempty"`
+
 	// Deploy the NFD-Topology-Updater
 	// NFD-Topology-Updater is a daemon responsible for examining allocated
 	// resources on a worker node to account for resources available to be
 	// allocated to new pod on a per-zone basis
 	// https://kubernetes-sigs.github.io/node-feature-discovery/master/get-started/introduction.html#nfd-topology-updater
+	// The field accepts either a TopologyUpdaterSpec object or, for
+	// backwards compatibility, a deprecated boolean equivalent to setting
+	// enabled.
 	// +optional
-	TopologyUpdater bool `json:"topologyUpdater"`
+	TopologyUpdater TopologyUpdaterSpec `json:"topologyUpdater"`
 
 	// Instance name. Used to separate annotation namespaces for
-	// multiple parallel deployments.
+	// multiple parallel deployments. The names of the operand objects
+	// (DaemonSets, Deployments, ConfigMaps and the prune Job) are suffixed
+	// with the instance name, so that several NodeFeatureDiscovery CRs can
+	// be deployed side by side in the same namespace. nfd-worker is not
+	// instance aware, so each instance must deploy its operands to a
+	// namespace of its own. The name cannot contain consecutive hyphens,
+	// which separate the instance from the worker pool names.
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]+(-[a-z0-9]+)*)?$`
+	// +kubebuilder:validation:MaxLength=32
 	// +optional
 	Instance string `json:"instance"`
 
@@ -64,25 +204,340 @@ type NodeFeatureDiscoverySpec struct {
 	// +optional
 	WorkerConfig ConfigMap `json:"workerConfig"`
 
+	// WorkerPools defines groups of nodes running nfd-worker with their own
+	// configuration. Each pool gets its own worker DaemonSet and ConfigMap,
+	// named after the pool. When at least one pool is defined, the pools
+	// replace the single default worker DaemonSet, so the node selectors
+	// of the pools should select disjoint sets of nodes.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	WorkerPools []WorkerPool `json:"workerPools,omitempty"`
+
 	// PruneOnDelete defines whether the NFD-master prune should be
 	// enabled or not. If enabled, the Operator will deploy an NFD-Master prune
 	// job that will remove all NFD labels (and other NFD-managed assets such
 	// as annotations, extended resources and taints) from the cluster nodes.
+	// Deprecated: use DeletionPolicy, which takes precedence when set
 	// +optional
 	PruneOnDelete bool `json:"prunerOnDelete"`
 
+	// DeletionPolicy defines what happens to the NFD labels of the nodes
+	// when the CR is deleted [defaults to Prune if PruneOnDelete is set,
+	// Orphan otherwise]
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// PrunePolicy tunes the prune job run on deletion of the CR when
+	// the deletion policy prunes the NFD labels
+	// +optional
+	PrunePolicy PrunePolicy `json:"prunePolicy,omitempty"`
+
 	// EnableTaints enables the enable the experimental tainting feature
 	// This allows keeping nodes with specialized hardware away from running general workload i
 	// and instead leave them for workloads that need the specialized hardware.
 	// +optional
 	EnableTaints bool `json:"enableTaints"`
+
+	// GC describes the nfd-gc deployment
+	// +optional
+	GC GCSpec `json:"gc,omitempty"`
+
+	// Metrics exposes the metrics of the operands through a Service per
+	// component and, if the Prometheus operator is installed, a
+	// ServiceMonitor per Service. Nothing is created when unset
+	// +optional
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
+}
+
+// MasterSpec describes the nfd-master deployment
+type MasterSpec struct {
+	// Replicas is the number of nfd-master pods. With more than one
+	// replica the pods run with leader election, are spread across nodes
+	// and are protected by a PodDisruptionBudget [defaults to 1]
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// GCSpec describes the nfd-gc deployment
+type GCSpec struct {
+	// Enabled deploys nfd-gc, which removes the NodeFeature and
+	// NodeResourceTopology objects of the nodes deleted from the cluster
+	// [defaults to true]
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// DeletionPolicy is what happens to the NFD labels of the nodes when the CR
+// is deleted
+// +kubebuilder:validation:Enum=Orphan;Prune;PruneAfterDelay
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyOrphan leaves the NFD labels on the nodes
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+
+	// DeletionPolicyPrune runs the prune job as soon as the CR is deleted
+	DeletionPolicyPrune DeletionPolicy = "Prune"
+
+	// DeletionPolicyPruneAfterDelay runs the prune job once
+	// PrunePolicy.Delay has passed since the deletion of the CR, unless
+	// another CR took over the instance in the meantime
+	DeletionPolicyPruneAfterDelay DeletionPolicy = "PruneAfterDelay"
+)
+
+// PruneFailureAction is what the operator does once the prune job failed
+// +kubebuilder:validation:Enum=Block;RemoveFinalizer
+type PruneFailureAction string
+
+const (
+	// PruneFailureActionBlock keeps the finalizer, so that the CR is not
+	// deleted until a prune job succeeds
+	PruneFailureActionBlock PruneFailureAction = "Block"
+
+	// PruneFailureActionRemoveFinalizer gives up on pruning and removes the
+	// finalizer, leaving the NFD labels on the nodes
+	PruneFailureActionRemoveFinalizer PruneFailureAction = "RemoveFinalizer"
+)
+
+// PrunePolicy describes how long and how many times the prune job runs,
+// and what happens to the CR when it fails. Changes are applied to the
+// next prune job only
+type PrunePolicy struct {
+	// Timeout is how long the prune job may run, retries included
+	// [defaults to 10m]
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="must be at least 1s"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries is the number of times a failed prune pod is retried
+	// [defaults to 3]
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Retries *int32 `json:"retries,omitempty"`
+
+	// TTLAfterFinished is how long a finished prune job is kept, at least
+	// 1m. A prune job that failed while the deletion is blocked is then run
+	// again [defaults to 1h]
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="must be at least 1m"
+	TTLAfterFinished *metav1.Duration `json:"ttlAfterFinished,omitempty"`
+
+	// OnFailure is what happens to the CR once the prune job failed
+	// [defaults to Block]
+	// +optional
+	OnFailure PruneFailureAction `json:"onFailure,omitempty"`
+
+	// Delay is how long the prune job waits after the deletion of the CR
+	// with the PruneAfterDelay deletion policy [defaults to 1h]
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+// MetricsSpec describes the metrics Services and ServiceMonitors of the
+// operands
+type MetricsSpec struct {
+	// Labels are added to the metrics Services and ServiceMonitors, e.g.
+	// to match the serviceMonitorSelector of a Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ServiceMonitor describes the ServiceMonitors created when the
+	// monitoring.coreos.com CRDs are installed
+	// +optional
+	ServiceMonitor ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+
+	// PrometheusRule describes the PrometheusRule alerting on the health
+	// of the operands, created when the monitoring.coreos.com CRDs are
+	// installed
+	// +optional
+	PrometheusRule PrometheusRuleSpec `json:"prometheusRule,omitempty"`
+}
+
+// PrometheusRuleSpec describes the alerts on the health of the operands.
+// The alerts are computed from the metrics of the operator and of the
+// operands, and from the metrics of kube-state-metrics
+type PrometheusRuleSpec struct {
+	// Enabled creates the PrometheusRule if the monitoring.coreos.com CRDs
+	// are installed
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// WorkerUnavailable fires when nfd-worker does not run on all the
+	// eligible nodes [defaults to 15m, warning]
+	// +optional
+	WorkerUnavailable AlertSpec `json:"workerUnavailable,omitempty"`
+
+	// MasterUnavailable fires when nfd-master has no available replica
+	// [defaults to 5m, critical]
+	// +optional
+	MasterUnavailable AlertSpec `json:"masterUnavailable,omitempty"`
+
+	// GCCrashLooping fires when a container of nfd-gc is in
+	// CrashLoopBackOff [defaults to 15m, warning]
+	// +optional
+	GCCrashLooping AlertSpec `json:"gcCrashLooping,omitempty"`
+
+	// PruneJobFailed fires when the prune job run on deletion of the CR
+	// failed [defaults to 0s, warning]
+	// +optional
+	PruneJobFailed AlertSpec `json:"pruneJobFailed,omitempty"`
+
+	// TopologyUpdaterStale fires when nfd-topology-updater fails to scan
+	// the resources of the nodes, so that the NodeResourceTopology objects
+	// are not updated anymore [defaults to 15m, warning]
+	// +optional
+	TopologyUpdaterStale AlertSpec `json:"topologyUpdaterStale,omitempty"`
+}
+
+// AlertSeverity is the value of the severity label of an alert
+// +kubebuilder:validation:Enum=critical;warning;info
+type AlertSeverity string
+
+const (
+	AlertSeverityCritical AlertSeverity = "critical"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityInfo     AlertSeverity = "info"
+)
+
+// AlertSpec tunes an alert of the PrometheusRule
+type AlertSpec struct {
+	// Disabled removes the alert from the PrometheusRule
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// For is how long the alert condition must hold before the alert
+	// fires
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+
+	// Severity is the value of the severity label of the alert
+	// +optional
+	Severity AlertSeverity `json:"severity,omitempty"`
+}
+
+// ServiceMonitorSpec describes the ServiceMonitors scraping the metrics
+// Services of the operands
+type ServiceMonitorSpec struct {
+	// Enabled creates the ServiceMonitors if the monitoring.coreos.com
+	// CRDs are installed [defaults to true]
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Interval at which the metrics are scraped [defaults to the scrape
+	// interval of Prometheus]
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// TLSConfig scrapes the metrics over HTTPS, e.g. through a TLS
+	// terminating proxy in front of the operands
+	// +optional
+	TLSConfig *MetricsTLSConfig `json:"tlsConfig,omitempty"`
+}
+
+// MetricsTLSConfig describes the TLS configuration Prometheus uses to
+// scrape the metrics. The Secrets must be in the namespace of the
+// NodeFeatureDiscovery CR
+type MetricsTLSConfig struct {
+	// CASecret is the key of a Secret holding the CA certificate used to
+	// verify the certificate of the server
+	// +optional
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+
+	// CertSecret is the key of a Secret holding the client certificate
+	// +optional
+	CertSecret *corev1.SecretKeySelector `json:"certSecret,omitempty"`
+
+	// KeySecret is the key of a Secret holding the client key
+	// +optional
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+
+	// ServerName is used to verify the hostname of the server
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of
+	// the server
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// TopologyUpdaterSpec describes the nfd-topology-updater DaemonSet. Its
+// schema is left untyped so that the deprecated boolean form is accepted,
+// the properties of the object form are still validated.
+// +kubebuilder:validation:Type=""
+// +kubebuilder:pruning:PreserveUnknownFields
+type TopologyUpdaterSpec struct {
+	// Enabled deploys nfd-topology-updater
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SleepInterval is the interval between two resource scans
+	// [defaults to 3s]
+	// +optional
+	SleepInterval *metav1.Duration `json:"sleepInterval,omitempty"`
+
+	// KubeletStateDir is the kubelet root directory on the nodes
+	// [defaults to /var/lib/kubelet]
+	// +optional
+	KubeletStateDir string `json:"kubeletStateDir,omitempty"`
+
+	// PodResourcesSocketPath is the path of the kubelet podresources socket
+	// on the nodes [defaults to pod-resources/kubelet.sock in KubeletStateDir]
+	// +optional
+	PodResourcesSocketPath string `json:"podResourcesSocketPath,omitempty"`
+
+	// KubeletConfigURI is the URI nfd-topology-updater reads the kubelet
+	// configuration from, as seen from the container (e.g.
+	// https://${NODE_ADDRESS}:10250/configz). Defaults to the config.yaml
+	// file of KubeletStateDir
+	// +optional
+	KubeletConfigURI string `json:"kubeletConfigURI,omitempty"`
+
+	// ExcludeList lists the resources that are not accounted for, per node
+	// name. The "*" key applies to all the nodes
+	// +optional
+	ExcludeList map[string][]string `json:"excludeList,omitempty"`
+
+	// Tolerations defines tolerations to be applied to the
+	// nfd-topology-updater DaemonSet
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Envs defines environment variables to be added to the
+	// nfd-topology-updater DaemonSet
+	// +optional
+	Envs []corev1.EnvVar `json:"envs,omitempty"`
+}
+
+// UnmarshalJSON accepts the plain boolean topologyUpdater field of older
+// CRs in addition to the TopologyUpdaterSpec object
+func (t *TopologyUpdaterSpec) UnmarshalJSON(data []byte) error {
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err == nil {
+		*t = TopologyUpdaterSpec{Enabled: enabled}
+		return nil
+	}
+	type topologyUpdaterSpec TopologyUpdaterSpec
+	return json.Unmarshal(data, (*topologyUpdaterSpec)(t))
 }
 
 // OperandSpec describes configuration options for the operand
 type OperandSpec struct {
+	// Namespace is the namespace the operands are deployed to. It is
+	// created with privileged Pod Security labels if it does not exist,
+	// and the ServiceAccounts and RBAC of the operands are created in it
+	// [defaults to the namespace of the CR]
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
 	// Image defines the image to pull for the
 	// NFD operand
-	// [defaults to registry.k8s.io/nfd/node-feature-discovery]
+	// [defaults to the image the operator was configured with through
+	// the NODE_FEATURE_DISCOVERY_IMAGE environment variable]
 	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
 	Image string `json:"image,omitempty"`
 
@@ -102,25 +557,345 @@ type OperandSpec struct {
 	// MasterTolerations defines tolerations to be applied to the master deployment
 	MasterTolerations []corev1.Toleration `json:"masterTolerations,omitempty"`
 
+	// GCTolerations defines tolerations to be applied to the gc deployment
+	GCTolerations []corev1.Toleration `json:"gcTolerations,omitempty"`
+
 	// WorkerEnv defines environment variables to be added to the worker Daemonset
 	WorkerEnvs []corev1.EnvVar `json:"workerEnvs,omitempty"`
 
 	// MasterEnv defines environment variables to be added to the master deployment
 	MasterEnvs []corev1.EnvVar `json:"masterEnvs,omitempty"`
+
+	// Resources defines the compute resources of the operand containers
+	// +optional
+	Resources OperandResources `json:"resources,omitempty"`
+
+	// Scheduling defines where the operand pods are scheduled
+	// +optional
+	Scheduling OperandScheduling `json:"scheduling,omitempty"`
+}
+
+// OperandScheduling describes the scheduling of each operand component
+type OperandScheduling struct {
+	// Master defines the scheduling of the nfd-master pods and of the
+	// prune job
+	// +optional
+	Master PodScheduling `json:"master,omitempty"`
+
+	// Worker defines the scheduling of the nfd-worker pods, including the
+	// ones of the worker pools
+	// +optional
+	Worker PodScheduling `json:"worker,omitempty"`
+
+	// GC defines the scheduling of the nfd-gc pods
+	// +optional
+	GC PodScheduling `json:"gc,omitempty"`
+}
+
+// TolerationsPolicy defines how the tolerations given in the CR are
+// combined with the built-in tolerations of a component
+// +kubebuilder:validation:Enum=Append;Replace
+type TolerationsPolicy string
+
+const (
+	// TolerationsPolicyAppend adds the tolerations of the CR to the
+	// built-in ones
+	TolerationsPolicyAppend TolerationsPolicy = "Append"
+
+	// TolerationsPolicyReplace uses the tolerations of the CR only
+	TolerationsPolicyReplace TolerationsPolicy = "Replace"
+)
+
+// PodScheduling describes the scheduling constraints of the pods of an
+// operand component
+type PodScheduling struct {
+	// NodeSelector restricts the pods to the nodes with the given labels
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity replaces the built-in affinity of the component when set
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is the priority class of the pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// TopologySpreadConstraints describes how the pods are spread across
+	// topology domains
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// TolerationsPolicy defines whether the tolerations of the component
+	// given in the CR are appended to the built-in ones or replace them
+	// [defaults to Append]
+	// +optional
+	TolerationsPolicy TolerationsPolicy `json:"tolerationsPolicy,omitempty"`
+}
+
+// OperandResources describes the compute resources of each operand
+// component. A component that is not set gets built-in defaults; set it
+// to an empty object to run the component without requests and limits.
+type OperandResources struct {
+	// Master defines the resources of the nfd-master container
+	// +optional
+	Master *corev1.ResourceRequirements `json:"master,omitempty"`
+
+	// Worker defines the resources of the nfd-worker containers
+	// +optional
+	Worker *corev1.ResourceRequirements `json:"worker,omitempty"`
+
+	// TopologyUpdater defines the resources of the nfd-topology-updater
+	// containers
+	// +optional
+	TopologyUpdater *corev1.ResourceRequirements `json:"topologyUpdater,omitempty"`
+
+	// GC defines the resources of the nfd-gc container
+	// +optional
+	GC *corev1.ResourceRequirements `json:"gc,omitempty"`
+
+	// Prune defines the resources of the nfd-master prune job container
+	// +optional
+	Prune *corev1.ResourceRequirements `json:"prune,omitempty"`
 }
 
-// ConfigMap describes configuration options for the NFD worker
+// WorkerPool describes a group of nodes running nfd-worker with the same
+// configuration
+type WorkerPool struct {
+	// Name of the pool. The worker DaemonSet and ConfigMap of the pool
+	// are suffixed with it, after a double hyphen
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+
+	// NodeSelector selects the nodes of the pool
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations defines tolerations to be applied to the worker pods of
+	// the pool, in addition to the default and the Operand.WorkerTolerations ones
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Config describes the nfd-worker configuration of the pool
+	// +optional
+	Config ConfigMap `json:"config,omitempty"`
+}
+
+// ConfigMap describes configuration options for the NFD worker. The
+// configuration can be given as a reference to a user managed ConfigMap
+// in ConfigMapRef, as a raw nfd-worker.conf in ConfigData, or with the
+// typed Core and Sources fields, in that order of precedence.
 type ConfigMap struct {
-	// BinaryData holds the NFD configuration file
-	ConfigData string `json:"configData"`
+	// ConfigMapRef references a user managed ConfigMap holding the
+	// nfd-worker configuration. When set, the operator mounts it into the
+	// worker pods instead of creating its own ConfigMap, and ConfigData
+	// and the typed fields are ignored.
+	// +optional
+	ConfigMapRef *WorkerConfigMapRef `json:"configMapRef,omitempty"`
+
+	// ConfigData holds the raw NFD worker configuration file. When set, it
+	// is used verbatim and the typed Core and Sources fields are ignored.
+	// +optional
+	ConfigData string `json:"configData,omitempty"`
+
+	// Core holds the core configuration of nfd-worker
+	// +optional
+	Core *WorkerCoreConfig `json:"core,omitempty"`
+
+	// Sources holds the configuration of the individual feature sources
+	// +optional
+	Sources *WorkerSourcesConfig `json:"sources,omitempty"`
+}
+
+// WorkerConfigMapRef references a key of a ConfigMap in the operand
+// namespace, i.e. the namespace the worker pods run in
+type WorkerConfigMapRef struct {
+	// Name of the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key of the ConfigMap holding the nfd-worker configuration
+	// [defaults to nfd-worker.conf]
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// WorkerCoreConfig describes the core section of the nfd-worker configuration
+type WorkerCoreConfig struct {
+	// SleepInterval is the delay between consecutive labeling passes
+	// +optional
+	SleepInterval *metav1.Duration `json:"sleepInterval,omitempty"`
+
+	// FeatureSources is the list of enabled feature sources. A special
+	// value "all" enables all sources, prefixing a source with "-"
+	// disables it
+	// +optional
+	FeatureSources []string `json:"featureSources,omitempty"`
+
+	// LabelSources is the list of enabled label sources. A special
+	// value "all" enables all sources, prefixing a source with "-"
+	// disables it
+	// +optional
+	LabelSources []string `json:"labelSources,omitempty"`
+
+	// LabelWhiteList is a regular expression for filtering feature labels
+	// based on their name
+	// +optional
+	LabelWhiteList string `json:"labelWhiteList,omitempty"`
+
+	// NoPublish disables all communication with nfd-master, for testing
+	// and debugging purposes
+	// +optional
+	NoPublish bool `json:"noPublish,omitempty"`
+}
+
+// WorkerSourcesConfig describes the per-source settings of nfd-worker
+type WorkerSourcesConfig struct {
+	// CPU holds the configuration of the cpu feature source
+	// +optional
+	CPU *CPUSourceConfig `json:"cpu,omitempty"`
+
+	// Kernel holds the configuration of the kernel feature source
+	// +optional
+	Kernel *KernelSourceConfig `json:"kernel,omitempty"`
+
+	// PCI holds the configuration of the pci feature source
+	// +optional
+	PCI *DeviceSourceConfig `json:"pci,omitempty"`
+
+	// USB holds the configuration of the usb feature source
+	// +optional
+	USB *DeviceSourceConfig `json:"usb,omitempty"`
+
+	// Custom holds the custom feature labeling rules. The rules are
+	// passed to nfd-worker as is, see the nfd-worker configuration
+	// reference for their format
+	// +optional
+	Custom []runtime.RawExtension `json:"custom,omitempty"`
+}
+
+// CPUSourceConfig describes the configuration of the cpu feature source
+type CPUSourceConfig struct {
+	// CPUID holds the cpuid attribute filters
+	// +optional
+	CPUID *CPUIDConfig `json:"cpuid,omitempty"`
+}
+
+// CPUIDConfig describes which cpuid attributes are published as labels.
+// AttributeWhitelist has priority over AttributeBlacklist
+type CPUIDConfig struct {
+	// AttributeBlacklist is the list of cpuid attributes to not publish
+	// +optional
+	AttributeBlacklist []string `json:"attributeBlacklist,omitempty"`
+
+	// AttributeWhitelist is the list of cpuid attributes to publish
+	// +optional
+	AttributeWhitelist []string `json:"attributeWhitelist,omitempty"`
+}
+
+// KernelSourceConfig describes the configuration of the kernel feature source
+type KernelSourceConfig struct {
+	// KconfigFile is the path of the kernel config file to read
+	// +optional
+	KconfigFile string `json:"kconfigFile,omitempty"`
+
+	// ConfigOpts is the list of kernel config options to publish as labels
+	// +optional
+	ConfigOpts []string `json:"configOpts,omitempty"`
+}
+
+// DeviceSourceConfig describes the configuration of the pci and usb
+// feature sources
+type DeviceSourceConfig struct {
+	// DeviceClassWhitelist is the list of device classes to publish
+	// +optional
+	DeviceClassWhitelist []string `json:"deviceClassWhitelist,omitempty"`
+
+	// DeviceLabelFields is the list of device attributes to use in the
+	// label names
+	// +optional
+	DeviceLabelFields []string `json:"deviceLabelFields,omitempty"`
 }
 
 // NodeFeatureDiscoveryStatus defines the observed state of NodeFeatureDiscovery
 // +k8s:openapi-gen=true
 type NodeFeatureDiscoveryStatus struct {
+	// ObservedGeneration is the generation of the spec the status was
+	// computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Conditions represents the latest available observations of current state.
 	// +optional
+	// +listType=map
+	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// WorkerPools represents the latest observed state of the worker pools
+	// +optional
+	WorkerPools []WorkerPoolStatus `json:"workerPools,omitempty"`
+
+	// Components represents the latest observed state of every enabled
+	// component
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// OperandNamespace is the namespace the operands were last deployed
+	// to, their objects are removed from it when spec.operand.namespace
+	// changes
+	// +optional
+	OperandNamespace string `json:"operandNamespace,omitempty"`
+}
+
+// ComponentStatus describes the observed state of the Deployment or
+// DaemonSet of a component
+type ComponentStatus struct {
+	// Name of the component: master, worker, worker-<pool name>,
+	// topology-updater or gc
+	Name string `json:"name"`
+
+	// Kind of the object running the component, Deployment or DaemonSet
+	Kind string `json:"kind"`
+
+	// Desired is the number of pods the component should run
+	Desired int32 `json:"desired"`
+
+	// Ready is the number of ready pods of the component
+	Ready int32 `json:"ready"`
+
+	// Updated is the number of pods of the component running the latest
+	// pod template
+	Updated int32 `json:"updated"`
+
+	// Image run by the component
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// LastError explains why the component is not available, it is empty
+	// when the component is available
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// WorkerPoolStatus describes the observed state of the worker DaemonSet of
+// a worker pool
+type WorkerPoolStatus struct {
+	// Name of the pool
+	Name string `json:"name"`
+
+	// DesiredNumberScheduled is the number of nodes that should be running
+	// the worker pod of the pool
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+
+	// NumberReady is the number of nodes running a ready worker pod of the pool
+	NumberReady int32 `json:"numberReady"`
+
+	// UpdatedNumberScheduled is the number of nodes running an up to date
+	// worker pod of the pool
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled"`
 }
 
 // +kubebuilder:object:root=true
@@ -150,9 +925,22 @@ func init() {
 	SchemeBuilder.Register(&NodeFeatureDiscovery{}, &NodeFeatureDiscoveryList{})
 }
 
-// ImagePath returns a compiled full valid image string
-func (o *OperandSpec) ImagePath() string {
-	return o.Image
+// GetOperandNamespace returns the namespace the operands are deployed to,
+// falling back to the namespace of the CR
+func (n *NodeFeatureDiscovery) GetOperandNamespace() string {
+	if n.Spec.Operand.Namespace != "" {
+		return n.Spec.Operand.Namespace
+	}
+	return n.Namespace
+}
+
+// ImagePath returns the image of the operands, falling back to
+// defaultImage, the image the operator was configured with
+func (o *OperandSpec) ImagePath(defaultImage string) string {
+	if o.Image != "" {
+		return o.Image
+	}
+	return defaultImage
 }
 
 // ImagePolicy returns a valid corev1.PullPolicy from the string in the CR
@@ -166,7 +954,224 @@ func (o *OperandSpec) ImagePolicy(pullPolicy string) corev1.PullPolicy {
 	return corev1.PullIfNotPresent
 }
 
+// GetImagePullPolicy returns the pull policy of the operand images,
+// falling back to DefaultImagePullPolicy
+func (o *OperandSpec) GetImagePullPolicy() corev1.PullPolicy {
+	if o.ImagePullPolicy != "" {
+		return corev1.PullPolicy(o.ImagePullPolicy)
+	}
+	return DefaultImagePullPolicy
+}
+
+// GetServicePort returns the port nfd-master listens on, falling back
+// to DefaultServicePort
+func (o *OperandSpec) GetServicePort() int {
+	if o.ServicePort != 0 {
+		return o.ServicePort
+	}
+	return DefaultServicePort
+}
+
+// GetMasterResources returns the resources of the nfd-master container,
+// falling back to DefaultMasterResources
+func (o *OperandSpec) GetMasterResources() corev1.ResourceRequirements {
+	return getResources(o.Resources.Master, &DefaultMasterResources)
+}
+
+// GetWorkerResources returns the resources of the nfd-worker containers,
+// falling back to DefaultWorkerResources
+func (o *OperandSpec) GetWorkerResources() corev1.ResourceRequirements {
+	return getResources(o.Resources.Worker, &DefaultWorkerResources)
+}
+
+// GetTopologyUpdaterResources returns the resources of the
+// nfd-topology-updater containers, falling back to
+// DefaultTopologyUpdaterResources
+func (o *OperandSpec) GetTopologyUpdaterResources() corev1.ResourceRequirements {
+	return getResources(o.Resources.TopologyUpdater, &DefaultTopologyUpdaterResources)
+}
+
+// GetGCResources returns the resources of the nfd-gc container, falling
+// back to DefaultGCResources
+func (o *OperandSpec) GetGCResources() corev1.ResourceRequirements {
+	return getResources(o.Resources.GC, &DefaultGCResources)
+}
+
+// GetPruneResources returns the resources of the prune job container,
+// falling back to DefaultPruneResources
+func (o *OperandSpec) GetPruneResources() corev1.ResourceRequirements {
+	return getResources(o.Resources.Prune, &DefaultPruneResources)
+}
+
+func getResources(resources, defaultResources *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if resources != nil {
+		return *resources.DeepCopy()
+	}
+	return *defaultResources.DeepCopy()
+}
+
+// GetSleepInterval returns the interval between two resource scans,
+// falling back to DefaultTopologyUpdaterSleepInterval
+func (t *TopologyUpdaterSpec) GetSleepInterval() time.Duration {
+	if t.SleepInterval != nil {
+		return t.SleepInterval.Duration
+	}
+	return DefaultTopologyUpdaterSleepInterval
+}
+
+// GetKubeletStateDir returns the kubelet root directory on the nodes,
+// falling back to DefaultKubeletStateDir
+func (t *TopologyUpdaterSpec) GetKubeletStateDir() string {
+	if t.KubeletStateDir != "" {
+		return t.KubeletStateDir
+	}
+	return DefaultKubeletStateDir
+}
+
+// GetPodResourcesSocketPath returns the path of the kubelet podresources
+// socket on the nodes, falling back to the one of the kubelet state
+// directory
+func (t *TopologyUpdaterSpec) GetPodResourcesSocketPath() string {
+	if t.PodResourcesSocketPath != "" {
+		return t.PodResourcesSocketPath
+	}
+	return path.Join(t.GetKubeletStateDir(), "pod-resources", "kubelet.sock")
+}
+
+// GetDeletionPolicy returns what happens to the NFD labels when the CR is
+// deleted, falling back to the deprecated PruneOnDelete
+func (s *NodeFeatureDiscoverySpec) GetDeletionPolicy() DeletionPolicy {
+	if s.DeletionPolicy != "" {
+		return s.DeletionPolicy
+	}
+	if s.PruneOnDelete {
+		return DeletionPolicyPrune
+	}
+	return DeletionPolicyOrphan
+}
+
+// GetTimeout returns how long the prune job may run, falling back to
+// DefaultPruneTimeout
+func (p *PrunePolicy) GetTimeout() time.Duration {
+	if p.Timeout != nil {
+		return p.Timeout.Duration
+	}
+	return DefaultPruneTimeout
+}
+
+// GetRetries returns the number of times a failed prune pod is retried,
+// falling back to DefaultPruneRetries
+func (p *PrunePolicy) GetRetries() int32 {
+	if p.Retries != nil {
+		return *p.Retries
+	}
+	return DefaultPruneRetries
+}
+
+// GetTTLAfterFinished returns how long a finished prune job is kept,
+// falling back to DefaultPruneTTLAfterFinished
+func (p *PrunePolicy) GetTTLAfterFinished() time.Duration {
+	if p.TTLAfterFinished != nil {
+		return p.TTLAfterFinished.Duration
+	}
+	return DefaultPruneTTLAfterFinished
+}
+
+// GetDelay returns how long the prune job waits after the deletion of the
+// CR, falling back to DefaultPruneDelay
+func (p *PrunePolicy) GetDelay() time.Duration {
+	if p.Delay != nil {
+		return p.Delay.Duration
+	}
+	return DefaultPruneDelay
+}
+
+// GetOnFailure returns what happens to the CR once the prune job failed,
+// falling back to PruneFailureActionBlock
+func (p *PrunePolicy) GetOnFailure() PruneFailureAction {
+	if p.OnFailure != "" {
+		return p.OnFailure
+	}
+	return PruneFailureActionBlock
+}
+
+// IsEnabled returns true unless nfd-gc is explicitly disabled
+func (g *GCSpec) IsEnabled() bool {
+	return g.Enabled == nil || *g.Enabled
+}
+
+// GetFor returns how long the alert condition must hold, falling back to
+// defaultFor
+func (a *AlertSpec) GetFor(defaultFor time.Duration) time.Duration {
+	if a.For != nil {
+		return a.For.Duration
+	}
+	return defaultFor
+}
+
+// GetSeverity returns the severity of the alert, falling back to
+// defaultSeverity
+func (a *AlertSpec) GetSeverity(defaultSeverity AlertSeverity) AlertSeverity {
+	if a.Severity != "" {
+		return a.Severity
+	}
+	return defaultSeverity
+}
+
+// IsEnabled returns true unless the ServiceMonitors are explicitly disabled
+func (s *ServiceMonitorSpec) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// GetReplicas returns the number of nfd-master replicas, falling back to
+// DefaultMasterReplicas
+func (m *MasterSpec) GetReplicas() int32 {
+	if m.Replicas != nil {
+		return *m.Replicas
+	}
+	return DefaultMasterReplicas
+}
+
+// IsHighlyAvailable returns true if more than one nfd-master replica is
+// requested
+func (m *MasterSpec) IsHighlyAvailable() bool {
+	return m.GetReplicas() > 1
+}
+
+// GetAffinity returns the affinity of the pods, falling back to
+// defaultAffinity
+func (s *PodScheduling) GetAffinity(defaultAffinity *corev1.Affinity) *corev1.Affinity {
+	if s.Affinity != nil {
+		return s.Affinity.DeepCopy()
+	}
+	return defaultAffinity
+}
+
+// GetTolerations combines the built-in defaultTolerations of a component
+// with the tolerations given in the CR, according to TolerationsPolicy
+func (s *PodScheduling) GetTolerations(defaultTolerations, tolerations []corev1.Toleration) []corev1.Toleration {
+	if s.TolerationsPolicy == TolerationsPolicyReplace {
+		return tolerations
+	}
+	return append(defaultTolerations, tolerations...)
+}
+
 // Data returns a valid ConfigMap name
 func (c *ConfigMap) Data() string {
 	return c.ConfigData
 }
+
+// IsTyped returns true if the worker configuration is given with the
+// typed fields instead of the raw ConfigData or a ConfigMap reference
+func (c *ConfigMap) IsTyped() bool {
+	return c.ConfigMapRef == nil && c.ConfigData == "" && (c.Core != nil || c.Sources != nil)
+}
+
+// GetKey returns the key of the referenced ConfigMap, falling back to
+// DefaultWorkerConfigMapKey
+func (r *WorkerConfigMapRef) GetKey() string {
+	if r.Key != "" {
+		return r.Key
+	}
+	return DefaultWorkerConfigMapKey
+}
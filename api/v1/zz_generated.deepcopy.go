@@ -24,12 +24,107 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSpec) DeepCopyInto(out *AlertSpec) {
+	*out = *in
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSpec.
+func (in *AlertSpec) DeepCopy() *AlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUIDConfig) DeepCopyInto(out *CPUIDConfig) {
+	*out = *in
+	if in.AttributeBlacklist != nil {
+		in, out := &in.AttributeBlacklist, &out.AttributeBlacklist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttributeWhitelist != nil {
+		in, out := &in.AttributeWhitelist, &out.AttributeWhitelist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUIDConfig.
+func (in *CPUIDConfig) DeepCopy() *CPUIDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUIDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUSourceConfig) DeepCopyInto(out *CPUSourceConfig) {
+	*out = *in
+	if in.CPUID != nil {
+		in, out := &in.CPUID, &out.CPUID
+		*out = new(CPUIDConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUSourceConfig.
+func (in *CPUSourceConfig) DeepCopy() *CPUSourceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUSourceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMap) DeepCopyInto(out *ConfigMap) {
 	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(WorkerConfigMapRef)
+		**out = **in
+	}
+	if in.Core != nil {
+		in, out := &in.Core, &out.Core
+		*out = new(WorkerCoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = new(WorkerSourcesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMap.
@@ -42,6 +137,145 @@ func (in *ConfigMap) DeepCopy() *ConfigMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceSourceConfig) DeepCopyInto(out *DeviceSourceConfig) {
+	*out = *in
+	if in.DeviceClassWhitelist != nil {
+		in, out := &in.DeviceClassWhitelist, &out.DeviceClassWhitelist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeviceLabelFields != nil {
+		in, out := &in.DeviceLabelFields, &out.DeviceLabelFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceSourceConfig.
+func (in *DeviceSourceConfig) DeepCopy() *DeviceSourceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceSourceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSpec) DeepCopyInto(out *GCSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSpec.
+func (in *GCSpec) DeepCopy() *GCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelSourceConfig) DeepCopyInto(out *KernelSourceConfig) {
+	*out = *in
+	if in.ConfigOpts != nil {
+		in, out := &in.ConfigOpts, &out.ConfigOpts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelSourceConfig.
+func (in *KernelSourceConfig) DeepCopy() *KernelSourceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelSourceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterSpec) DeepCopyInto(out *MasterSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterSpec.
+func (in *MasterSpec) DeepCopy() *MasterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+	in.PrometheusRule.DeepCopyInto(&out.PrometheusRule)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
+func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsTLSConfig) DeepCopyInto(out *MetricsTLSConfig) {
+	*out = *in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertSecret != nil {
+		in, out := &in.CertSecret, &out.CertSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsTLSConfig.
+func (in *MetricsTLSConfig) DeepCopy() *MetricsTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeFeatureDiscovery) DeepCopyInto(out *NodeFeatureDiscovery) {
 	*out = *in
@@ -105,6 +339,8 @@ func (in *NodeFeatureDiscoveryList) DeepCopyObject() runtime.Object {
 func (in *NodeFeatureDiscoverySpec) DeepCopyInto(out *NodeFeatureDiscoverySpec) {
 	*out = *in
 	in.Operand.DeepCopyInto(&out.Operand)
+	in.Master.DeepCopyInto(&out.Master)
+	in.TopologyUpdater.DeepCopyInto(&out.TopologyUpdater)
 	if in.ExtraLabelNs != nil {
 		in, out := &in.ExtraLabelNs, &out.ExtraLabelNs
 		*out = make([]string, len(*in))
@@ -115,7 +351,21 @@ func (in *NodeFeatureDiscoverySpec) DeepCopyInto(out *NodeFeatureDiscoverySpec)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.WorkerConfig = in.WorkerConfig
+	in.WorkerConfig.DeepCopyInto(&out.WorkerConfig)
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]WorkerPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PrunePolicy.DeepCopyInto(&out.PrunePolicy)
+	in.GC.DeepCopyInto(&out.GC)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFeatureDiscoverySpec.
@@ -138,6 +388,16 @@ func (in *NodeFeatureDiscoveryStatus) DeepCopyInto(out *NodeFeatureDiscoveryStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]WorkerPoolStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFeatureDiscoveryStatus.
@@ -150,6 +410,64 @@ func (in *NodeFeatureDiscoveryStatus) DeepCopy() *NodeFeatureDiscoveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandResources) DeepCopyInto(out *OperandResources) {
+	*out = *in
+	if in.Master != nil {
+		in, out := &in.Master, &out.Master
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Worker != nil {
+		in, out := &in.Worker, &out.Worker
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologyUpdater != nil {
+		in, out := &in.TopologyUpdater, &out.TopologyUpdater
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GC != nil {
+		in, out := &in.GC, &out.GC
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandResources.
+func (in *OperandResources) DeepCopy() *OperandResources {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandScheduling) DeepCopyInto(out *OperandScheduling) {
+	*out = *in
+	in.Master.DeepCopyInto(&out.Master)
+	in.Worker.DeepCopyInto(&out.Worker)
+	in.GC.DeepCopyInto(&out.GC)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandScheduling.
+func (in *OperandScheduling) DeepCopy() *OperandScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperandSpec) DeepCopyInto(out *OperandSpec) {
 	*out = *in
@@ -167,6 +485,13 @@ func (in *OperandSpec) DeepCopyInto(out *OperandSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GCTolerations != nil {
+		in, out := &in.GCTolerations, &out.GCTolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WorkerEnvs != nil {
 		in, out := &in.WorkerEnvs, &out.WorkerEnvs
 		*out = make([]corev1.EnvVar, len(*in))
@@ -181,6 +506,8 @@ func (in *OperandSpec) DeepCopyInto(out *OperandSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandSpec.
@@ -192,3 +519,304 @@ func (in *OperandSpec) DeepCopy() *OperandSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodScheduling) DeepCopyInto(out *PodScheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodScheduling.
+func (in *PodScheduling) DeepCopy() *PodScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(PodScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
+	*out = *in
+	in.WorkerUnavailable.DeepCopyInto(&out.WorkerUnavailable)
+	in.MasterUnavailable.DeepCopyInto(&out.MasterUnavailable)
+	in.GCCrashLooping.DeepCopyInto(&out.GCCrashLooping)
+	in.PruneJobFailed.DeepCopyInto(&out.PruneJobFailed)
+	in.TopologyUpdaterStale.DeepCopyInto(&out.TopologyUpdaterStale)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
+func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunePolicy) DeepCopyInto(out *PrunePolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLAfterFinished != nil {
+		in, out := &in.TTLAfterFinished, &out.TTLAfterFinished
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunePolicy.
+func (in *PrunePolicy) DeepCopy() *PrunePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorSpec) DeepCopyInto(out *ServiceMonitorSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(MetricsTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorSpec.
+func (in *ServiceMonitorSpec) DeepCopy() *ServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyUpdaterSpec) DeepCopyInto(out *TopologyUpdaterSpec) {
+	*out = *in
+	if in.SleepInterval != nil {
+		in, out := &in.SleepInterval, &out.SleepInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExcludeList != nil {
+		in, out := &in.ExcludeList, &out.ExcludeList
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyUpdaterSpec.
+func (in *TopologyUpdaterSpec) DeepCopy() *TopologyUpdaterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyUpdaterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerConfigMapRef) DeepCopyInto(out *WorkerConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerConfigMapRef.
+func (in *WorkerConfigMapRef) DeepCopy() *WorkerConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerCoreConfig) DeepCopyInto(out *WorkerCoreConfig) {
+	*out = *in
+	if in.SleepInterval != nil {
+		in, out := &in.SleepInterval, &out.SleepInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FeatureSources != nil {
+		in, out := &in.FeatureSources, &out.FeatureSources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSources != nil {
+		in, out := &in.LabelSources, &out.LabelSources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerCoreConfig.
+func (in *WorkerCoreConfig) DeepCopy() *WorkerCoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerCoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPool) DeepCopyInto(out *WorkerPool) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPool.
+func (in *WorkerPool) DeepCopy() *WorkerPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPoolStatus) DeepCopyInto(out *WorkerPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPoolStatus.
+func (in *WorkerPoolStatus) DeepCopy() *WorkerPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSourcesConfig) DeepCopyInto(out *WorkerSourcesConfig) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(CPUSourceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kernel != nil {
+		in, out := &in.Kernel, &out.Kernel
+		*out = new(KernelSourceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PCI != nil {
+		in, out := &in.PCI, &out.PCI
+		*out = new(DeviceSourceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.USB != nil {
+		in, out := &in.USB, &out.USB
+		*out = new(DeviceSourceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSourcesConfig.
+func (in *WorkerSourcesConfig) DeepCopy() *WorkerSourcesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSourcesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -25,15 +25,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+	"sigs.k8s.io/yaml"
 )
 
 //go:generate mockgen -source=configmap.go -package=configmap -destination=mock_configmap.go ConfigMapAPI
 
 type ConfigMapAPI interface {
+	SetMasterConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, masterCM *corev1.ConfigMap) error
 	SetWorkerConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, workerCM *corev1.ConfigMap) error
-	DeleteConfigMap(ctx context.Context, namespace, name string) error
+	SetTopologyUpdaterConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, topologyCM *corev1.ConfigMap) error
+	SetWorkerPoolConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pool *nfdv1.WorkerPool, workerCM *corev1.ConfigMap) error
+	ListWorkerPoolConfigMaps(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]corev1.ConfigMap, error)
+	DeleteConfigMap(ctx context.Context, namespace, name string) (bool, error)
+	GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error)
 }
 
 type configMap struct {
@@ -49,13 +56,135 @@ func NewConfigMapAPI(client client.Client, scheme *runtime.Scheme) ConfigMapAPI
 }
 
 func (c *configMap) SetWorkerConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, cm *corev1.ConfigMap) error {
+	configData, err := getWorkerConfigData(&nfdInstance.Spec.WorkerConfig)
+	if err != nil {
+		return err
+	}
+
+	cm.Data = map[string]string{"nfd-worker-conf": configData}
+
+	return ownership.SetOwner(nfdInstance, cm, c.scheme)
+}
+
+// masterConfigFile mirrors the part of nfd-master.conf set by the operator
+type masterConfigFile struct {
+	Restrictions masterRestrictions `json:"restrictions"`
+}
+
+type masterRestrictions struct {
+	NodeFeatureNamespaceSelector *metav1.LabelSelector `json:"nodeFeatureNamespaceSelector"`
+}
+
+// SetMasterConfigMapAsDesired restricts the nfd-master of an instance, named
+// or not, to the NodeFeature objects created in its operand namespace, i.e.
+// to the ones of its own nfd-worker, nfd-worker not being instance aware
+func (c *configMap) SetMasterConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, cm *corev1.ConfigMap) error {
+	data, err := yaml.Marshal(&masterConfigFile{
+		Restrictions: masterRestrictions{
+			NodeFeatureNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: nfdInstance.GetOperandNamespace()},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render the master configuration: %w", err)
+	}
+
+	cm.Data = map[string]string{"nfd-master.conf": string(data)}
+
+	return ownership.SetOwner(nfdInstance, cm, c.scheme)
+}
+
+func (c *configMap) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm)
+	return cm, err
+}
 
-	cm.Data = map[string]string{"nfd-worker-conf": nfdInstance.Spec.WorkerConfig.ConfigData}
+// workerConfigFile mirrors the layout of nfd-worker.conf
+type workerConfigFile struct {
+	Core    *nfdv1.WorkerCoreConfig    `json:"core,omitempty"`
+	Sources *nfdv1.WorkerSourcesConfig `json:"sources,omitempty"`
+}
 
-	return controllerutil.SetControllerReference(nfdInstance, cm, c.scheme)
+// getWorkerConfigData returns the content of nfd-worker.conf. The raw
+// ConfigData takes precedence over the typed configuration, and
+// DefaultWorkerConfig is used when neither is set
+func getWorkerConfigData(workerConfig *nfdv1.ConfigMap) (string, error) {
+	if !workerConfig.IsTyped() {
+		if workerConfig.ConfigData == "" {
+			return nfdv1.DefaultWorkerConfig, nil
+		}
+		return workerConfig.ConfigData, nil
+	}
+	data, err := yaml.Marshal(&workerConfigFile{
+		Core:    workerConfig.Core,
+		Sources: workerConfig.Sources,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render the worker configuration: %w", err)
+	}
+	return string(data), nil
+}
+
+func (c *configMap) SetWorkerPoolConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pool *nfdv1.WorkerPool, cm *corev1.ConfigMap) error {
+	configData, err := getWorkerConfigData(&pool.Config)
+	if err != nil {
+		return err
+	}
+
+	cm.Labels = map[string]string{names.WorkerPoolLabel: pool.Name}
+	cm.Data = map[string]string{"nfd-worker-conf": configData}
+
+	return ownership.SetOwner(nfdInstance, cm, c.scheme)
+}
+
+func (c *configMap) SetTopologyUpdaterConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, cm *corev1.ConfigMap) error {
+	configData, err := getTopologyUpdaterConfigData(&nfdInstance.Spec.TopologyUpdater)
+	if err != nil {
+		return err
+	}
+
+	cm.Data = map[string]string{"nfd-topology-updater.conf": configData}
+
+	return ownership.SetOwner(nfdInstance, cm, c.scheme)
+}
+
+// topologyUpdaterConfigFile mirrors the layout of nfd-topology-updater.conf
+type topologyUpdaterConfigFile struct {
+	ExcludeList map[string][]string `json:"excludeList,omitempty"`
+}
+
+// getTopologyUpdaterConfigData returns the content of nfd-topology-updater.conf
+func getTopologyUpdaterConfigData(topologyUpdater *nfdv1.TopologyUpdaterSpec) (string, error) {
+	if len(topologyUpdater.ExcludeList) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(&topologyUpdaterConfigFile{ExcludeList: topologyUpdater.ExcludeList})
+	if err != nil {
+		return "", fmt.Errorf("failed to render the topology updater configuration: %w", err)
+	}
+	return string(data), nil
+}
+
+func (c *configMap) ListWorkerPoolConfigMaps(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]corev1.ConfigMap, error) {
+	cmList := corev1.ConfigMapList{}
+	err := c.client.List(ctx, &cmList, client.InNamespace(nfdInstance.GetOperandNamespace()), client.HasLabels{names.WorkerPoolLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worker pool configmaps in namespace %s: %w", nfdInstance.GetOperandNamespace(), err)
+	}
+	// several NFD instances can share the namespace
+	owned := make([]corev1.ConfigMap, 0, len(cmList.Items))
+	for _, cm := range cmList.Items {
+		if ownership.IsOwnedBy(&cm, nfdInstance) {
+			owned = append(owned, cm)
+		}
+	}
+	return owned, nil
 }
 
-func (c *configMap) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+// DeleteConfigMap deletes the ConfigMap, if it exists, and reports whether it existed
+func (c *configMap) DeleteConfigMap(ctx context.Context, namespace, name string) (bool, error) {
 	cm := corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -63,8 +192,8 @@ func (c *configMap) DeleteConfigMap(ctx context.Context, namespace, name string)
 		},
 	}
 	err := c.client.Delete(ctx, &cm)
-	if err != nil && client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to delete configmap %s/%s: %w", namespace, name, err)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete configmap %s/%s: %w", namespace, name, err)
 	}
-	return nil
+	return err == nil, nil
 }
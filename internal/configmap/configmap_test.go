@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -27,7 +28,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
 	"sigs.k8s.io/yaml"
@@ -77,6 +81,208 @@ var _ = Describe("SetWorkerDaemonsetAsDesired", func() {
 	})
 })
 
+var _ = Describe("SetMasterConfigMapAsDesired", func() {
+	var (
+		configmapAPI ConfigMapAPI
+	)
+
+	BeforeEach(func() {
+		configmapAPI = NewConfigMapAPI(nil, scheme)
+	})
+
+	ctx := context.Background()
+
+	It("master is restricted to the NodeFeature objects of the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+				Operand:  nfdv1.OperandSpec{Namespace: "nfd-blue"},
+			},
+		}
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-master-blue", Namespace: "nfd-blue"},
+		}
+
+		err := configmapAPI.SetMasterConfigMapAsDesired(ctx, &nfdCR, &cm)
+		Expect(err).To(BeNil())
+		Expect(cm.Data).To(Equal(map[string]string{
+			"nfd-master.conf": "restrictions:\n  nodeFeatureNamespaceSelector:\n    matchLabels:\n      kubernetes.io/metadata.name: nfd-blue\n",
+		}))
+	})
+})
+
+var _ = Describe("SetWorkerPoolConfigMapAsDesired", func() {
+	var (
+		configmapAPI ConfigMapAPI
+	)
+
+	BeforeEach(func() {
+		configmapAPI = NewConfigMapAPI(nil, scheme)
+	})
+
+	ctx := context.Background()
+
+	It("worker pool config populated with correct values", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+		}
+		pool := nfdv1.WorkerPool{
+			Name:   "gpu",
+			Config: nfdv1.ConfigMap{ConfigData: "core:\n  sleepInterval: 10s\n"},
+		}
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker--gpu", Namespace: "test-namespace"},
+		}
+
+		err := configmapAPI.SetWorkerPoolConfigMapAsDesired(ctx, &nfdCR, &pool, &cm)
+		Expect(err).To(BeNil())
+		Expect(cm.Labels).To(Equal(map[string]string{"nfd.kubernetes.io/worker-pool": "gpu"}))
+		Expect(cm.Data).To(Equal(map[string]string{"nfd-worker-conf": "core:\n  sleepInterval: 10s\n"}))
+		Expect(metav1.IsControlledBy(&cm, &nfdCR)).To(BeTrue())
+	})
+})
+
+var _ = Describe("SetTopologyUpdaterConfigMapAsDesired", func() {
+	var (
+		configmapAPI ConfigMapAPI
+	)
+
+	BeforeEach(func() {
+		configmapAPI = NewConfigMapAPI(nil, scheme)
+	})
+
+	ctx := context.Background()
+
+	DescribeTable("rendering nfd-topology-updater.conf", func(excludeList map[string][]string, expectedData string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true, ExcludeList: excludeList},
+			},
+		}
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-topology-updater", Namespace: "test-namespace"},
+		}
+
+		err := configmapAPI.SetTopologyUpdaterConfigMapAsDesired(ctx, &nfdCR, &cm)
+		Expect(err).To(BeNil())
+		Expect(cm.Data).To(Equal(map[string]string{"nfd-topology-updater.conf": expectedData}))
+		Expect(metav1.IsControlledBy(&cm, &nfdCR)).To(BeTrue())
+	},
+		Entry("no exclude list", nil, ""),
+		Entry("exclude list", map[string][]string{
+			"*":      {"memory"},
+			"node-1": {"cpu", "hugepages-2Mi"},
+		},
+			"excludeList:\n"+
+				"  '*':\n"+
+				"  - memory\n"+
+				"  node-1:\n"+
+				"  - cpu\n"+
+				"  - hugepages-2Mi\n"),
+	)
+})
+
+var _ = Describe("ListWorkerPoolConfigMaps", func() {
+	var (
+		ctrl  *gomock.Controller
+		clnt  *client.MockClient
+		cmAPI ConfigMapAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		cmAPI = NewConfigMapAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace", UID: "nfd-uid"},
+	}
+
+	It("only the configmaps controlled by the NFD CR are returned", func() {
+		ownedCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nfd-worker--gpu",
+				OwnerReferences: []metav1.OwnerReference{{UID: "nfd-uid", Controller: ptr.To(true)}},
+			},
+		}
+		otherCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-cm"},
+		}
+		clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.InNamespace("test-namespace"), ctrlclient.HasLabels{"nfd.kubernetes.io/worker-pool"}).DoAndReturn(
+			func(_ interface{}, list *corev1.ConfigMapList, _ ...ctrlclient.ListOption) error {
+				list.Items = []corev1.ConfigMap{ownedCM, otherCM}
+				return nil
+			},
+		)
+
+		res, err := cmAPI.ListWorkerPoolConfigMaps(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(res).To(Equal([]corev1.ConfigMap{ownedCM}))
+	})
+
+	It("error flow", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := cmAPI.ListWorkerPoolConfigMaps(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("getWorkerConfigData", func() {
+	typedConfig := nfdv1.ConfigMap{
+		Core: &nfdv1.WorkerCoreConfig{
+			SleepInterval:  &metav1.Duration{Duration: 30 * time.Second},
+			FeatureSources: []string{"all", "-usb"},
+		},
+		Sources: &nfdv1.WorkerSourcesConfig{
+			CPU: &nfdv1.CPUSourceConfig{
+				CPUID: &nfdv1.CPUIDConfig{AttributeWhitelist: []string{"AVX512F"}},
+			},
+			PCI: &nfdv1.DeviceSourceConfig{DeviceClassWhitelist: []string{"0200", "03"}},
+			Custom: []runtime.RawExtension{
+				{Raw: []byte(`{"name":"my-rule","labels":{"my-label":"true"}}`)},
+			},
+		},
+	}
+
+	DescribeTable("rendering nfd-worker.conf", func(workerConfig nfdv1.ConfigMap, expectedData string) {
+		data, err := getWorkerConfigData(&workerConfig)
+		Expect(err).To(BeNil())
+		Expect(data).To(Equal(expectedData))
+	},
+		Entry("no configuration falls back to the default", nfdv1.ConfigMap{}, nfdv1.DefaultWorkerConfig),
+		Entry("raw configuration", nfdv1.ConfigMap{ConfigData: "core:\n  sleepInterval: 10s\n"}, "core:\n  sleepInterval: 10s\n"),
+		Entry("typed configuration", typedConfig,
+			"core:\n"+
+				"  featureSources:\n"+
+				"  - all\n"+
+				"  - -usb\n"+
+				"  sleepInterval: 30s\n"+
+				"sources:\n"+
+				"  cpu:\n"+
+				"    cpuid:\n"+
+				"      attributeWhitelist:\n"+
+				"      - AVX512F\n"+
+				"  custom:\n"+
+				"  - labels:\n"+
+				"      my-label: \"true\"\n"+
+				"    name: my-rule\n"+
+				"  pci:\n"+
+				"    deviceClassWhitelist:\n"+
+				"    - \"0200\"\n"+
+				"    - \"03\"\n"),
+		Entry("raw configuration takes precedence over the typed one", nfdv1.ConfigMap{
+			ConfigData: "core:\n  sleepInterval: 10s\n",
+			Core:       typedConfig.Core,
+			Sources:    typedConfig.Sources,
+		}, "core:\n  sleepInterval: 10s\n"),
+	)
+})
+
 var _ = Describe("DeleteConfigMap", func() {
 	var (
 		ctrl  *gomock.Controller
@@ -103,21 +309,63 @@ var _ = Describe("DeleteConfigMap", func() {
 	It("failure to delete configmap from the cluster", func() {
 		clnt.EXPECT().Delete(ctx, expectedCM).Return(fmt.Errorf("some error"))
 
-		err := cmAPI.DeleteConfigMap(ctx, namespace, name)
+		_, err := cmAPI.DeleteConfigMap(ctx, namespace, name)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("configmap is not present in the cluster", func() {
 		clnt.EXPECT().Delete(ctx, expectedCM).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
 
-		err := cmAPI.DeleteConfigMap(ctx, namespace, name)
+		deleted, err := cmAPI.DeleteConfigMap(ctx, namespace, name)
 		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
 	})
 
 	It("configmap deleted successfully", func() {
 		clnt.EXPECT().Delete(ctx, expectedCM).Return(nil)
 
-		err := cmAPI.DeleteConfigMap(ctx, namespace, name)
+		deleted, err := cmAPI.DeleteConfigMap(ctx, namespace, name)
 		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
+
+var _ = Describe("GetConfigMap", func() {
+	var (
+		ctrl  *gomock.Controller
+		clnt  *client.MockClient
+		cmAPI ConfigMapAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		cmAPI = NewConfigMapAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	name := "cm-name"
+	namespace := "cm-namespace"
+
+	It("good flow", func() {
+		expectedCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		}
+		clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.SetName(name)
+				cm.SetNamespace(namespace)
+				return nil
+			},
+		)
+		res, err := cmAPI.GetConfigMap(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(res).To(Equal(expectedCM))
+	})
+
+	It("error flow", func() {
+		clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, gomock.Any()).Return(fmt.Errorf("some error"))
+		_, err := cmAPI.GetConfigMap(ctx, namespace, name)
+		Expect(err).To(HaveOccurred())
 	})
 })
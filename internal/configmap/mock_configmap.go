@@ -41,11 +41,12 @@ func (m *MockConfigMapAPI) EXPECT() *MockConfigMapAPIMockRecorder {
 }
 
 // DeleteConfigMap mocks base method.
-func (m *MockConfigMapAPI) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+func (m *MockConfigMapAPI) DeleteConfigMap(ctx context.Context, namespace, name string) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteConfigMap", ctx, namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteConfigMap indicates an expected call of DeleteConfigMap.
@@ -54,6 +55,64 @@ func (mr *MockConfigMapAPIMockRecorder) DeleteConfigMap(ctx, namespace, name any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfigMap", reflect.TypeOf((*MockConfigMapAPI)(nil).DeleteConfigMap), ctx, namespace, name)
 }
 
+// GetConfigMap mocks base method.
+func (m *MockConfigMapAPI) GetConfigMap(ctx context.Context, namespace, name string) (*v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap", ctx, namespace, name)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap.
+func (mr *MockConfigMapAPIMockRecorder) GetConfigMap(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockConfigMapAPI)(nil).GetConfigMap), ctx, namespace, name)
+}
+
+// ListWorkerPoolConfigMaps mocks base method.
+func (m *MockConfigMapAPI) ListWorkerPoolConfigMaps(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) ([]v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkerPoolConfigMaps", ctx, nfdInstance)
+	ret0, _ := ret[0].([]v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkerPoolConfigMaps indicates an expected call of ListWorkerPoolConfigMaps.
+func (mr *MockConfigMapAPIMockRecorder) ListWorkerPoolConfigMaps(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkerPoolConfigMaps", reflect.TypeOf((*MockConfigMapAPI)(nil).ListWorkerPoolConfigMaps), ctx, nfdInstance)
+}
+
+// SetMasterConfigMapAsDesired mocks base method.
+func (m *MockConfigMapAPI) SetMasterConfigMapAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, masterCM *v1.ConfigMap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMasterConfigMapAsDesired", ctx, nfdInstance, masterCM)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMasterConfigMapAsDesired indicates an expected call of SetMasterConfigMapAsDesired.
+func (mr *MockConfigMapAPIMockRecorder) SetMasterConfigMapAsDesired(ctx, nfdInstance, masterCM any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterConfigMapAsDesired", reflect.TypeOf((*MockConfigMapAPI)(nil).SetMasterConfigMapAsDesired), ctx, nfdInstance, masterCM)
+}
+
+// SetTopologyUpdaterConfigMapAsDesired mocks base method.
+func (m *MockConfigMapAPI) SetTopologyUpdaterConfigMapAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, topologyCM *v1.ConfigMap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTopologyUpdaterConfigMapAsDesired", ctx, nfdInstance, topologyCM)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTopologyUpdaterConfigMapAsDesired indicates an expected call of SetTopologyUpdaterConfigMapAsDesired.
+func (mr *MockConfigMapAPIMockRecorder) SetTopologyUpdaterConfigMapAsDesired(ctx, nfdInstance, topologyCM any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopologyUpdaterConfigMapAsDesired", reflect.TypeOf((*MockConfigMapAPI)(nil).SetTopologyUpdaterConfigMapAsDesired), ctx, nfdInstance, topologyCM)
+}
+
 // SetWorkerConfigMapAsDesired mocks base method.
 func (m *MockConfigMapAPI) SetWorkerConfigMapAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, workerCM *v1.ConfigMap) error {
 	m.ctrl.T.Helper()
@@ -67,3 +126,17 @@ func (mr *MockConfigMapAPIMockRecorder) SetWorkerConfigMapAsDesired(ctx, nfdInst
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerConfigMapAsDesired", reflect.TypeOf((*MockConfigMapAPI)(nil).SetWorkerConfigMapAsDesired), ctx, nfdInstance, workerCM)
 }
+
+// SetWorkerPoolConfigMapAsDesired mocks base method.
+func (m *MockConfigMapAPI) SetWorkerPoolConfigMapAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, pool *v10.WorkerPool, workerCM *v1.ConfigMap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkerPoolConfigMapAsDesired", ctx, nfdInstance, pool, workerCM)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkerPoolConfigMapAsDesired indicates an expected call of SetWorkerPoolConfigMapAsDesired.
+func (mr *MockConfigMapAPIMockRecorder) SetWorkerPoolConfigMapAsDesired(ctx, nfdInstance, pool, workerCM any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerPoolConfigMapAsDesired", reflect.TypeOf((*MockConfigMapAPI)(nil).SetWorkerPoolConfigMapAsDesired), ctx, nfdInstance, pool, workerCM)
+}
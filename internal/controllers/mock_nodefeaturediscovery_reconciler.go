@@ -39,6 +39,20 @@ func (m *MocknodeFeatureDiscoveryHelperAPI) EXPECT() *MocknodeFeatureDiscoveryHe
 	return m.recorder
 }
 
+// deleteOperandRBAC mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) deleteOperandRBAC(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteOperandRBAC", ctx, nfdInstance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// deleteOperandRBAC indicates an expected call of deleteOperandRBAC.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) deleteOperandRBAC(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteOperandRBAC", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).deleteOperandRBAC), ctx, nfdInstance)
+}
+
 // finalizeComponents mocks base method.
 func (m *MocknodeFeatureDiscoveryHelperAPI) finalizeComponents(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
 	m.ctrl.T.Helper()
@@ -53,6 +67,21 @@ func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) finalizeComponents(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "finalizeComponents", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).finalizeComponents), ctx, nfdInstance)
 }
 
+// getOperandNamespaceOwner mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) getOperandNamespaceOwner(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) (*v1.NodeFeatureDiscovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getOperandNamespaceOwner", ctx, nfdInstance)
+	ret0, _ := ret[0].(*v1.NodeFeatureDiscovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getOperandNamespaceOwner indicates an expected call of getOperandNamespaceOwner.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) getOperandNamespaceOwner(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getOperandNamespaceOwner", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).getOperandNamespaceOwner), ctx, nfdInstance)
+}
+
 // handleGC mocks base method.
 func (m *MocknodeFeatureDiscoveryHelperAPI) handleGC(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
 	m.ctrl.T.Helper()
@@ -81,6 +110,62 @@ func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handleMaster(ctx, nfdIn
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handleMaster", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handleMaster), ctx, nfdInstance)
 }
 
+// handleMetrics mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) handleMetrics(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "handleMetrics", ctx, nfdInstance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// handleMetrics indicates an expected call of handleMetrics.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handleMetrics(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handleMetrics", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handleMetrics), ctx, nfdInstance)
+}
+
+// handleOperandNamespace mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) handleOperandNamespace(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "handleOperandNamespace", ctx, nfdInstance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// handleOperandNamespace indicates an expected call of handleOperandNamespace.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handleOperandNamespace(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handleOperandNamespace", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handleOperandNamespace), ctx, nfdInstance)
+}
+
+// handleOperandNamespaceConflict mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) handleOperandNamespaceConflict(ctx context.Context, nfdInstance, owner *v1.NodeFeatureDiscovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "handleOperandNamespaceConflict", ctx, nfdInstance, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// handleOperandNamespaceConflict indicates an expected call of handleOperandNamespaceConflict.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handleOperandNamespaceConflict(ctx, nfdInstance, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handleOperandNamespaceConflict", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handleOperandNamespaceConflict), ctx, nfdInstance, owner)
+}
+
+// handleOperandRBAC mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) handleOperandRBAC(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "handleOperandRBAC", ctx, nfdInstance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// handleOperandRBAC indicates an expected call of handleOperandRBAC.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handleOperandRBAC(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handleOperandRBAC", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handleOperandRBAC), ctx, nfdInstance)
+}
+
 // handlePrune mocks base method.
 func (m *MocknodeFeatureDiscoveryHelperAPI) handlePrune(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) (bool, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +181,35 @@ func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handlePrune(ctx, nfdIns
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handlePrune", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handlePrune), ctx, nfdInstance)
 }
 
+// handlePruneRequest mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) handlePruneRequest(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "handlePruneRequest", ctx, nfdInstance)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// handlePruneRequest indicates an expected call of handlePruneRequest.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handlePruneRequest(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handlePruneRequest", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handlePruneRequest), ctx, nfdInstance)
+}
+
+// handleRestoreSnapshot mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) handleRestoreSnapshot(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "handleRestoreSnapshot", ctx, nfdInstance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// handleRestoreSnapshot indicates an expected call of handleRestoreSnapshot.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) handleRestoreSnapshot(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "handleRestoreSnapshot", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).handleRestoreSnapshot), ctx, nfdInstance)
+}
+
 // handleStatus mocks base method.
 func (m *MocknodeFeatureDiscoveryHelperAPI) handleStatus(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) error {
 	m.ctrl.T.Helper()
@@ -152,6 +266,21 @@ func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) hasFinalizer(nfdInstanc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "hasFinalizer", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).hasFinalizer), nfdInstance)
 }
 
+// isInstanceTakenOver mocks base method.
+func (m *MocknodeFeatureDiscoveryHelperAPI) isInstanceTakenOver(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "isInstanceTakenOver", ctx, nfdInstance)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// isInstanceTakenOver indicates an expected call of isInstanceTakenOver.
+func (mr *MocknodeFeatureDiscoveryHelperAPIMockRecorder) isInstanceTakenOver(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isInstanceTakenOver", reflect.TypeOf((*MocknodeFeatureDiscoveryHelperAPI)(nil).isInstanceTakenOver), ctx, nfdInstance)
+}
+
 // removeFinalizer mocks base method.
 func (m *MocknodeFeatureDiscoveryHelperAPI) removeFinalizer(ctx context.Context, instance *v1.NodeFeatureDiscovery) error {
 	m.ctrl.T.Helper()
@@ -21,18 +21,26 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -41,21 +49,70 @@ import (
 	"sigs.k8s.io/node-feature-discovery-operator/internal/daemonset"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/deployment"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/metrics"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/namespace"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/poddisruptionbudget"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/prometheusrule"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/rbac"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/service"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/servicemonitor"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/snapshot"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/status"
 )
 
-const finalizerLabel = "nfd-finalizer"
+const (
+	finalizerLabel = "nfd-finalizer"
+
+	// workerConfigMapRefIndexKey indexes the NodeFeatureDiscovery CRs by the
+	// operand namespace and the name of the worker ConfigMap they reference
+	workerConfigMapRefIndexKey = "spec.workerConfig.configMapRef.name"
+)
+
+// components of the NFD CR, as labelled in the metrics
+const (
+	componentMaster          = "master"
+	componentWorker          = "worker"
+	componentTopologyUpdater = "topology-updater"
+	componentGC              = "gc"
+)
+
+// reasons of the events recorded on the NFD CR
+const (
+	eventReasonCreated           = "Created"
+	eventReasonUpdated           = "Updated"
+	eventReasonDeleted           = "Deleted"
+	eventReasonFailedReconcile   = "FailedReconcile"
+	eventReasonFailedDelete      = "FailedDelete"
+	eventReasonPruneStarted      = "PruneJobStarted"
+	eventReasonPruneSucceeded    = "PruneJobSucceeded"
+	eventReasonPruneFailed       = "PruneJobFailed"
+	eventReasonTakenOver         = "InstanceTakenOver"
+	eventReasonNamespaceConflict = "OperandNamespaceConflict"
+	eventReasonSnapshotFailed    = "SnapshotFailed"
+	eventReasonRestored          = "SnapshotRestored"
+	eventReasonRestoreFailed     = "SnapshotRestoreFailed"
+	eventReasonFinalizerAdded    = "FinalizerAdded"
+	eventReasonFinalizerRemoved  = "FinalizerRemoved"
+)
 
 // NodeFeatureDiscoveryReconciler reconciles a NodeFeatureDiscovery object
 type nodeFeatureDiscoveryReconciler struct {
-	helper nodeFeatureDiscoveryHelperAPI
+	helper     nodeFeatureDiscoveryHelperAPI
+	metricsAPI metrics.MetricsAPI
 }
 
-func NewNodeFeatureDiscoveryReconciler(client client.Client, deploymentAPI deployment.DeploymentAPI, daemonsetAPI daemonset.DaemonsetAPI,
-	configmapAPI configmap.ConfigMapAPI, jobAPI job.JobAPI, statusAPI status.StatusAPI, scheme *runtime.Scheme) *nodeFeatureDiscoveryReconciler {
-	helper := newNodeFeatureDiscoveryHelperAPI(client, deploymentAPI, daemonsetAPI, configmapAPI, jobAPI, statusAPI, scheme)
+func NewNodeFeatureDiscoveryReconciler(client client.Client, namespaceAPI namespace.NamespaceAPI, rbacAPI rbac.RBACAPI, deploymentAPI deployment.DeploymentAPI, daemonsetAPI daemonset.DaemonsetAPI,
+	configmapAPI configmap.ConfigMapAPI, jobAPI job.JobAPI, snapshotAPI snapshot.SnapshotAPI, pdbAPI poddisruptionbudget.PodDisruptionBudgetAPI,
+	serviceAPI service.ServiceAPI, serviceMonitorAPI servicemonitor.ServiceMonitorAPI, prometheusRuleAPI prometheusrule.PrometheusRuleAPI,
+	statusAPI status.StatusAPI, metricsAPI metrics.MetricsAPI, recorder record.EventRecorder, scheme *runtime.Scheme,
+	watchNamespaces []string) *nodeFeatureDiscoveryReconciler {
+	helper := newNodeFeatureDiscoveryHelperAPI(client, namespaceAPI, rbacAPI, deploymentAPI, daemonsetAPI, configmapAPI, jobAPI, snapshotAPI, pdbAPI, serviceAPI, serviceMonitorAPI,
+		prometheusRuleAPI, statusAPI, metricsAPI, recorder, scheme, watchNamespaces)
 	return &nodeFeatureDiscoveryReconciler{
-		helper: helper,
+		helper:     helper,
+		metricsAPI: metricsAPI,
 	}
 }
 
@@ -64,15 +121,91 @@ func NewNodeFeatureDiscoveryReconciler(client client.Client, deploymentAPI deplo
 func (r *nodeFeatureDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	p := getPredicates()
 
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &nfdv1.NodeFeatureDiscovery{}, workerConfigMapRefIndexKey, workerConfigMapRefIndexer)
+	if err != nil {
+		return fmt.Errorf("failed to index NodeFeatureDiscovery by worker configmap: %w", err)
+	}
+
 	// watch for all events on NodeFeatureDiscovery and for
-	// update and delete events for the resource created by operator
-	return ctrl.NewControllerManagedBy(mgr).
+	// update and delete events for the resource created by operator.
+	// The resources deployed outside of the namespace of the CR carry
+	// owner labels instead of an owner reference, and are mapped back to
+	// the CR through them. User managed worker ConfigMaps are watched as
+	// well, so that creating or fixing them triggers a reconcile
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&nfdv1.NodeFeatureDiscovery{}).
-		Owns(&appsv1.Deployment{}, builder.WithPredicates(p)).
-		Owns(&appsv1.DaemonSet{}, builder.WithPredicates(p)).
-		Owns(&corev1.ConfigMap{}, builder.WithPredicates(p)).
-		Owns(&batchv1.Job{}, builder.WithPredicates(p)).
-		Complete(reconcile.AsReconciler[*nfdv1.NodeFeatureDiscovery](mgr.GetClient(), r))
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(getWorkerConfigMapMapFunc(mgr.GetClient())))
+	for _, obj := range []client.Object{
+		&appsv1.Deployment{},
+		&appsv1.DaemonSet{},
+		&corev1.ConfigMap{},
+		&corev1.Service{},
+		&batchv1.Job{},
+		&policyv1.PodDisruptionBudget{},
+		&corev1.ServiceAccount{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
+		&rbacv1.ClusterRoleBinding{},
+	} {
+		b = ownsObject(b, obj, p)
+	}
+
+	// the ServiceMonitors and the PrometheusRule can only be watched if
+	// their CRDs are installed when the operator starts
+	smSupported, err := servicemonitor.IsSupported(mgr.GetRESTMapper())
+	if err != nil {
+		return err
+	}
+	if smSupported {
+		b = ownsObject(b, servicemonitor.NewServiceMonitor("", ""), p)
+	}
+	ruleSupported, err := prometheusrule.IsSupported(mgr.GetRESTMapper())
+	if err != nil {
+		return err
+	}
+	if ruleSupported {
+		b = ownsObject(b, prometheusrule.NewPrometheusRule("", ""), p)
+	}
+	return b.Complete(reconcile.AsReconciler[*nfdv1.NodeFeatureDiscovery](mgr.GetClient(), r))
+}
+
+// ownsObject watches the objects of a kind owned by the NFD CRs, either
+// through their owner reference or through their owner labels
+func ownsObject(b *builder.Builder, obj client.Object, p predicate.Predicate) *builder.Builder {
+	return b.Owns(obj, builder.WithPredicates(p)).
+		Watches(obj, ownership.EnqueueRequestForOwner(), builder.WithPredicates(p))
+}
+
+func workerConfigMapRefIndexer(obj client.Object) []string {
+	nfdInstance, ok := obj.(*nfdv1.NodeFeatureDiscovery)
+	if !ok || nfdInstance.Spec.WorkerConfig.ConfigMapRef == nil {
+		return nil
+	}
+	return []string{workerConfigMapRefIndexValue(nfdInstance.GetOperandNamespace(), nfdInstance.Spec.WorkerConfig.ConfigMapRef.Name)}
+}
+
+func workerConfigMapRefIndexValue(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// getWorkerConfigMapMapFunc returns a function mapping a ConfigMap to the
+// NodeFeatureDiscovery CRs that reference it as their worker configuration.
+// The CRs are looked up in every namespace, since the ConfigMap is in the
+// operand namespace
+func getWorkerConfigMapMapFunc(clnt client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		nfdList := nfdv1.NodeFeatureDiscoveryList{}
+		err := clnt.List(ctx, &nfdList, client.MatchingFields{workerConfigMapRefIndexKey: workerConfigMapRefIndexValue(obj.GetNamespace(), obj.GetName())})
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to list NodeFeatureDiscovery CRs referencing configmap", "namespace", obj.GetNamespace(), "name", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(nfdList.Items))
+		for _, nfdInstance := range nfdList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&nfdInstance)})
+		}
+		return requests
+	}
 }
 
 func getPredicates() predicate.Predicate {
@@ -89,6 +222,9 @@ func getPredicates() predicate.Predicate {
 }
 
 func isControlledByNFD(obj client.Object) bool {
+	if ownership.IsOwnedByLabels(obj) {
+		return true
+	}
 	controller := metav1.GetControllerOf(obj)
 	if controller == nil {
 		return false
@@ -100,8 +236,21 @@ func isControlledByNFD(obj client.Object) bool {
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;patch
+// +kubebuilder:rbac:groups=core,resources=nodes/status,verbs=patch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=nfd-master;nfd-gc;nfd-topology-updater;nfd-prune
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nfd.k8s-sigs.io,resources=nodefeaturerules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nfd.k8s-sigs.io,resources=nodefeatures,verbs=get;create;update
 // +kubebuilder:rbac:groups=nfd.kubernetes.io,resources=nodefeaturediscoveries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nfd.kubernetes.io,resources=nodefeaturediscoveries/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nfd.kubernetes.io,resources=nodefeaturediscoveries/finalizers,verbs=update
@@ -114,20 +263,47 @@ func (r *nodeFeatureDiscoveryReconciler) Reconcile(ctx context.Context, nfdInsta
 	logger := ctrl.LoggerFrom(ctx).WithValues("instance namespace", nfdInstance.Namespace, "instance name", nfdInstance.Name)
 
 	if nfdInstance.DeletionTimestamp != nil {
-		// NFD CR is being deleted
-		err := r.helper.finalizeComponents(ctx, nfdInstance)
+		// NFD CR is being deleted. Once another CR took over its instance,
+		// or when its operand namespace is owned by another CR, the operand
+		// objects and the NFD labels belong to that CR, so they are neither
+		// deleted nor pruned
+		takenOver, err := r.helper.isInstanceTakenOver(ctx, nfdInstance)
 		if err != nil {
-			return res, fmt.Errorf("failed to finalize components for %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+			return res, err
 		}
-		done, err := r.helper.handlePrune(ctx, nfdInstance)
-		if err != nil {
-			return res, fmt.Errorf("failed to handle pruning for %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+		if !takenOver {
+			owner, err := r.helper.getOperandNamespaceOwner(ctx, nfdInstance)
+			if err != nil {
+				return res, err
+			}
+			takenOver = owner != nil
 		}
-		if !done {
-			// reconcile will be called again when prune job has been completed
-			return res, nil
+		if !takenOver {
+			err = r.helper.finalizeComponents(ctx, nfdInstance)
+			if err != nil {
+				return res, fmt.Errorf("failed to finalize components for %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+			}
+			done, err := r.helper.handlePrune(ctx, nfdInstance)
+			if err != nil {
+				return res, fmt.Errorf("failed to handle pruning for %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+			}
+			if !done {
+				// reconcile will be called again when prune job has been
+				// completed, or once the prune delay has passed
+				res.RequeueAfter = max(time.Until(getPruneTime(nfdInstance)), 0)
+				return res, nil
+			}
+			err = r.helper.deleteOperandRBAC(ctx, nfdInstance)
+			if err != nil {
+				return res, fmt.Errorf("failed to delete operand RBAC for %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+			}
+		}
+		err = r.helper.removeFinalizer(ctx, nfdInstance)
+		if err != nil {
+			return res, err
 		}
-		return res, r.helper.removeFinalizer(ctx, nfdInstance)
+		r.metricsAPI.DeleteInstance(nfdInstance)
+		return res, nil
 	}
 
 	// If the finalizer doesn't exist, add it.
@@ -135,21 +311,67 @@ func (r *nodeFeatureDiscoveryReconciler) Reconcile(ctx context.Context, nfdInsta
 		return res, r.helper.setFinalizer(ctx, nfdInstance)
 	}
 
+	// instances are kept apart by their operand namespace, only one CR
+	// deploys operands in it
+	owner, err := r.helper.getOperandNamespaceOwner(ctx, nfdInstance)
+	if err != nil {
+		return res, err
+	}
+	if owner != nil {
+		return res, r.helper.handleOperandNamespaceConflict(ctx, nfdInstance, owner)
+	}
+
+	// the operands cannot be deployed before their namespace exists
+	logger.Info("reconciling operand namespace")
+	err = r.helper.handleOperandNamespace(ctx, nfdInstance)
+	if err != nil {
+		return res, err
+	}
+
+	// nor before the ServiceAccounts they run as
+	logger.Info("reconciling operand RBAC")
+	err = r.helper.handleOperandRBAC(ctx, nfdInstance)
+	if err != nil {
+		return res, err
+	}
+
 	errs := make([]error, 0, 10)
 	logger.Info("reconciling master component")
-	err := r.helper.handleMaster(ctx, nfdInstance)
+	err = r.helper.handleMaster(ctx, nfdInstance)
+	r.metricsAPI.ObserveReconcile(nfdInstance, componentMaster, err)
 	errs = append(errs, err)
 
-	logger.Info("reconciling worker component")
-	err = r.helper.handleWorker(ctx, nfdInstance)
+	logger.Info("reconciling prune request")
+	pruning, err := r.helper.handlePruneRequest(ctx, nfdInstance)
 	errs = append(errs, err)
 
+	if pruning {
+		// the workers would relabel the nodes while they are being pruned,
+		// they are recreated once the prune job completed
+		logger.Info("worker component paused by the prune request")
+	} else {
+		logger.Info("reconciling worker component")
+		err = r.helper.handleWorker(ctx, nfdInstance)
+		r.metricsAPI.ObserveReconcile(nfdInstance, componentWorker, err)
+		errs = append(errs, err)
+	}
+
 	logger.Info("reconciling topology components")
 	err = r.helper.handleTopology(ctx, nfdInstance)
+	r.metricsAPI.ObserveReconcile(nfdInstance, componentTopologyUpdater, err)
 	errs = append(errs, err)
 
 	logger.Info("reconciling garbage collector")
 	err = r.helper.handleGC(ctx, nfdInstance)
+	r.metricsAPI.ObserveReconcile(nfdInstance, componentGC, err)
+	errs = append(errs, err)
+
+	logger.Info("reconciling metrics services")
+	err = r.helper.handleMetrics(ctx, nfdInstance)
+	errs = append(errs, err)
+
+	logger.Info("restoring NFD labels snapshot")
+	err = r.helper.handleRestoreSnapshot(ctx, nfdInstance)
 	errs = append(errs, err)
 
 	logger.Info("reconciling NFD status")
@@ -166,60 +388,420 @@ type nodeFeatureDiscoveryHelperAPI interface {
 	hasFinalizer(nfdInstance *nfdv1.NodeFeatureDiscovery) bool
 	setFinalizer(ctx context.Context, instance *nfdv1.NodeFeatureDiscovery) error
 	removeFinalizer(ctx context.Context, instance *nfdv1.NodeFeatureDiscovery) error
+	handleOperandNamespace(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
+	handleOperandRBAC(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
+	deleteOperandRBAC(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
 	handleMaster(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
 	handleWorker(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
 	handleTopology(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
 	handleGC(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
+	handleMetrics(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
+	isInstanceTakenOver(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (bool, error)
+	getOperandNamespaceOwner(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (*nfdv1.NodeFeatureDiscovery, error)
+	handleOperandNamespaceConflict(ctx context.Context, nfdInstance, owner *nfdv1.NodeFeatureDiscovery) error
 	handlePrune(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (bool, error)
+	handlePruneRequest(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (bool, error)
+	handleRestoreSnapshot(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
 	handleStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
 }
 
 type nodeFeatureDiscoveryHelper struct {
-	client        client.Client
-	deploymentAPI deployment.DeploymentAPI
-	daemonsetAPI  daemonset.DaemonsetAPI
-	configmapAPI  configmap.ConfigMapAPI
-	jobAPI        job.JobAPI
-	statusAPI     status.StatusAPI
-	scheme        *runtime.Scheme
+	client            client.Client
+	namespaceAPI      namespace.NamespaceAPI
+	rbacAPI           rbac.RBACAPI
+	deploymentAPI     deployment.DeploymentAPI
+	daemonsetAPI      daemonset.DaemonsetAPI
+	configmapAPI      configmap.ConfigMapAPI
+	jobAPI            job.JobAPI
+	snapshotAPI       snapshot.SnapshotAPI
+	pdbAPI            poddisruptionbudget.PodDisruptionBudgetAPI
+	serviceAPI        service.ServiceAPI
+	serviceMonitorAPI servicemonitor.ServiceMonitorAPI
+	prometheusRuleAPI prometheusrule.PrometheusRuleAPI
+	statusAPI         status.StatusAPI
+	metricsAPI        metrics.MetricsAPI
+	recorder          record.EventRecorder
+	scheme            *runtime.Scheme
+	// watchNamespaces are the namespaces cached by the manager, all of
+	// them when empty
+	watchNamespaces []string
 }
 
-func newNodeFeatureDiscoveryHelperAPI(client client.Client, deploymentAPI deployment.DeploymentAPI, daemonsetAPI daemonset.DaemonsetAPI,
-	configmapAPI configmap.ConfigMapAPI, jobAPI job.JobAPI, statusAPI status.StatusAPI, scheme *runtime.Scheme) nodeFeatureDiscoveryHelperAPI {
+func newNodeFeatureDiscoveryHelperAPI(client client.Client, namespaceAPI namespace.NamespaceAPI, rbacAPI rbac.RBACAPI, deploymentAPI deployment.DeploymentAPI, daemonsetAPI daemonset.DaemonsetAPI,
+	configmapAPI configmap.ConfigMapAPI, jobAPI job.JobAPI, snapshotAPI snapshot.SnapshotAPI, pdbAPI poddisruptionbudget.PodDisruptionBudgetAPI,
+	serviceAPI service.ServiceAPI, serviceMonitorAPI servicemonitor.ServiceMonitorAPI, prometheusRuleAPI prometheusrule.PrometheusRuleAPI,
+	statusAPI status.StatusAPI, metricsAPI metrics.MetricsAPI, recorder record.EventRecorder, scheme *runtime.Scheme,
+	watchNamespaces []string) nodeFeatureDiscoveryHelperAPI {
 	return &nodeFeatureDiscoveryHelper{
-		client:        client,
-		deploymentAPI: deploymentAPI,
-		daemonsetAPI:  daemonsetAPI,
-		configmapAPI:  configmapAPI,
-		jobAPI:        jobAPI,
-		statusAPI:     statusAPI,
-		scheme:        scheme,
+		client:            client,
+		namespaceAPI:      namespaceAPI,
+		rbacAPI:           rbacAPI,
+		deploymentAPI:     deploymentAPI,
+		daemonsetAPI:      daemonsetAPI,
+		configmapAPI:      configmapAPI,
+		jobAPI:            jobAPI,
+		snapshotAPI:       snapshotAPI,
+		pdbAPI:            pdbAPI,
+		serviceAPI:        serviceAPI,
+		serviceMonitorAPI: serviceMonitorAPI,
+		prometheusRuleAPI: prometheusRuleAPI,
+		statusAPI:         statusAPI,
+		metricsAPI:        metricsAPI,
+		recorder:          recorder,
+		scheme:            scheme,
+		watchNamespaces:   watchNamespaces,
 	}
 }
 
+// createOrPatch creates or patches an object of the operand, and records an
+// event on the NFD CR when the object changed or could not be patched
+func (nfdh *nodeFeatureDiscoveryHelper) createOrPatch(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery,
+	obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+
+	// unstructured objects carry their kind, typed ones are named after it
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = reflect.TypeOf(obj).Elem().Name()
+	}
+	opRes, err := controllerutil.CreateOrPatch(ctx, nfdh.client, obj, f)
+	if err != nil {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonFailedReconcile,
+			"failed to create or patch %s %s/%s: %v", kind, obj.GetNamespace(), obj.GetName(), err)
+		return opRes, err
+	}
+	switch opRes {
+	case controllerutil.OperationResultCreated:
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonCreated,
+			"created %s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+	case controllerutil.OperationResultUpdated:
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonUpdated,
+			"updated %s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+	}
+	return opRes, nil
+}
+
+// deleteObject deletes an object of the operand with deleteFunc, and records
+// an event on the NFD CR when the object existed or could not be deleted
+func (nfdh *nodeFeatureDiscoveryHelper) deleteObject(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery,
+	kind, namespace, name string, deleteFunc func(ctx context.Context, namespace, name string) (bool, error)) error {
+
+	deleted, err := deleteFunc(ctx, namespace, name)
+	if err != nil {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonFailedDelete,
+			"failed to delete %s %s/%s: %v", kind, namespace, name, err)
+		return err
+	}
+	if deleted {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonDeleted,
+			"deleted %s %s/%s", kind, namespace, name)
+	}
+	return nil
+}
+
+// deleteOwnedObject deletes an object of the operand with deleteFunc only if
+// it is owned by the NFD CR, leaving alone an object of the same name
+// installed by other means
+func (nfdh *nodeFeatureDiscoveryHelper) deleteOwnedObject(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery,
+	kind string, obj client.Object, deleteFunc func(ctx context.Context, namespace, name string) (bool, error)) error {
+
+	err := nfdh.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get %s %s: %w", kind, obj.GetName(), err)
+	}
+	if !ownership.IsOwnedBy(obj, nfdInstance) {
+		return nil
+	}
+	return nfdh.deleteObject(ctx, nfdInstance, kind, obj.GetNamespace(), obj.GetName(), deleteFunc)
+}
+
+// finalizeComponents deletes the operands of a CR being deleted, including
+// the ones left in the previous operand namespace. The garbage collector
+// only deletes the objects with an owner reference, so the ones deployed
+// outside of the namespace of the CR are deleted here, metrics included
 func (nfdh *nodeFeatureDiscoveryHelper) finalizeComponents(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
-	err := nfdh.daemonsetAPI.DeleteDaemonSet(ctx, nfdInstance.Namespace, "nfd-worker")
+	if prevInstance := getPreviousOperandInstance(nfdInstance); prevInstance != nil {
+		err := nfdh.deleteComponents(ctx, prevInstance)
+		if err != nil {
+			return err
+		}
+		err = nfdh.deleteMetrics(ctx, prevInstance)
+		if err != nil {
+			return err
+		}
+		err = nfdh.deleteOperandRBAC(ctx, prevInstance)
+		if err != nil {
+			return err
+		}
+	}
+	err := nfdh.deleteComponents(ctx, nfdInstance)
 	if err != nil {
-		return fmt.Errorf("failed to delete worker daemonset: %w", err)
+		return err
+	}
+	if nfdInstance.GetOperandNamespace() != nfdInstance.Namespace {
+		return nfdh.deleteMetrics(ctx, nfdInstance)
+	}
+	return nil
+}
+
+// deleteComponents deletes the Deployments and DaemonSets of the operands,
+// along with their ConfigMaps and PodDisruptionBudget
+func (nfdh *nodeFeatureDiscoveryHelper) deleteComponents(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	err := nfdh.deleteDefaultWorker(ctx, nfdInstance)
+	if err != nil {
+		return err
 	}
 
-	err = nfdh.configmapAPI.DeleteConfigMap(ctx, nfdInstance.Namespace, "nfd-worker")
+	err = nfdh.deleteWorkerPools(ctx, nfdInstance, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete worker config map: %w", err)
+		return err
 	}
 
-	if nfdInstance.Spec.TopologyUpdater {
-		err = nfdh.daemonsetAPI.DeleteDaemonSet(ctx, nfdInstance.Namespace, "nfd-topology-updater")
+	// the topology updater may have been deployed before being disabled,
+	// so it is deleted whatever the spec says
+	err = nfdh.deleteTopology(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	if nfdInstance.Spec.Master.IsHighlyAvailable() {
+		err = nfdh.deleteObject(ctx, nfdInstance, "PodDisruptionBudget", nfdInstance.GetOperandNamespace(), names.Master(nfdInstance), nfdh.pdbAPI.DeletePodDisruptionBudget)
 		if err != nil {
-			return fmt.Errorf("failed to delete topology-updater daemonset: %w", err)
+			return fmt.Errorf("failed to delete master poddisruptionbudget: %w", err)
 		}
 	}
-	err = nfdh.deploymentAPI.DeleteDeployment(ctx, nfdInstance.Namespace, "nfd-master")
+	err = nfdh.deleteObject(ctx, nfdInstance, "Deployment", nfdInstance.GetOperandNamespace(), names.Master(nfdInstance), nfdh.deploymentAPI.DeleteDeployment)
 	if err != nil {
 		return fmt.Errorf("failed to delete master deployment: %w", err)
 	}
+	err = nfdh.deleteObject(ctx, nfdInstance, "ConfigMap", nfdInstance.GetOperandNamespace(), names.Master(nfdInstance), nfdh.configmapAPI.DeleteConfigMap)
+	if err != nil {
+		return fmt.Errorf("failed to delete master configmap: %w", err)
+	}
+
+	return nfdh.deleteObject(ctx, nfdInstance, "Deployment", nfdInstance.GetOperandNamespace(), names.GC(nfdInstance), nfdh.deploymentAPI.DeleteDeployment)
+}
+
+// deleteMetrics deletes the metrics Services, the ServiceMonitors and the
+// PrometheusRule of the operands
+func (nfdh *nodeFeatureDiscoveryHelper) deleteMetrics(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	err := nfdh.deleteMetricsServices(ctx, nfdInstance, nil)
+	if err != nil {
+		return err
+	}
+	smSupported, err := nfdh.serviceMonitorAPI.IsServiceMonitorSupported()
+	if err != nil {
+		return err
+	}
+	if smSupported {
+		err = nfdh.deleteMetricsServiceMonitors(ctx, nfdInstance, nil)
+		if err != nil {
+			return err
+		}
+	}
+	ruleSupported, err := nfdh.prometheusRuleAPI.IsPrometheusRuleSupported()
+	if err != nil || !ruleSupported {
+		return err
+	}
+	err = nfdh.deleteObject(ctx, nfdInstance, prometheusrule.GroupVersionKind.Kind, nfdInstance.GetOperandNamespace(),
+		names.PrometheusRule(nfdInstance), nfdh.prometheusRuleAPI.DeletePrometheusRule)
+	if err != nil {
+		return fmt.Errorf("failed to delete prometheusrule: %w", err)
+	}
+	return nil
+}
+
+// getPreviousOperandInstance returns a copy of the CR deploying its operands
+// to the namespace recorded in the status, or nil if spec.operand.namespace
+// did not change since
+func getPreviousOperandInstance(nfdInstance *nfdv1.NodeFeatureDiscovery) *nfdv1.NodeFeatureDiscovery {
+	prevNamespace := nfdInstance.Status.OperandNamespace
+	if prevNamespace == "" || prevNamespace == nfdInstance.GetOperandNamespace() {
+		return nil
+	}
+	prevInstance := nfdInstance.DeepCopy()
+	prevInstance.Spec.Operand.Namespace = prevNamespace
+	return prevInstance
+}
+
+// handleOperandNamespace creates the operand namespace if it does not exist,
+// and moves the operands out of the previous operand namespace when
+// spec.operand.namespace changed. The operand namespace is recorded in the
+// status once the previous one has been cleaned up. A namespace the operator
+// does not watch is rejected, since the operand objects could not be read
+// back from the cache
+func (nfdh *nodeFeatureDiscoveryHelper) handleOperandNamespace(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	operandNamespace := nfdInstance.GetOperandNamespace()
+	if !namespace.IsWatched(nfdh.watchNamespaces, operandNamespace) {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonFailedReconcile,
+			"operand namespace %s is not watched by the operator", operandNamespace)
+		return fmt.Errorf("operand namespace %s is not watched by the operator", operandNamespace)
+	}
+	if operandNamespace != nfdInstance.Namespace {
+		created, err := nfdh.namespaceAPI.CreateNamespaceIfNotExists(ctx, nfdInstance, operandNamespace)
+		if err != nil {
+			nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonFailedReconcile,
+				"failed to create Namespace %s: %v", operandNamespace, err)
+			return fmt.Errorf("failed to create operand namespace: %w", err)
+		}
+		if created {
+			nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonCreated, "created Namespace %s", operandNamespace)
+		}
+	}
+
+	if prevInstance := getPreviousOperandInstance(nfdInstance); prevInstance != nil {
+		err := nfdh.deleteComponents(ctx, prevInstance)
+		if err != nil {
+			return fmt.Errorf("failed to delete the operands from namespace %s: %w", prevInstance.GetOperandNamespace(), err)
+		}
+		err = nfdh.deleteMetrics(ctx, prevInstance)
+		if err != nil {
+			return fmt.Errorf("failed to delete the operands from namespace %s: %w", prevInstance.GetOperandNamespace(), err)
+		}
+		err = nfdh.deleteOperandRBAC(ctx, prevInstance)
+		if err != nil {
+			return fmt.Errorf("failed to delete the operands from namespace %s: %w", prevInstance.GetOperandNamespace(), err)
+		}
+	}
+
+	if nfdInstance.Status.OperandNamespace == operandNamespace {
+		return nil
+	}
+	unmodifiedCR := nfdInstance.DeepCopy()
+	nfdInstance.Status.OperandNamespace = operandNamespace
+	err := nfdh.client.Status().Patch(ctx, nfdInstance, client.MergeFrom(unmodifiedCR))
+	if err != nil {
+		return fmt.Errorf("failed to record the operand namespace: %w", err)
+	}
+	return nil
+}
+
+// handleOperandRBAC creates the ServiceAccounts of the operands in the
+// operand namespace, along with the nfd-worker Role and the bindings to the
+// operand ClusterRoles. The ones of the namespace of the CR are installed
+// with the operator, so nothing is created there. An object of the same name
+// not labelled with an NFD CR, e.g. installed with the operator, is left as
+// is
+func (nfdh *nodeFeatureDiscoveryHelper) handleOperandRBAC(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	operandNamespace := nfdInstance.GetOperandNamespace()
+	if operandNamespace == nfdInstance.Namespace {
+		return nil
+	}
+
+	for _, name := range rbac.OperandServiceAccounts {
+		sa := corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: operandNamespace},
+		}
+		_, err := nfdh.createOrPatch(ctx, nfdInstance, &sa, func() error {
+			if !isOperandRBACAdoptable(&sa, nfdInstance) {
+				return nil
+			}
+			return nfdh.rbacAPI.SetServiceAccountAsDesired(nfdInstance, &sa)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile serviceaccount %s/%s: %w", operandNamespace, name, err)
+		}
+	}
 
-	return nfdh.deploymentAPI.DeleteDeployment(ctx, nfdInstance.Namespace, "nfd-gc")
+	workerRole := rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: operandNamespace},
+	}
+	_, err := nfdh.createOrPatch(ctx, nfdInstance, &workerRole, func() error {
+		if !isOperandRBACAdoptable(&workerRole, nfdInstance) {
+			return nil
+		}
+		return nfdh.rbacAPI.SetWorkerRoleAsDesired(nfdInstance, &workerRole)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile worker role: %w", err)
+	}
+	workerRoleBinding := rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: operandNamespace},
+	}
+	_, err = nfdh.createOrPatch(ctx, nfdInstance, &workerRoleBinding, func() error {
+		if !isOperandRBACAdoptable(&workerRoleBinding, nfdInstance) {
+			return nil
+		}
+		return nfdh.rbacAPI.SetWorkerRoleBindingAsDesired(nfdInstance, &workerRoleBinding)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile worker rolebinding: %w", err)
+	}
+
+	for _, clusterRole := range rbac.OperandClusterRoles {
+		crb := rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: names.OperandClusterRoleBinding(nfdInstance, clusterRole)},
+		}
+		_, err = nfdh.createOrPatch(ctx, nfdInstance, &crb, func() error {
+			if !isOperandRBACAdoptable(&crb, nfdInstance) {
+				return nil
+			}
+			return nfdh.rbacAPI.SetClusterRoleBindingAsDesired(nfdInstance, clusterRole, &crb)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile clusterrolebinding %s: %w", crb.Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteOperandRBAC deletes the objects created by handleOperandRBAC. It
+// runs once the prune job, which needs them, is done
+func (nfdh *nodeFeatureDiscoveryHelper) deleteOperandRBAC(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	operandNamespace := nfdInstance.GetOperandNamespace()
+	if operandNamespace == nfdInstance.Namespace {
+		return nil
+	}
+
+	deleteClusterRoleBinding := func(ctx context.Context, _, name string) (bool, error) {
+		return nfdh.rbacAPI.DeleteClusterRoleBinding(ctx, name)
+	}
+	for _, clusterRole := range rbac.OperandClusterRoles {
+		crb := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: names.OperandClusterRoleBinding(nfdInstance, clusterRole)},
+		}
+		err := nfdh.deleteOwnedObject(ctx, nfdInstance, "ClusterRoleBinding", crb, deleteClusterRoleBinding)
+		if err != nil {
+			return fmt.Errorf("failed to delete clusterrolebinding: %w", err)
+		}
+	}
+
+	err := nfdh.deleteOwnedObject(ctx, nfdInstance, "RoleBinding", &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: operandNamespace},
+	}, nfdh.rbacAPI.DeleteRoleBinding)
+	if err != nil {
+		return fmt.Errorf("failed to delete worker rolebinding: %w", err)
+	}
+	err = nfdh.deleteOwnedObject(ctx, nfdInstance, "Role", &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: operandNamespace},
+	}, nfdh.rbacAPI.DeleteRole)
+	if err != nil {
+		return fmt.Errorf("failed to delete worker role: %w", err)
+	}
+
+	for _, name := range rbac.OperandServiceAccounts {
+		err = nfdh.deleteOwnedObject(ctx, nfdInstance, "ServiceAccount", &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: operandNamespace},
+		}, nfdh.rbacAPI.DeleteServiceAccount)
+		if err != nil {
+			return fmt.Errorf("failed to delete serviceaccount: %w", err)
+		}
+	}
+	return nil
+}
+
+// isAdoptable reports whether an object can be patched on behalf of the NFD
+// CR, i.e. whether it does not exist yet or is owned by the CR
+func isAdoptable(obj client.Object, nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
+	return obj.GetResourceVersion() == "" || ownership.IsOwnedBy(obj, nfdInstance)
+}
+
+// isOperandRBACAdoptable extends isAdoptable to the operand RBAC labelled
+// with another NFD CR. Only the CR owning the operand namespace reconciles
+// its RBAC, so such objects were left by a CR whose instance was taken over
+func isOperandRBACAdoptable(obj client.Object, nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
+	return isAdoptable(obj, nfdInstance) || ownership.IsOwnedByLabels(obj)
 }
 
 func (nfdh *nodeFeatureDiscoveryHelper) hasFinalizer(nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
@@ -228,131 +810,790 @@ func (nfdh *nodeFeatureDiscoveryHelper) hasFinalizer(nfdInstance *nfdv1.NodeFeat
 
 func (nfdh *nodeFeatureDiscoveryHelper) setFinalizer(ctx context.Context, instance *nfdv1.NodeFeatureDiscovery) error {
 	instance.Finalizers = append(instance.Finalizers, finalizerLabel)
-	return nfdh.client.Update(ctx, instance)
+	err := nfdh.client.Update(ctx, instance)
+	if err != nil {
+		return err
+	}
+	nfdh.recorder.Eventf(instance, corev1.EventTypeNormal, eventReasonFinalizerAdded, "added finalizer %s", finalizerLabel)
+	return nil
 }
 
 func (nfdh *nodeFeatureDiscoveryHelper) removeFinalizer(ctx context.Context, instance *nfdv1.NodeFeatureDiscovery) error {
 	updated := controllerutil.RemoveFinalizer(instance, finalizerLabel)
-	if updated {
-		return nfdh.client.Update(ctx, instance)
+	if !updated {
+		return nil
+	}
+	err := nfdh.client.Update(ctx, instance)
+	if err != nil {
+		return err
 	}
+	nfdh.recorder.Eventf(instance, corev1.EventTypeNormal, eventReasonFinalizerRemoved, "removed finalizer %s", finalizerLabel)
 	return nil
 }
 
 func (nfdh *nodeFeatureDiscoveryHelper) handleMaster(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	// nfd-worker is not instance aware, the master is restricted to the
+	// NodeFeature objects of its operand namespace instead
+	masterCM := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: names.Master(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
+	}
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &masterCM, func() error {
+		return nfdh.configmapAPI.SetMasterConfigMapAsDesired(ctx, nfdInstance, &masterCM)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile master configmap %s/%s: %w", masterCM.Namespace, masterCM.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("reconciled master configmap", "namespace", masterCM.Namespace, "name", masterCM.Name, "result", opRes)
+
 	masterDep := appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "nfd-master", Namespace: nfdInstance.Namespace},
+		ObjectMeta: metav1.ObjectMeta{Name: names.Master(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
 	}
-	opRes, err := controllerutil.CreateOrPatch(ctx, nfdh.client, &masterDep, func() error {
+	opRes, err = nfdh.createOrPatch(ctx, nfdInstance, &masterDep, func() error {
 		return nfdh.deploymentAPI.SetMasterDeploymentAsDesired(nfdInstance, &masterDep)
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to reconcile master deployment %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+		return fmt.Errorf("failed to reconcile master deployment %s/%s: %w", masterDep.Namespace, masterDep.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("reconciled master deployment", "namespace", masterDep.Namespace, "name", masterDep.Name, "result", opRes)
+
+	return nfdh.handleMasterPodDisruptionBudget(ctx, nfdInstance)
+}
+
+// handleMasterPodDisruptionBudget protects a highly available nfd-master
+// from node drains. A single replica is not protected, since the budget
+// would block the drain of its node
+func (nfdh *nodeFeatureDiscoveryHelper) handleMasterPodDisruptionBudget(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	if !nfdInstance.Spec.Master.IsHighlyAvailable() {
+		err := nfdh.deleteObject(ctx, nfdInstance, "PodDisruptionBudget", nfdInstance.GetOperandNamespace(), names.Master(nfdInstance), nfdh.pdbAPI.DeletePodDisruptionBudget)
+		if err != nil {
+			return fmt.Errorf("failed to delete master poddisruptionbudget: %w", err)
+		}
+		return nil
+	}
+
+	masterPDB := policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: names.Master(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
+	}
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &masterPDB, func() error {
+		return nfdh.pdbAPI.SetMasterPodDisruptionBudgetAsDesired(nfdInstance, &masterPDB)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile master poddisruptionbudget %s/%s: %w", masterPDB.Namespace, masterPDB.Name, err)
 	}
-	ctrl.LoggerFrom(ctx).Info("reconciled master deployment", "namespace", nfdInstance.Namespace, "name", nfdInstance.Name, "result", opRes)
+	ctrl.LoggerFrom(ctx).Info("reconciled master poddisruptionbudget", "namespace", masterPDB.Namespace, "name", masterPDB.Name, "result", opRes)
 	return nil
 }
 
 func (nfdh *nodeFeatureDiscoveryHelper) handleWorker(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	if len(nfdInstance.Spec.WorkerPools) == 0 {
+		err := nfdh.handleDefaultWorker(ctx, nfdInstance)
+		if err != nil {
+			return err
+		}
+		return nfdh.deleteWorkerPools(ctx, nfdInstance, nil)
+	}
+
+	// the worker pools replace the default worker
+	err := nfdh.deleteDefaultWorker(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	errs := make([]error, 0, len(nfdInstance.Spec.WorkerPools)+1)
+	for i := range nfdInstance.Spec.WorkerPools {
+		errs = append(errs, nfdh.handleWorkerPool(ctx, nfdInstance, &nfdInstance.Spec.WorkerPools[i]))
+	}
+	errs = append(errs, nfdh.deleteWorkerPools(ctx, nfdInstance, nfdInstance.Spec.WorkerPools))
+	return errors.Join(errs...)
+}
+
+func (nfdh *nodeFeatureDiscoveryHelper) handleDefaultWorker(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
 	logger := ctrl.LoggerFrom(ctx)
 
-	workerCM := corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: nfdInstance.Namespace},
+	if ref := nfdInstance.Spec.WorkerConfig.ConfigMapRef; ref != nil {
+		err := nfdh.checkWorkerConfigMapRef(ctx, nfdInstance.GetOperandNamespace(), ref)
+		if err != nil {
+			return err
+		}
+		// the operator owned ConfigMap is not used anymore
+		if ref.Name != names.Worker(nfdInstance) {
+			err = nfdh.deleteObject(ctx, nfdInstance, "ConfigMap", nfdInstance.GetOperandNamespace(), names.Worker(nfdInstance), nfdh.configmapAPI.DeleteConfigMap)
+			if err != nil {
+				return fmt.Errorf("failed to delete worker configmap: %w", err)
+			}
+		}
+	} else {
+		workerCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: names.Worker(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
+		}
+		cmRes, err := nfdh.createOrPatch(ctx, nfdInstance, &workerCM, func() error {
+			return nfdh.configmapAPI.SetWorkerConfigMapAsDesired(ctx, nfdInstance, &workerCM)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile worker configmap %s/%s: %w", workerCM.Namespace, workerCM.Name, err)
+		}
+		logger.Info("reconciled worker ConfigMap", "namespace", workerCM.Namespace, "name", workerCM.Name, "result", cmRes)
+	}
+
+	workerDS := appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: names.Worker(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
 	}
-	cmRes, err := controllerutil.CreateOrPatch(ctx, nfdh.client, &workerCM, func() error {
-		return nfdh.configmapAPI.SetWorkerConfigMapAsDesired(ctx, nfdInstance, &workerCM)
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &workerDS, func() error {
+		return nfdh.daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, nfdInstance, &workerDS)
 	})
 	if err != nil {
-		return fmt.Errorf("failed to reconcile worker configmap %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+		return fmt.Errorf("failed to reconcile worker DaemonSet %s/%s: %w", workerDS.Namespace, workerDS.Name, err)
+	}
+
+	logger.Info("reconciled worker DaemonSet", "namespace", workerDS.Namespace, "name", workerDS.Name, "result", opRes)
+
+	return nil
+}
+
+func (nfdh *nodeFeatureDiscoveryHelper) handleWorkerPool(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pool *nfdv1.WorkerPool) error {
+	logger := ctrl.LoggerFrom(ctx)
+	name := names.WorkerPool(nfdInstance, pool.Name)
+
+	if ref := pool.Config.ConfigMapRef; ref != nil {
+		err := nfdh.checkWorkerConfigMapRef(ctx, nfdInstance.GetOperandNamespace(), ref)
+		if err != nil {
+			return fmt.Errorf("worker pool %s: %w", pool.Name, err)
+		}
+	} else {
+		workerCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nfdInstance.GetOperandNamespace()},
+		}
+		cmRes, err := nfdh.createOrPatch(ctx, nfdInstance, &workerCM, func() error {
+			return nfdh.configmapAPI.SetWorkerPoolConfigMapAsDesired(ctx, nfdInstance, pool, &workerCM)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile worker pool configmap %s/%s: %w", nfdInstance.GetOperandNamespace(), name, err)
+		}
+		logger.Info("reconciled worker pool ConfigMap", "namespace", nfdInstance.GetOperandNamespace(), "name", name, "result", cmRes)
 	}
-	logger.Info("reconciled worker ConfigMap", "namespace", nfdInstance.Namespace, "name", nfdInstance.Name, "result", cmRes)
 
 	workerDS := appsv1.DaemonSet{
-		ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: nfdInstance.Namespace},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nfdInstance.GetOperandNamespace()},
 	}
-	opRes, err := controllerutil.CreateOrPatch(ctx, nfdh.client, &workerDS, func() error {
-		return nfdh.daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, nfdInstance, &workerDS)
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &workerDS, func() error {
+		return nfdh.daemonsetAPI.SetWorkerPoolDaemonsetAsDesired(ctx, nfdInstance, pool, &workerDS)
 	})
 	if err != nil {
-		return fmt.Errorf("failed to reconcile worker DaemonSet %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+		return fmt.Errorf("failed to reconcile worker pool DaemonSet %s/%s: %w", nfdInstance.GetOperandNamespace(), name, err)
+	}
+	logger.Info("reconciled worker pool DaemonSet", "namespace", nfdInstance.GetOperandNamespace(), "name", name, "result", opRes)
+	return nil
+}
+
+// checkWorkerConfigMapRef verifies that the user managed worker ConfigMap
+// and key exist, mounting a missing one would leave the worker pods pending
+func (nfdh *nodeFeatureDiscoveryHelper) checkWorkerConfigMapRef(ctx context.Context, namespace string, ref *nfdv1.WorkerConfigMapRef) error {
+	userCM, err := nfdh.configmapAPI.GetConfigMap(ctx, namespace, ref.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get worker configmap %s/%s: %w", namespace, ref.Name, err)
+	}
+	if _, ok := userCM.Data[ref.GetKey()]; !ok {
+		return fmt.Errorf("key %s not found in worker configmap %s/%s", ref.GetKey(), namespace, ref.Name)
+	}
+	return nil
+}
+
+func (nfdh *nodeFeatureDiscoveryHelper) deleteDefaultWorker(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	err := nfdh.deleteObject(ctx, nfdInstance, "DaemonSet", nfdInstance.GetOperandNamespace(), names.Worker(nfdInstance), nfdh.daemonsetAPI.DeleteDaemonSet)
+	if err != nil {
+		return fmt.Errorf("failed to delete worker daemonset: %w", err)
 	}
 
-	logger.Info("reconciled worker DaemonSet", "namespace", nfdInstance.Namespace, "name", nfdInstance.Name, "result", opRes)
+	// never delete a user managed worker ConfigMap that happens to have
+	// the name of the operator owned one
+	if ref := nfdInstance.Spec.WorkerConfig.ConfigMapRef; ref == nil || ref.Name != names.Worker(nfdInstance) {
+		err = nfdh.deleteObject(ctx, nfdInstance, "ConfigMap", nfdInstance.GetOperandNamespace(), names.Worker(nfdInstance), nfdh.configmapAPI.DeleteConfigMap)
+		if err != nil {
+			return fmt.Errorf("failed to delete worker config map: %w", err)
+		}
+	}
+	return nil
+}
 
+// deleteWorkerPools deletes the DaemonSets and ConfigMaps of the worker
+// pools that are not part of keepPools anymore
+func (nfdh *nodeFeatureDiscoveryHelper) deleteWorkerPools(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, keepPools []nfdv1.WorkerPool) error {
+	keepDS := make(map[string]bool, len(keepPools))
+	keepCM := make(map[string]bool, len(keepPools))
+	for _, pool := range keepPools {
+		keepDS[pool.Name] = true
+		keepCM[pool.Name] = pool.Config.ConfigMapRef == nil
+	}
+
+	poolDSs, err := nfdh.daemonsetAPI.ListWorkerPoolDaemonSets(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	for _, ds := range poolDSs {
+		// a pool named before the double hyphen separator is recreated
+		// under its current name
+		poolName := ds.Labels[names.WorkerPoolLabel]
+		if keepDS[poolName] && ds.Name == names.WorkerPool(nfdInstance, poolName) {
+			continue
+		}
+		err = nfdh.deleteObject(ctx, nfdInstance, "DaemonSet", ds.Namespace, ds.Name, nfdh.daemonsetAPI.DeleteDaemonSet)
+		if err != nil {
+			return fmt.Errorf("failed to delete worker pool daemonset: %w", err)
+		}
+		ctrl.LoggerFrom(ctx).Info("deleted worker pool DaemonSet", "namespace", ds.Namespace, "name", ds.Name)
+	}
+
+	poolCMs, err := nfdh.configmapAPI.ListWorkerPoolConfigMaps(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	for _, cm := range poolCMs {
+		poolName := cm.Labels[names.WorkerPoolLabel]
+		if keepCM[poolName] && cm.Name == names.WorkerPool(nfdInstance, poolName) {
+			continue
+		}
+		err = nfdh.deleteObject(ctx, nfdInstance, "ConfigMap", cm.Namespace, cm.Name, nfdh.configmapAPI.DeleteConfigMap)
+		if err != nil {
+			return fmt.Errorf("failed to delete worker pool configmap: %w", err)
+		}
+		ctrl.LoggerFrom(ctx).Info("deleted worker pool ConfigMap", "namespace", cm.Namespace, "name", cm.Name)
+	}
 	return nil
 }
 
 func (nfdh *nodeFeatureDiscoveryHelper) handleTopology(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
-	if !nfdInstance.Spec.TopologyUpdater {
-		return nil
+	if !nfdInstance.Spec.TopologyUpdater.Enabled {
+		return nfdh.deleteTopology(ctx, nfdInstance)
 	}
+	topologyCM := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: names.TopologyUpdater(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
+	}
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &topologyCM, func() error {
+		return nfdh.configmapAPI.SetTopologyUpdaterConfigMapAsDesired(ctx, nfdInstance, &topologyCM)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile topology configmap %s/%s: %w", topologyCM.Namespace, topologyCM.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("reconciled topology configmap", "namespace", topologyCM.Namespace, "name", topologyCM.Name, "result", opRes)
+
 	topologyDS := appsv1.DaemonSet{
-		ObjectMeta: metav1.ObjectMeta{Name: "nfd-topology-updater", Namespace: nfdInstance.Namespace},
+		ObjectMeta: metav1.ObjectMeta{Name: names.TopologyUpdater(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
 	}
-	opRes, err := controllerutil.CreateOrPatch(ctx, nfdh.client, &topologyDS, func() error {
+	opRes, err = nfdh.createOrPatch(ctx, nfdInstance, &topologyDS, func() error {
 		return nfdh.daemonsetAPI.SetTopologyDaemonsetAsDesired(ctx, nfdInstance, &topologyDS)
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to reconcile topology daemonset %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+		return fmt.Errorf("failed to reconcile topology daemonset %s/%s: %w", topologyDS.Namespace, topologyDS.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("reconciled topoplogy daemonset", "namespace", topologyDS.Namespace, "name", topologyDS.Name, "result", opRes)
+	return nil
+}
+
+// deleteTopology deletes the topology updater DaemonSet and ConfigMap, if
+// they exist
+func (nfdh *nodeFeatureDiscoveryHelper) deleteTopology(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	err := nfdh.deleteObject(ctx, nfdInstance, "DaemonSet", nfdInstance.GetOperandNamespace(), names.TopologyUpdater(nfdInstance), nfdh.daemonsetAPI.DeleteDaemonSet)
+	if err != nil {
+		return fmt.Errorf("failed to delete topology-updater daemonset: %w", err)
+	}
+	err = nfdh.deleteObject(ctx, nfdInstance, "ConfigMap", nfdInstance.GetOperandNamespace(), names.TopologyUpdater(nfdInstance), nfdh.configmapAPI.DeleteConfigMap)
+	if err != nil {
+		return fmt.Errorf("failed to delete topology-updater configmap: %w", err)
 	}
-	ctrl.LoggerFrom(ctx).Info("reconciled topoplogy daemonset", "namespace", nfdInstance.Namespace, "name", nfdInstance.Name, "result", opRes)
 	return nil
 }
 
 func (nfdh *nodeFeatureDiscoveryHelper) handleGC(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	if !nfdInstance.Spec.GC.IsEnabled() {
+		err := nfdh.deleteObject(ctx, nfdInstance, "Deployment", nfdInstance.GetOperandNamespace(), names.GC(nfdInstance), nfdh.deploymentAPI.DeleteDeployment)
+		if err != nil {
+			return fmt.Errorf("failed to delete nfd-gc deployment: %w", err)
+		}
+		return nil
+	}
 	gcDep := appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "nfd-gc", Namespace: nfdInstance.Namespace},
+		ObjectMeta: metav1.ObjectMeta{Name: names.GC(nfdInstance), Namespace: nfdInstance.GetOperandNamespace()},
 	}
-	opRes, err := controllerutil.CreateOrPatch(ctx, nfdh.client, &gcDep, func() error {
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &gcDep, func() error {
 		return nfdh.deploymentAPI.SetGCDeploymentAsDesired(nfdInstance, &gcDep)
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to reconcile nfd-gc deployment %s/%s: %w", nfdInstance.Namespace, nfdInstance.Name, err)
+		return fmt.Errorf("failed to reconcile nfd-gc deployment %s/%s: %w", gcDep.Namespace, gcDep.Name, err)
 	}
-	ctrl.LoggerFrom(ctx).Info("reconciled nfd-gc deployment", "namespace", nfdInstance.Namespace, "name", nfdInstance.Name, "result", opRes)
+	ctrl.LoggerFrom(ctx).Info("reconciled nfd-gc deployment", "namespace", gcDep.Namespace, "name", gcDep.Name, "result", opRes)
 	return nil
 }
 
+// handleMetrics exposes the metrics of every operand Deployment and
+// DaemonSet with a Service and, if the Prometheus operator is installed, a
+// ServiceMonitor. The objects of the operands that are not deployed anymore,
+// or all of them when spec.metrics is unset, are deleted
+func (nfdh *nodeFeatureDiscoveryHelper) handleMetrics(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	operands := getMetricsOperands(nfdInstance)
+	errs := make([]error, 0, len(operands)+3)
+	for _, operand := range operands {
+		svc := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: names.Metrics(operand), Namespace: nfdInstance.GetOperandNamespace()},
+		}
+		opRes, err := nfdh.createOrPatch(ctx, nfdInstance, &svc, func() error {
+			return nfdh.serviceAPI.SetMetricsServiceAsDesired(nfdInstance, operand, &svc)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile metrics service %s/%s: %w", svc.Namespace, svc.Name, err))
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info("reconciled metrics service", "namespace", svc.Namespace, "name", svc.Name, "result", opRes)
+	}
+	errs = append(errs, nfdh.deleteMetricsServices(ctx, nfdInstance, operands))
+	errs = append(errs, nfdh.handleMetricsServiceMonitors(ctx, nfdInstance, operands))
+	errs = append(errs, nfdh.handlePrometheusRule(ctx, nfdInstance))
+	return errors.Join(errs...)
+}
+
+// handleMetricsServiceMonitors scrapes the metrics Services of the operands,
+// unless the ServiceMonitor CRD is not installed
+func (nfdh *nodeFeatureDiscoveryHelper) handleMetricsServiceMonitors(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, operands []string) error {
+	supported, err := nfdh.serviceMonitorAPI.IsServiceMonitorSupported()
+	if err != nil || !supported {
+		return err
+	}
+
+	if nfdInstance.Spec.Metrics == nil || !nfdInstance.Spec.Metrics.ServiceMonitor.IsEnabled() {
+		operands = nil
+	}
+	errs := make([]error, 0, len(operands)+1)
+	for _, operand := range operands {
+		sm := servicemonitor.NewServiceMonitor(nfdInstance.GetOperandNamespace(), names.Metrics(operand))
+		opRes, err := nfdh.createOrPatch(ctx, nfdInstance, sm, func() error {
+			return nfdh.serviceMonitorAPI.SetMetricsServiceMonitorAsDesired(nfdInstance, operand, sm)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile metrics servicemonitor %s/%s: %w", sm.GetNamespace(), sm.GetName(), err))
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info("reconciled metrics servicemonitor", "namespace", sm.GetNamespace(), "name", sm.GetName(), "result", opRes)
+	}
+	errs = append(errs, nfdh.deleteMetricsServiceMonitors(ctx, nfdInstance, operands))
+	return errors.Join(errs...)
+}
+
+// handlePrometheusRule creates the PrometheusRule alerting on the health of
+// the operands if it is enabled in the CR, and deletes it otherwise. It is
+// skipped if the PrometheusRule CRD is not installed
+func (nfdh *nodeFeatureDiscoveryHelper) handlePrometheusRule(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	enabled := nfdInstance.Spec.Metrics != nil && nfdInstance.Spec.Metrics.PrometheusRule.Enabled
+	supported, err := nfdh.prometheusRuleAPI.IsPrometheusRuleSupported()
+	if err != nil {
+		return err
+	}
+	if !supported {
+		if enabled {
+			ctrl.LoggerFrom(ctx).Info("prometheusrule CRD is not installed, skipping the alerts")
+		}
+		return nil
+	}
+
+	name := names.PrometheusRule(nfdInstance)
+	if !enabled {
+		err = nfdh.deleteObject(ctx, nfdInstance, prometheusrule.GroupVersionKind.Kind, nfdInstance.GetOperandNamespace(), name, nfdh.prometheusRuleAPI.DeletePrometheusRule)
+		if err != nil {
+			return fmt.Errorf("failed to delete prometheusrule: %w", err)
+		}
+		return nil
+	}
+
+	rule := prometheusrule.NewPrometheusRule(nfdInstance.GetOperandNamespace(), name)
+	opRes, err := nfdh.createOrPatch(ctx, nfdInstance, rule, func() error {
+		return nfdh.prometheusRuleAPI.SetPrometheusRuleAsDesired(nfdInstance, rule)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile prometheusrule %s/%s: %w", rule.GetNamespace(), rule.GetName(), err)
+	}
+	ctrl.LoggerFrom(ctx).Info("reconciled prometheusrule", "namespace", rule.GetNamespace(), "name", rule.GetName(), "result", opRes)
+	return nil
+}
+
+// getMetricsOperands returns the names of the Deployments and DaemonSets
+// whose metrics are exposed, none if spec.metrics is unset
+func getMetricsOperands(nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
+	if nfdInstance.Spec.Metrics == nil {
+		return nil
+	}
+	operands := []string{names.Master(nfdInstance)}
+	if len(nfdInstance.Spec.WorkerPools) == 0 {
+		operands = append(operands, names.Worker(nfdInstance))
+	}
+	for _, pool := range nfdInstance.Spec.WorkerPools {
+		operands = append(operands, names.WorkerPool(nfdInstance, pool.Name))
+	}
+	if nfdInstance.Spec.TopologyUpdater.Enabled {
+		operands = append(operands, names.TopologyUpdater(nfdInstance))
+	}
+	if nfdInstance.Spec.GC.IsEnabled() {
+		operands = append(operands, names.GC(nfdInstance))
+	}
+	return operands
+}
+
+func (nfdh *nodeFeatureDiscoveryHelper) deleteMetricsServices(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, keepOperands []string) error {
+	svcs, err := nfdh.serviceAPI.ListMetricsServices(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	for _, svc := range svcs {
+		if slices.Contains(keepOperands, svc.Labels[names.MetricsLabel]) {
+			continue
+		}
+		err = nfdh.deleteObject(ctx, nfdInstance, "Service", svc.Namespace, svc.Name, nfdh.serviceAPI.DeleteService)
+		if err != nil {
+			return fmt.Errorf("failed to delete metrics service: %w", err)
+		}
+	}
+	return nil
+}
+
+func (nfdh *nodeFeatureDiscoveryHelper) deleteMetricsServiceMonitors(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, keepOperands []string) error {
+	sms, err := nfdh.serviceMonitorAPI.ListMetricsServiceMonitors(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	for _, sm := range sms {
+		if slices.Contains(keepOperands, sm.GetLabels()[names.MetricsLabel]) {
+			continue
+		}
+		err = nfdh.deleteObject(ctx, nfdInstance, servicemonitor.GroupVersionKind.Kind, sm.GetNamespace(), sm.GetName(), nfdh.serviceMonitorAPI.DeleteServiceMonitor)
+		if err != nil {
+			return fmt.Errorf("failed to delete metrics servicemonitor: %w", err)
+		}
+	}
+	return nil
+}
+
+// isInstanceTakenOver reports whether another NFD CR of the namespace, not
+// being deleted, runs the same instance in the same operand namespace as the
+// CR being deleted
+func (nfdh *nodeFeatureDiscoveryHelper) isInstanceTakenOver(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (bool, error) {
+	nfdList := nfdv1.NodeFeatureDiscoveryList{}
+	err := nfdh.client.List(ctx, &nfdList, client.InNamespace(nfdInstance.Namespace))
+	if err != nil {
+		return false, fmt.Errorf("failed to list NodeFeatureDiscoveries in namespace %s: %w", nfdInstance.Namespace, err)
+	}
+	for _, other := range nfdList.Items {
+		if other.UID == nfdInstance.UID || other.DeletionTimestamp != nil || other.Spec.Instance != nfdInstance.Spec.Instance ||
+			other.GetOperandNamespace() != nfdInstance.GetOperandNamespace() {
+			continue
+		}
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonTakenOver,
+			"NodeFeatureDiscovery %s/%s took over the instance, its objects and the NFD labels are kept", other.Namespace, other.Name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// getOperandNamespaceOwner returns the NFD CR deploying its operands in the
+// operand namespace of nfdInstance, nil when it is nfdInstance itself. The
+// operands, their RBAC and the NFD labels of an operand namespace are shared,
+// so the oldest CR using it owns it. A CR being deleted is skipped once it
+// has been taken over by the CR of the same instance
+func (nfdh *nodeFeatureDiscoveryHelper) getOperandNamespaceOwner(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (*nfdv1.NodeFeatureDiscovery, error) {
+	nfdList := nfdv1.NodeFeatureDiscoveryList{}
+	err := nfdh.client.List(ctx, &nfdList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NodeFeatureDiscoveries: %w", err)
+	}
+	var owner *nfdv1.NodeFeatureDiscovery
+	for i := range nfdList.Items {
+		other := &nfdList.Items[i]
+		if other.GetOperandNamespace() != nfdInstance.GetOperandNamespace() {
+			continue
+		}
+		if other.UID != nfdInstance.UID && other.DeletionTimestamp != nil &&
+			other.Namespace == nfdInstance.Namespace && other.Spec.Instance == nfdInstance.Spec.Instance {
+			continue
+		}
+		if owner == nil || isOlder(other, owner) {
+			owner = other
+		}
+	}
+	if owner == nil || owner.UID == nfdInstance.UID {
+		return nil, nil
+	}
+	return owner, nil
+}
+
+// isOlder orders the NFD CRs by creation time, then by namespace and name
+func isOlder(a, b *nfdv1.NodeFeatureDiscovery) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// handleOperandNamespaceConflict reports a CR whose operand namespace is
+// owned by another CR in its status. Nothing is deployed for it, the error
+// retries the reconcile until the operand namespace is changed or released
+func (nfdh *nodeFeatureDiscoveryHelper) handleOperandNamespaceConflict(ctx context.Context, nfdInstance, owner *nfdv1.NodeFeatureDiscovery) error {
+	nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonNamespaceConflict,
+		"operand namespace %s is used by NodeFeatureDiscovery %s/%s", nfdInstance.GetOperandNamespace(), owner.Namespace, owner.Name)
+	unmodifiedCR := nfdInstance.DeepCopy()
+	nfdInstance.Status.Conditions = nfdh.statusAPI.MergeConditions(nfdInstance.Status.Conditions,
+		nfdh.statusAPI.GetOperandNamespaceConflictConditions(nfdInstance, owner), nfdInstance.Generation)
+	nfdInstance.Status.ObservedGeneration = nfdInstance.Generation
+	if !equality.Semantic.DeepEqual(unmodifiedCR.Status, nfdInstance.Status) {
+		err := nfdh.client.Status().Patch(ctx, nfdInstance, client.MergeFrom(unmodifiedCR))
+		if err != nil {
+			return fmt.Errorf("failed to report the operand namespace conflict: %w", err)
+		}
+	}
+	return fmt.Errorf("operand namespace %s is used by NodeFeatureDiscovery %s/%s", nfdInstance.GetOperandNamespace(), owner.Namespace, owner.Name)
+}
+
+// getPruneTime returns when the prune job of a CR being deleted starts: on
+// deletion, or once the delay of the PruneAfterDelay deletion policy has
+// passed
+func getPruneTime(nfdInstance *nfdv1.NodeFeatureDiscovery) time.Time {
+	if nfdInstance.DeletionTimestamp == nil {
+		return time.Time{}
+	}
+	deletedAt := nfdInstance.DeletionTimestamp.Time
+	if nfdInstance.Spec.GetDeletionPolicy() != nfdv1.DeletionPolicyPruneAfterDelay {
+		return deletedAt
+	}
+	return deletedAt.Add(nfdInstance.Spec.PrunePolicy.GetDelay())
+}
+
 func (nfdh *nodeFeatureDiscoveryHelper) handlePrune(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (bool, error) {
-	if !nfdInstance.Spec.PruneOnDelete {
+	if nfdInstance.Spec.GetDeletionPolicy() == nfdv1.DeletionPolicyOrphan {
 		return true, nil
 	}
 
-	pruneJob, err := nfdh.jobAPI.GetJob(ctx, nfdInstance.Namespace, "nfd-prune")
+	pruneJob, err := nfdh.jobAPI.GetJob(ctx, nfdInstance.GetOperandNamespace(), names.Prune(nfdInstance))
 	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			err = nfdh.jobAPI.CreatePruneJob(ctx, nfdInstance)
-			if err != nil {
-				return false, fmt.Errorf("failed to create nfd-prune job: %w", err)
-			}
-			return false, nil
+		if !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get nfd-prune job: %w", err)
+		}
+		// the succeeded job was removed before the finalizer could be
+		if nfdInstance.DeletionTimestamp != nil && status.IsPruneJobSucceededSince(nfdInstance.Status.Conditions, nfdInstance.DeletionTimestamp.Time) {
+			return true, nil
+		}
+		if pruneAt := getPruneTime(nfdInstance); time.Now().Before(pruneAt) {
+			return false, nfdh.setPruneCondition(ctx, nfdInstance, nfdh.statusAPI.GetPruneDelayedCondition(nfdInstance, pruneAt))
+		}
+		err = nfdh.handleSnapshot(ctx, nfdInstance, string(nfdInstance.UID))
+		if err != nil {
+			return false, err
+		}
+		err = nfdh.jobAPI.CreatePruneJob(ctx, nfdInstance)
+		if err != nil {
+			nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonPruneFailed, "failed to create prune job: %v", err)
+			return false, fmt.Errorf("failed to create nfd-prune job: %w", err)
 		}
-		return false, fmt.Errorf("failed to get nfd-prune job: %w", err)
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonPruneStarted,
+			"started prune job %s/%s to remove the NFD labels from the nodes", nfdInstance.GetOperandNamespace(), names.Prune(nfdInstance))
+		return false, nfdh.setPruneCondition(ctx, nfdInstance, nfdh.statusAPI.GetPruneCondition(nfdInstance, nil))
 	}
+	nfdh.metricsAPI.ObservePruneJob(nfdInstance, pruneJob)
 
-	var returnErr error
 	done := false
-	if pruneJob.Status.Succeeded > 0 {
+	if job.IsSucceeded(pruneJob) {
 		done = true
-	}
-	if pruneJob.Status.Failed > 0 {
-		returnErr = fmt.Errorf("prune job's pod has failed")
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonPruneSucceeded,
+			"prune job %s/%s succeeded", pruneJob.Namespace, pruneJob.Name)
+	} else if failed := job.GetFailedCondition(pruneJob); failed != nil {
+		// the job is not retried anymore: either the deletion waits for the
+		// job to be deleted, by the user or once its TTL expires, and run
+		// again, or the operator gives up on pruning
+		done = nfdInstance.Spec.PrunePolicy.GetOnFailure() == nfdv1.PruneFailureActionRemoveFinalizer
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonPruneFailed,
+			"prune job %s/%s failed: %s, onFailure is %s", pruneJob.Namespace, pruneJob.Name, failed.Reason,
+			nfdInstance.Spec.PrunePolicy.GetOnFailure())
 	}
 
 	// no need to explicitly delete Prune job,
 	// it will be deleted by K8S scheduler once NFD CR is deleted from etcd
-	return done, returnErr
+	return done, nfdh.setPruneCondition(ctx, nfdInstance, nfdh.statusAPI.GetPruneCondition(nfdInstance, pruneJob))
 }
 
-func (nfdh *nodeFeatureDiscoveryHelper) handleStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
-	conditions := nfdh.statusAPI.GetConditions(ctx, nfdInstance)
-	if nfdh.statusAPI.AreConditionsEqual(nfdInstance.Status.Conditions, conditions) {
+// handleSnapshot saves what the prune job is about to remove from the nodes
+// to a ConfigMap that outlives the CR, so that an accidental prune can be
+// recovered with handleRestoreSnapshot. The prune job is not started until
+// the snapshot is saved. pruneID identifies the prune and names the snapshot,
+// so that a job started again for the same prune keeps the first snapshot.
+// Only the newest snapshot.HistoryLimit snapshots of the CR are kept
+func (nfdh *nodeFeatureDiscoveryHelper) handleSnapshot(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pruneID string) error {
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: nfdInstance.Namespace, Name: names.Snapshot(nfdInstance, pruneID)},
+	}
+	_, err := nfdh.createOrPatch(ctx, nfdInstance, &cm, func() error {
+		return nfdh.snapshotAPI.SetSnapshotConfigMapAsDesired(ctx, nfdInstance, pruneID, &cm)
+	})
+	if err != nil {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonSnapshotFailed,
+			"failed to snapshot the NFD labels before pruning them: %v", err)
+		return fmt.Errorf("failed to snapshot the NFD labels: %w", err)
+	}
+
+	snapshots, err := nfdh.snapshotAPI.ListSnapshots(ctx, nfdInstance)
+	if err != nil {
+		return fmt.Errorf("failed to list the NFD labels snapshots: %w", err)
+	}
+	// the snapshot just saved is kept whatever its creation time
+	kept := 1
+	for _, s := range snapshots {
+		if s.Name == cm.Name {
+			continue
+		}
+		if kept < snapshot.HistoryLimit {
+			kept++
+			continue
+		}
+		err = nfdh.deleteObject(ctx, nfdInstance, "ConfigMap", s.Namespace, s.Name, nfdh.configmapAPI.DeleteConfigMap)
+		if err != nil {
+			return fmt.Errorf("failed to delete snapshot configmap %s/%s: %w", s.Namespace, s.Name, err)
+		}
+	}
+	return nil
+}
+
+// handlePruneRequest runs the prune job requested by the prune annotation of
+// the CR, and reports whether the prune is in progress. The job is named
+// after the request, so that it is never taken for the job pruning on
+// deletion. The workers are deleted while the job runs, and recreated by
+// handleWorker once the job completed, so that they relabel the nodes from
+// scratch. The result of the job is kept in the Pruned condition, then the
+// job and the annotation are removed
+func (nfdh *nodeFeatureDiscoveryHelper) handlePruneRequest(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) (bool, error) {
+	request, ok := nfdInstance.Annotations[nfdv1.PruneRequestedAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	jobName := names.PruneRequest(nfdInstance, request)
+	pruneJob, err := nfdh.jobAPI.GetJob(ctx, nfdInstance.GetOperandNamespace(), jobName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return true, fmt.Errorf("failed to get nfd-prune job: %w", err)
+		}
+		err = nfdh.pauseWorkers(ctx, nfdInstance)
+		if err != nil {
+			return true, err
+		}
+		err = nfdh.handleSnapshot(ctx, nfdInstance, string(nfdInstance.UID)+"/"+request)
+		if err != nil {
+			return true, err
+		}
+		err = nfdh.jobAPI.CreatePruneRequestJob(ctx, nfdInstance, request)
+		if err != nil {
+			nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonPruneFailed, "failed to create prune job: %v", err)
+			return true, fmt.Errorf("failed to create nfd-prune job: %w", err)
+		}
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonPruneStarted,
+			"started prune job %s/%s to remove the NFD labels from the nodes", nfdInstance.GetOperandNamespace(), jobName)
+		return true, nfdh.setPruneCondition(ctx, nfdInstance, nfdh.statusAPI.GetPruneCondition(nfdInstance, nil))
+	}
+	nfdh.metricsAPI.ObservePruneJob(nfdInstance, pruneJob)
+
+	condition := nfdh.statusAPI.GetPruneCondition(nfdInstance, pruneJob)
+	if job.IsSucceeded(pruneJob) {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonPruneSucceeded,
+			"prune job %s/%s succeeded", pruneJob.Namespace, pruneJob.Name)
+	} else if failed := job.GetFailedCondition(pruneJob); failed != nil {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonPruneFailed,
+			"prune job %s/%s failed: %s", pruneJob.Namespace, pruneJob.Name, failed.Reason)
+	} else {
+		// a worker recreated by an update of the CR is deleted again
+		return true, errors.Join(nfdh.pauseWorkers(ctx, nfdInstance), nfdh.setPruneCondition(ctx, nfdInstance, condition))
+	}
+
+	err = nfdh.setPruneCondition(ctx, nfdInstance, condition)
+	if err != nil {
+		return true, err
+	}
+	err = nfdh.deleteObject(ctx, nfdInstance, "Job", pruneJob.Namespace, pruneJob.Name, nfdh.jobAPI.DeleteJob)
+	if err != nil {
+		return true, fmt.Errorf("failed to delete nfd-prune job: %w", err)
+	}
+	unmodifiedCR := nfdInstance.DeepCopy()
+	delete(nfdInstance.Annotations, nfdv1.PruneRequestedAnnotation)
+	err = nfdh.client.Patch(ctx, nfdInstance, client.MergeFrom(unmodifiedCR))
+	if err != nil {
+		return true, fmt.Errorf("failed to remove the %s annotation: %w", nfdv1.PruneRequestedAnnotation, err)
+	}
+	return false, nil
+}
+
+// pauseWorkers deletes the DaemonSets of the default worker and of the
+// worker pools, along with their operator owned ConfigMaps
+func (nfdh *nodeFeatureDiscoveryHelper) pauseWorkers(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	err := nfdh.deleteDefaultWorker(ctx, nfdInstance)
+	if err != nil {
+		return err
+	}
+	return nfdh.deleteWorkerPools(ctx, nfdInstance, nil)
+}
+
+// handleRestoreSnapshot re-applies to the nodes the snapshot named by the
+// restore annotation of the CR, then removes the annotation. A failed restore
+// keeps the annotation, so that it is retried on the next reconcile
+func (nfdh *nodeFeatureDiscoveryHelper) handleRestoreSnapshot(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	name, ok := nfdInstance.Annotations[nfdv1.RestoreSnapshotAnnotation]
+	if !ok {
+		return nil
+	}
+	restored, err := nfdh.snapshotAPI.RestoreSnapshot(ctx, nfdInstance, name)
+	if err != nil {
+		nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeWarning, eventReasonRestoreFailed,
+			"failed to restore snapshot %s/%s: %v", nfdInstance.Namespace, name, err)
+		return fmt.Errorf("failed to restore snapshot %s/%s: %w", nfdInstance.Namespace, name, err)
+	}
+	nfdh.recorder.Eventf(nfdInstance, corev1.EventTypeNormal, eventReasonRestored,
+		"restored snapshot %s/%s on %d nodes", nfdInstance.Namespace, name, restored)
+
+	unmodifiedCR := nfdInstance.DeepCopy()
+	delete(nfdInstance.Annotations, nfdv1.RestoreSnapshotAnnotation)
+	err = nfdh.client.Patch(ctx, nfdInstance, client.MergeFrom(unmodifiedCR))
+	if err != nil {
+		return fmt.Errorf("failed to remove the %s annotation: %w", nfdv1.RestoreSnapshotAnnotation, err)
+	}
+	return nil
+}
+
+// setPruneCondition patches the Pruned condition of a CR being deleted. The
+// other conditions are left as is, since the components are being removed
+func (nfdh *nodeFeatureDiscoveryHelper) setPruneCondition(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, condition metav1.Condition) error {
+	unmodifiedCR := nfdInstance.DeepCopy()
+	condition.ObservedGeneration = nfdInstance.Generation
+	meta.SetStatusCondition(&nfdInstance.Status.Conditions, condition)
+	if equality.Semantic.DeepEqual(unmodifiedCR.Status, nfdInstance.Status) {
 		return nil
 	}
+	err := nfdh.client.Status().Patch(ctx, nfdInstance, client.MergeFrom(unmodifiedCR))
+	if err != nil {
+		return fmt.Errorf("failed to set the %s condition: %w", condition.Type, err)
+	}
+	return nil
+}
+
+// handleStatus patches the status of the NFD CR if it changed. The status
+// records the generation of the spec it was computed from, so that clients
+// can tell whether the latest spec has been processed. The metrics of the
+// components and of the conditions are exported from that status
+func (nfdh *nodeFeatureDiscoveryHelper) handleStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	conditions := append(nfdh.statusAPI.GetConditions(ctx, nfdInstance), nfdh.statusAPI.GetComponentsCondition(ctx, nfdInstance))
 	unmodifiedCR := nfdInstance.DeepCopy()
-	nfdInstance.Status.Conditions = conditions
+	nfdInstance.Status.Conditions = nfdh.statusAPI.MergeConditions(nfdInstance.Status.Conditions, conditions, nfdInstance.Generation)
+	nfdInstance.Status.WorkerPools = nfdh.statusAPI.GetWorkerPoolsStatus(ctx, nfdInstance)
+	nfdInstance.Status.Components = nfdh.statusAPI.GetComponentsStatus(ctx, nfdInstance)
+	nfdInstance.Status.ObservedGeneration = nfdInstance.Generation
+	nfdh.metricsAPI.SetComponentsStatus(nfdInstance, nfdInstance.Status.Components)
+	nfdh.metricsAPI.SetConditions(nfdInstance, nfdInstance.Status.Conditions)
+	if equality.Semantic.DeepEqual(unmodifiedCR.Status, nfdInstance.Status) {
+		return nil
+	}
 	return nfdh.client.Status().Patch(ctx, nfdInstance, client.MergeFrom(unmodifiedCR))
 }
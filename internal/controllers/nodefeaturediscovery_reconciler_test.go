@@ -19,6 +19,7 @@ package new_controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -26,9 +27,15 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -39,22 +46,34 @@ import (
 	"sigs.k8s.io/node-feature-discovery-operator/internal/daemonset"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/deployment"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/metrics"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/namespace"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/poddisruptionbudget"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/prometheusrule"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/rbac"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/service"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/servicemonitor"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/snapshot"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/status"
 )
 
 var _ = Describe("Reconcile", func() {
 	var (
-		ctrl       *gomock.Controller
-		mockHelper *MocknodeFeatureDiscoveryHelperAPI
-		nfdr       *nodeFeatureDiscoveryReconciler
+		ctrl        *gomock.Controller
+		mockHelper  *MocknodeFeatureDiscoveryHelperAPI
+		mockMetrics *metrics.MockMetricsAPI
+		nfdr        *nodeFeatureDiscoveryReconciler
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockHelper = NewMocknodeFeatureDiscoveryHelperAPI(ctrl)
+		mockMetrics = metrics.NewMockMetricsAPI(ctrl)
 
 		nfdr = &nodeFeatureDiscoveryReconciler{
-			helper: mockHelper,
+			helper:     mockHelper,
+			metricsAPI: mockMetrics,
 		}
 	})
 
@@ -64,10 +83,43 @@ var _ = Describe("Reconcile", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{}
 
 		mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(true)
+		mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil)
+		mockHelper.EXPECT().handleOperandNamespace(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleOperandRBAC(ctx, &nfdCR).Return(nil)
 		mockHelper.EXPECT().handleMaster(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentMaster, nil)
+		mockHelper.EXPECT().handlePruneRequest(ctx, &nfdCR).Return(false, nil)
 		mockHelper.EXPECT().handleWorker(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentWorker, nil)
 		mockHelper.EXPECT().handleTopology(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentTopologyUpdater, nil)
 		mockHelper.EXPECT().handleGC(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentGC, nil)
+		mockHelper.EXPECT().handleMetrics(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleRestoreSnapshot(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleStatus(ctx, &nfdCR).Return(nil)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).To(BeNil())
+	})
+
+	It("the worker is not reconciled while a requested prune is in progress", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(true)
+		mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil)
+		mockHelper.EXPECT().handleOperandNamespace(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleOperandRBAC(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleMaster(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentMaster, nil)
+		mockHelper.EXPECT().handlePruneRequest(ctx, &nfdCR).Return(true, nil)
+		mockHelper.EXPECT().handleTopology(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentTopologyUpdater, nil)
+		mockHelper.EXPECT().handleGC(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentGC, nil)
+		mockHelper.EXPECT().handleMetrics(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleRestoreSnapshot(ctx, &nfdCR).Return(nil)
 		mockHelper.EXPECT().handleStatus(ctx, &nfdCR).Return(nil)
 
 		res, err := nfdr.Reconcile(ctx, &nfdCR)
@@ -80,6 +132,8 @@ var _ = Describe("Reconcile", func() {
 		timestamp := metav1.Now()
 		nfdCR.SetDeletionTimestamp(&timestamp)
 
+		mockHelper.EXPECT().isInstanceTakenOver(ctx, &nfdCR).Return(false, nil)
+		mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil)
 		if finalizeComponentsError {
 			mockHelper.EXPECT().finalizeComponents(ctx, &nfdCR).Return(fmt.Errorf("some error"))
 			goto executeTestFunction
@@ -94,11 +148,13 @@ var _ = Describe("Reconcile", func() {
 			goto executeTestFunction
 		}
 		mockHelper.EXPECT().handlePrune(ctx, &nfdCR).Return(true, nil)
+		mockHelper.EXPECT().deleteOperandRBAC(ctx, &nfdCR).Return(nil)
 		if removeFinalizerError {
 			mockHelper.EXPECT().removeFinalizer(ctx, &nfdCR).Return(fmt.Errorf("some error"))
 			goto executeTestFunction
 		}
 		mockHelper.EXPECT().removeFinalizer(ctx, &nfdCR).Return(nil)
+		mockMetrics.EXPECT().DeleteInstance(&nfdCR)
 
 	executeTestFunction:
 
@@ -117,6 +173,61 @@ var _ = Describe("Reconcile", func() {
 		Entry("fully successfull flow", false, false, true, false),
 	)
 
+	It("instance taken over by another CR, the finalizer is removed right away", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		timestamp := metav1.Now()
+		nfdCR.SetDeletionTimestamp(&timestamp)
+		gomock.InOrder(
+			mockHelper.EXPECT().isInstanceTakenOver(ctx, &nfdCR).Return(true, nil),
+			mockHelper.EXPECT().removeFinalizer(ctx, &nfdCR).Return(nil),
+			mockMetrics.EXPECT().DeleteInstance(&nfdCR),
+		)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).To(BeNil())
+	})
+
+	It("operand namespace owned by another CR, the finalizer is removed right away", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		timestamp := metav1.Now()
+		nfdCR.SetDeletionTimestamp(&timestamp)
+		owner := nfdv1.NodeFeatureDiscovery{ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "other-cr"}}
+		gomock.InOrder(
+			mockHelper.EXPECT().isInstanceTakenOver(ctx, &nfdCR).Return(false, nil),
+			mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(&owner, nil),
+			mockHelper.EXPECT().removeFinalizer(ctx, &nfdCR).Return(nil),
+			mockMetrics.EXPECT().DeleteInstance(&nfdCR),
+		)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).To(BeNil())
+	})
+
+	It("prune delayed, reconcile is requeued once the delay has passed", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				DeletionPolicy: nfdv1.DeletionPolicyPruneAfterDelay,
+				PrunePolicy: nfdv1.PrunePolicy{
+					Delay: &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+		}
+		timestamp := metav1.Now()
+		nfdCR.SetDeletionTimestamp(&timestamp)
+		gomock.InOrder(
+			mockHelper.EXPECT().isInstanceTakenOver(ctx, &nfdCR).Return(false, nil),
+			mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil),
+			mockHelper.EXPECT().finalizeComponents(ctx, &nfdCR).Return(nil),
+			mockHelper.EXPECT().handlePrune(ctx, &nfdCR).Return(false, nil),
+		)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(res.RequeueAfter).To(BeNumerically("~", 30*time.Minute, time.Minute))
+	})
+
 	DescribeTable("setFinalizer flow", func(setFinalizerError error) {
 		nfdCR := nfdv1.NodeFeatureDiscovery{}
 		mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(false)
@@ -134,36 +245,92 @@ var _ = Describe("Reconcile", func() {
 		Entry("setFinalizer succeeded", fmt.Errorf("set finalizer error")),
 	)
 
+	It("components are not reconciled if the operand RBAC cannot be created", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		gomock.InOrder(
+			mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(true),
+			mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil),
+			mockHelper.EXPECT().handleOperandNamespace(ctx, &nfdCR).Return(nil),
+			mockHelper.EXPECT().handleOperandRBAC(ctx, &nfdCR).Return(fmt.Errorf("some error")),
+		)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("components are not reconciled if another CR uses the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		owner := nfdv1.NodeFeatureDiscovery{ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "other-cr"}}
+		gomock.InOrder(
+			mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(true),
+			mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(&owner, nil),
+			mockHelper.EXPECT().handleOperandNamespaceConflict(ctx, &nfdCR, &owner).Return(fmt.Errorf("some error")),
+		)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("components are not reconciled if the operand namespace cannot be created", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		gomock.InOrder(
+			mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(true),
+			mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil),
+			mockHelper.EXPECT().handleOperandNamespace(ctx, &nfdCR).Return(fmt.Errorf("some error")),
+		)
+
+		res, err := nfdr.Reconcile(ctx, &nfdCR)
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).To(HaveOccurred())
+	})
+
 	DescribeTable("check components error flows", func(handlerMasterError,
 		handlerWorkerError,
 		handleTopologyError,
 		handlerGCError,
 		handlePruneError,
+		handleMetricsError,
+		handleRestoreError,
 		handleStatusError error) {
 		nfdCR := nfdv1.NodeFeatureDiscovery{}
 
 		mockHelper.EXPECT().hasFinalizer(&nfdCR).Return(true)
+		mockHelper.EXPECT().getOperandNamespaceOwner(ctx, &nfdCR).Return(nil, nil)
+		mockHelper.EXPECT().handleOperandNamespace(ctx, &nfdCR).Return(nil)
+		mockHelper.EXPECT().handleOperandRBAC(ctx, &nfdCR).Return(nil)
 		mockHelper.EXPECT().handleMaster(ctx, &nfdCR).Return(handlerMasterError)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentMaster, handlerMasterError)
+		mockHelper.EXPECT().handlePruneRequest(ctx, &nfdCR).Return(false, handlePruneError)
 		mockHelper.EXPECT().handleWorker(ctx, &nfdCR).Return(handlerWorkerError)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentWorker, handlerWorkerError)
 		mockHelper.EXPECT().handleTopology(ctx, &nfdCR).Return(handleTopologyError)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentTopologyUpdater, handleTopologyError)
 		mockHelper.EXPECT().handleGC(ctx, &nfdCR).Return(handlerGCError)
+		mockMetrics.EXPECT().ObserveReconcile(&nfdCR, componentGC, handlerGCError)
+		mockHelper.EXPECT().handleMetrics(ctx, &nfdCR).Return(handleMetricsError)
+		mockHelper.EXPECT().handleRestoreSnapshot(ctx, &nfdCR).Return(handleRestoreError)
 		mockHelper.EXPECT().handleStatus(ctx, &nfdCR).Return(handleStatusError)
 
 		res, err := nfdr.Reconcile(ctx, &nfdCR)
 		Expect(res).To(Equal(reconcile.Result{}))
 		if handlerMasterError != nil || handlerWorkerError != nil || handleTopologyError != nil ||
-			handlerGCError != nil || handlePruneError != nil || handleStatusError != nil {
+			handlerGCError != nil || handlePruneError != nil || handleMetricsError != nil || handleRestoreError != nil || handleStatusError != nil {
 			Expect(err).To(HaveOccurred())
 		} else {
 			Expect(err).To(BeNil())
 		}
 	},
-		Entry("handleMaster failed", fmt.Errorf("master error"), nil, nil, nil, nil, nil),
-		Entry("handleWorker failed", nil, fmt.Errorf("worker error"), nil, nil, nil, nil),
-		Entry("handleTopology failed", nil, nil, fmt.Errorf("topology error"), nil, nil, nil),
-		Entry("handleGC failed", nil, nil, nil, fmt.Errorf("gc error"), nil, nil),
-		Entry("handleStatus failed", nil, nil, nil, nil, nil, fmt.Errorf("status error")),
-		Entry("all components succeeded", nil, nil, nil, nil, nil, nil),
+		Entry("handleMaster failed", fmt.Errorf("master error"), nil, nil, nil, nil, nil, nil, nil),
+		Entry("handleWorker failed", nil, fmt.Errorf("worker error"), nil, nil, nil, nil, nil, nil),
+		Entry("handleTopology failed", nil, nil, fmt.Errorf("topology error"), nil, nil, nil, nil, nil),
+		Entry("handleGC failed", nil, nil, nil, fmt.Errorf("gc error"), nil, nil, nil, nil),
+		Entry("handlePruneRequest failed", nil, nil, nil, nil, fmt.Errorf("prune error"), nil, nil, nil),
+		Entry("handleMetrics failed", nil, nil, nil, nil, nil, fmt.Errorf("metrics error"), nil, nil),
+		Entry("handleRestoreSnapshot failed", nil, nil, nil, nil, nil, nil, fmt.Errorf("restore error"), nil),
+		Entry("handleStatus failed", nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("status error")),
+		Entry("all components succeeded", nil, nil, nil, nil, nil, nil, nil, nil),
 	)
 })
 
@@ -172,6 +339,9 @@ var _ = Describe("handleMaster", func() {
 		ctrl           *gomock.Controller
 		clnt           *client.MockClient
 		mockDeployment *deployment.MockDeploymentAPI
+		mockPDB        *poddisruptionbudget.MockPodDisruptionBudgetAPI
+		mockCM         *configmap.MockConfigMapAPI
+		recorder       *record.FakeRecorder
 		nfdh           nodeFeatureDiscoveryHelperAPI
 	)
 
@@ -179,25 +349,84 @@ var _ = Describe("handleMaster", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		mockPDB = poddisruptionbudget.NewMockPodDisruptionBudgetAPI(ctrl)
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
+		recorder = record.NewFakeRecorder(10)
 
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, mockDeployment, nil, nil, nil, nil, scheme)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, mockDeployment, nil, mockCM, nil, nil, mockPDB, nil, nil, nil, nil, nil, recorder, scheme, nil)
 	})
 
 	ctx := context.Background()
 
-	It("should create new nfd-master deployment if it does not exist", func() {
+	It("should create new nfd-master configmap and deployment if they do not exist", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{}
 		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(nil),
 			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
 			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
 			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockPDB.EXPECT().DeletePodDisruptionBudget(ctx, nfdCR.Namespace, "nfd-master").Return(true, nil),
+		)
+
+		err := nfdh.handleMaster(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(recorder.Events).To(HaveLen(3))
+		Expect(<-recorder.Events).To(Equal("Normal Created created ConfigMap /nfd-master"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Deployment /nfd-master"))
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted PodDisruptionBudget /nfd-master"))
+	})
+
+	It("nfd-master deployment is created in the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: "nfd-operands", Name: "nfd-master"}, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: "nfd-operands", Name: "nfd-master"}, gomock.Any()).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockPDB.EXPECT().DeletePodDisruptionBudget(ctx, "nfd-operands", "nfd-master").Return(false, nil),
+		)
+
+		err := nfdh.handleMaster(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal Created created ConfigMap nfd-operands/nfd-master"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Deployment nfd-operands/nfd-master"))
+	})
+
+	It("master configmap of a named instance is created before the deployment", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+			Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "blue"},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: "test-namespace", Name: "nfd-master-blue"}, gomock.Any()).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: "test-namespace", Name: "nfd-master-blue"}, gomock.Any()).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockPDB.EXPECT().DeletePodDisruptionBudget(ctx, "test-namespace", "nfd-master-blue").Return(false, nil),
 		)
 
 		err := nfdh.handleMaster(ctx, &nfdCR)
 		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal Created created ConfigMap test-namespace/nfd-master-blue"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Deployment test-namespace/nfd-master-blue"))
 	})
 
-	It("deployment exists, no need to create it, update is not executed", func() {
+	It("configmap and deployment exist, no need to create them, update is not executed", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "nfd-cr",
@@ -208,6 +437,14 @@ var _ = Describe("handleMaster", func() {
 			ObjectMeta: metav1.ObjectMeta{Namespace: nfdCR.Namespace, Name: "nfd-master"},
 		}
 		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, _ interface{}, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					cm.SetName("nfd-master")
+					cm.SetNamespace(nfdCR.Namespace)
+					return nil
+				},
+			),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
 			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
 				func(_ interface{}, _ interface{}, dp *appsv1.Deployment, _ ...ctrlclient.GetOption) error {
 					dp.SetName(existingDeployment.Name)
@@ -216,21 +453,72 @@ var _ = Describe("handleMaster", func() {
 				},
 			),
 			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, &existingDeployment).Return(nil),
+			mockPDB.EXPECT().DeletePodDisruptionBudget(ctx, nfdCR.Namespace, "nfd-master").Return(false, nil),
+		)
+
+		err := nfdh.handleMaster(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("several master replicas, pdb is created", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Master: nfdv1.MasterSpec{Replicas: ptr.To[int32](3)},
+			},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&appsv1.Deployment{})).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&policyv1.PodDisruptionBudget{})).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockPDB.EXPECT().SetMasterPodDisruptionBudgetAsDesired(&nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&policyv1.PodDisruptionBudget{})).Return(nil),
 		)
 
 		err := nfdh.handleMaster(ctx, &nfdCR)
 		Expect(err).To(BeNil())
 	})
 
+	It("error flow, failed to delete the pdb of a single master replica", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockPDB.EXPECT().DeletePodDisruptionBudget(ctx, nfdCR.Namespace, "nfd-master").Return(false, fmt.Errorf("some error")),
+		)
+
+		err := nfdh.handleMaster(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.Events).To(HaveLen(3))
+		Expect(<-recorder.Events).To(Equal("Normal Created created ConfigMap /nfd-master"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Deployment /nfd-master"))
+		Expect(<-recorder.Events).To(Equal("Warning FailedDelete failed to delete PodDisruptionBudget /nfd-master: some error"))
+	})
+
 	It("error flow, failed to populate deployment object", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{}
 		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetMasterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(nil),
 			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
 			mockDeployment.EXPECT().SetMasterDeploymentAsDesired(&nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
 		)
 
 		err := nfdh.handleMaster(ctx, &nfdCR)
 		Expect(err).To(HaveOccurred())
+		Expect(recorder.Events).To(HaveLen(2))
+		Expect(<-recorder.Events).To(Equal("Normal Created created ConfigMap /nfd-master"))
+		Expect(<-recorder.Events).To(HavePrefix("Warning FailedReconcile failed to create or patch Deployment /nfd-master: "))
 	})
 })
 
@@ -249,7 +537,7 @@ var _ = Describe("handleWorker", func() {
 		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
 		mockCM = configmap.NewMockConfigMapAPI(ctrl)
 
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, mockDS, mockCM, nil, nil, scheme)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, mockDS, mockCM, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(100), scheme, nil)
 	})
 
 	ctx := context.Background()
@@ -263,6 +551,8 @@ var _ = Describe("handleWorker", func() {
 			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
 			mockDS.EXPECT().SetWorkerDaemonsetAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
 			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return(nil, nil),
 		)
 
 		err := nfdh.handleWorker(ctx, &nfdCR)
@@ -276,7 +566,7 @@ var _ = Describe("handleWorker", func() {
 				Namespace: "test-namespace",
 			},
 			Spec: nfdv1.NodeFeatureDiscoverySpec{
-				TopologyUpdater: true,
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
 			},
 		}
 		existingDS := appsv1.DaemonSet{
@@ -302,6 +592,8 @@ var _ = Describe("handleWorker", func() {
 				},
 			),
 			mockDS.EXPECT().SetWorkerDaemonsetAsDesired(ctx, &nfdCR, &existingDS).Return(nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return(nil, nil),
 		)
 
 		err := nfdh.handleWorker(ctx, &nfdCR)
@@ -330,13 +622,59 @@ var _ = Describe("handleWorker", func() {
 		err := nfdh.handleWorker(ctx, &nfdCR)
 		Expect(err).To(HaveOccurred())
 	})
+
+	Context("worker config is referenced from a user managed configmap", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-cr",
+				Namespace: "test-namespace",
+			},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm", Key: "worker.conf"},
+				},
+			},
+		}
+
+		It("owned configmap is deleted and daemonset is created", func() {
+			userCM := corev1.ConfigMap{Data: map[string]string{"worker.conf": ""}}
+			gomock.InOrder(
+				mockCM.EXPECT().GetConfigMap(ctx, nfdCR.Namespace, "user-cm").Return(&userCM, nil),
+				mockCM.EXPECT().DeleteConfigMap(ctx, nfdCR.Namespace, "nfd-worker").Return(true, nil),
+				clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+				mockDS.EXPECT().SetWorkerDaemonsetAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+				clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+				mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return(nil, nil),
+				mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return(nil, nil),
+			)
+
+			err := nfdh.handleWorker(ctx, &nfdCR)
+			Expect(err).To(BeNil())
+		})
+
+		It("error flow, referenced configmap is missing", func() {
+			mockCM.EXPECT().GetConfigMap(ctx, nfdCR.Namespace, "user-cm").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+			err := nfdh.handleWorker(ctx, &nfdCR)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("error flow, referenced key is missing", func() {
+			userCM := corev1.ConfigMap{Data: map[string]string{"nfd-worker.conf": ""}}
+			mockCM.EXPECT().GetConfigMap(ctx, nfdCR.Namespace, "user-cm").Return(&userCM, nil)
+
+			err := nfdh.handleWorker(ctx, &nfdCR)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
 
-var _ = Describe("handleTopology", func() {
+var _ = Describe("handleWorker with worker pools", func() {
 	var (
 		ctrl   *gomock.Controller
 		clnt   *client.MockClient
 		mockDS *daemonset.MockDaemonsetAPI
+		mockCM *configmap.MockConfigMapAPI
 		nfdh   nodeFeatureDiscoveryHelperAPI
 	)
 
@@ -344,518 +682,2262 @@ var _ = Describe("handleTopology", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
 
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, mockDS, nil, nil, nil, scheme)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, mockDS, mockCM, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(100), scheme, nil)
 	})
 
 	ctx := context.Background()
+	namespace := "test-namespace"
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: namespace},
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			WorkerPools: []nfdv1.WorkerPool{
+				{
+					Name:         "gpu",
+					NodeSelector: map[string]string{"node-role.kubernetes.io/gpu": ""},
+				},
+			},
+		},
+	}
 
-	It("should create new nfd-topology daemonset if it does not exist", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{
-			Spec: nfdv1.NodeFeatureDiscoverySpec{
-				TopologyUpdater: true,
+	It("default worker is deleted, pool objects are created and removed pools are deleted", func() {
+		removedDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "nfd-worker--smartnic",
+				Labels:    map[string]string{"nfd.kubernetes.io/worker-pool": "smartnic"},
+			},
+		}
+		keptDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "nfd-worker--gpu",
+				Labels:    map[string]string{"nfd.kubernetes.io/worker-pool": "gpu"},
+			},
+		}
+		removedCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "nfd-worker--smartnic",
+				Labels:    map[string]string{"nfd.kubernetes.io/worker-pool": "smartnic"},
 			},
 		}
 		gomock.InOrder(
-			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
-			mockDS.EXPECT().SetTopologyDaemonsetAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(true, nil),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-worker--gpu"}, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetWorkerPoolConfigMapAsDesired(ctx, &nfdCR, &nfdCR.Spec.WorkerPools[0], gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-worker--gpu"}, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDS.EXPECT().SetWorkerPoolDaemonsetAsDesired(ctx, &nfdCR, &nfdCR.Spec.WorkerPools[0], gomock.Any()).Return(nil),
 			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return([]appsv1.DaemonSet{keptDS, removedDS}, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker--smartnic").Return(true, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return([]corev1.ConfigMap{removedCM}, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker--smartnic").Return(true, nil),
 		)
 
-		err := nfdh.handleTopology(ctx, &nfdCR)
+		err := nfdh.handleWorker(ctx, &nfdCR)
 		Expect(err).To(BeNil())
 	})
 
-	It("topology daemonset exists, no need to create it, update is not executed", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{
+	It("pool objects named before the double hyphen separator are deleted", func() {
+		legacyDS := appsv1.DaemonSet{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "nfd-cr",
-				Namespace: "test-namespace",
-			},
-			Spec: nfdv1.NodeFeatureDiscoverySpec{
-				TopologyUpdater: true,
+				Namespace: namespace,
+				Name:      "nfd-worker-gpu",
+				Labels:    map[string]string{"nfd.kubernetes.io/worker-pool": "gpu"},
 			},
 		}
-		existingDS := appsv1.DaemonSet{
-			ObjectMeta: metav1.ObjectMeta{Namespace: nfdCR.Namespace, Name: "nfd-topology-updater"},
+		legacyCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "nfd-worker-gpu",
+				Labels:    map[string]string{"nfd.kubernetes.io/worker-pool": "gpu"},
+			},
 		}
 		gomock.InOrder(
-			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
-				func(_ interface{}, _ interface{}, ds *appsv1.DaemonSet, _ ...ctrlclient.GetOption) error {
-					ds.SetName(existingDS.Name)
-					ds.SetNamespace(existingDS.Namespace)
-					return nil
-				},
-			),
-			mockDS.EXPECT().SetTopologyDaemonsetAsDesired(ctx, &nfdCR, &existingDS).Return(nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(false, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(false, nil),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-worker--gpu"}, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetWorkerPoolConfigMapAsDesired(ctx, &nfdCR, &nfdCR.Spec.WorkerPools[0], gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-worker--gpu"}, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDS.EXPECT().SetWorkerPoolDaemonsetAsDesired(ctx, &nfdCR, &nfdCR.Spec.WorkerPools[0], gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return([]appsv1.DaemonSet{legacyDS}, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker-gpu").Return(true, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return([]corev1.ConfigMap{legacyCM}, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker-gpu").Return(true, nil),
 		)
 
-		err := nfdh.handleTopology(ctx, &nfdCR)
+		err := nfdh.handleWorker(ctx, &nfdCR)
 		Expect(err).To(BeNil())
 	})
 
-	It("error flow, failed to populate daemonset object", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{
-			Spec: nfdv1.NodeFeatureDiscoverySpec{
-				TopologyUpdater: true,
-			},
-		}
-		gomock.InOrder(
-			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
-			mockDS.EXPECT().SetTopologyDaemonsetAsDesired(ctx, &nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
-		)
+	It("error flow, failed to delete the default worker", func() {
+		mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(false, fmt.Errorf("some error"))
 
-		err := nfdh.handleTopology(ctx, &nfdCR)
+		err := nfdh.handleWorker(ctx, &nfdCR)
 		Expect(err).To(HaveOccurred())
 	})
 
-	It("if TopologyUpdate not set - nothing to do", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{}
+	It("error flow, a pool failed, removed pools are still cleaned up", func() {
+		gomock.InOrder(
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(true, nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetWorkerPoolConfigMapAsDesired(ctx, &nfdCR, &nfdCR.Spec.WorkerPools[0], gomock.Any()).Return(fmt.Errorf("some error")),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return(nil, nil),
+		)
 
-		err := nfdh.handleTopology(ctx, &nfdCR)
-		Expect(err).To(BeNil())
+		err := nfdh.handleWorker(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
 	})
 })
 
-var _ = Describe("handleGC", func() {
+var _ = Describe("getWorkerConfigMapMapFunc", func() {
 	var (
-		ctrl           *gomock.Controller
-		clnt           *client.MockClient
-		mockDeployment *deployment.MockDeploymentAPI
-		nfdh           nodeFeatureDiscoveryHelperAPI
+		ctrl *gomock.Controller
+		clnt *client.MockClient
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
-
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, mockDeployment, nil, nil, nil, nil, scheme)
 	})
 
 	ctx := context.Background()
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-cm", Namespace: "test-namespace"},
+	}
 
-	It("should create new nfd-gc deployment if it does not exist", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{}
-		gomock.InOrder(
-			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
-			mockDeployment.EXPECT().SetGCDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
-			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+	It("CRs referencing the configmap are enqueued", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingFields{workerConfigMapRefIndexKey: "test-namespace/user-cm"}).DoAndReturn(
+			func(_ interface{}, list *nfdv1.NodeFeatureDiscoveryList, _ ...ctrlclient.ListOption) error {
+				list.Items = []nfdv1.NodeFeatureDiscovery{
+					{ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "other-namespace"}},
+				}
+				return nil
+			},
 		)
 
-		err := nfdh.handleGC(ctx, &nfdCR)
-		Expect(err).To(BeNil())
+		res := getWorkerConfigMapMapFunc(clnt)(ctx, &cm)
+		Expect(res).To(Equal([]reconcile.Request{
+			{NamespacedName: ctrlclient.ObjectKey{Name: "nfd-cr", Namespace: "test-namespace"}},
+			{NamespacedName: ctrlclient.ObjectKey{Name: "nfd-cr", Namespace: "other-namespace"}},
+		}))
 	})
 
-	It("nfd-gc deployment exists, no need to create it, update is not executed", func() {
+	It("failed to list CRs", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		res := getWorkerConfigMapMapFunc(clnt)(ctx, &cm)
+		Expect(res).To(BeNil())
+	})
+})
+
+var _ = Describe("workerConfigMapRefIndexer", func() {
+	It("no configmap is referenced", func() {
+		Expect(workerConfigMapRefIndexer(&nfdv1.NodeFeatureDiscovery{})).To(BeNil())
+	})
+
+	It("configmap is referenced", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "nfd-cr",
-				Namespace: "test-namespace",
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm"},
+				},
 			},
 		}
-		existingDeployment := appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{Namespace: nfdCR.Namespace, Name: "nfd-gc"},
+		Expect(workerConfigMapRefIndexer(&nfdCR)).To(Equal([]string{"test-namespace/user-cm"}))
+	})
+
+	It("configmap is referenced in the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm"},
+				},
+			},
 		}
-		gomock.InOrder(
+		Expect(workerConfigMapRefIndexer(&nfdCR)).To(Equal([]string{"nfd-operands/user-cm"}))
+	})
+})
+
+var _ = Describe("handleTopology", func() {
+	var (
+		ctrl   *gomock.Controller
+		clnt   *client.MockClient
+		mockDS *daemonset.MockDaemonsetAPI
+		mockCM *configmap.MockConfigMapAPI
+		nfdh   nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, mockDS, mockCM, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(100), scheme, nil)
+	})
+
+	ctx := context.Background()
+
+	It("should create new nfd-topology configmap and daemonset if they do not exist", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+			},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetTopologyUpdaterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDS.EXPECT().SetTopologyDaemonsetAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+		)
+
+		err := nfdh.handleTopology(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("topology configmap and daemonset exist, no need to create them, update is not executed", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-cr",
+				Namespace: "test-namespace",
+			},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+			},
+		}
+		existingCM := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: nfdCR.Namespace, Name: "nfd-topology-updater"},
+		}
+		existingDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: nfdCR.Namespace, Name: "nfd-topology-updater"},
+		}
+		gomock.InOrder(
 			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
-				func(_ interface{}, _ interface{}, dp *appsv1.Deployment, _ ...ctrlclient.GetOption) error {
-					dp.SetName(existingDeployment.Name)
-					dp.SetNamespace(existingDeployment.Namespace)
+				func(_ interface{}, _ interface{}, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					cm.SetName(existingCM.Name)
+					cm.SetNamespace(existingCM.Namespace)
 					return nil
 				},
 			),
-			mockDeployment.EXPECT().SetGCDeploymentAsDesired(&nfdCR, &existingDeployment).Return(nil),
+			mockCM.EXPECT().SetTopologyUpdaterConfigMapAsDesired(ctx, &nfdCR, &existingCM).Return(nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, _ interface{}, ds *appsv1.DaemonSet, _ ...ctrlclient.GetOption) error {
+					ds.SetName(existingDS.Name)
+					ds.SetNamespace(existingDS.Namespace)
+					return nil
+				},
+			),
+			mockDS.EXPECT().SetTopologyDaemonsetAsDesired(ctx, &nfdCR, &existingDS).Return(nil),
 		)
 
-		err := nfdh.handleGC(ctx, &nfdCR)
+		err := nfdh.handleTopology(ctx, &nfdCR)
 		Expect(err).To(BeNil())
 	})
 
-	It("error flow, failed to populate nfd-gc deployment object", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{}
+	It("error flow, failed to populate configmap object", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+			},
+		}
 		gomock.InOrder(
 			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
-			mockDeployment.EXPECT().SetGCDeploymentAsDesired(&nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
+			mockCM.EXPECT().SetTopologyUpdaterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
 		)
 
-		err := nfdh.handleGC(ctx, &nfdCR)
+		err := nfdh.handleTopology(ctx, &nfdCR)
 		Expect(err).To(HaveOccurred())
 	})
-})
-
-var _ = Describe("hasFinalizer", func() {
-	It("checking return status whether finalizer set or not", func() {
-		nfdh := newNodeFeatureDiscoveryHelperAPI(nil, nil, nil, nil, nil, nil, nil)
 
-		By("finalizers was empty")
+	It("error flow, failed to populate daemonset object", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: nil,
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
 			},
 		}
-		res := nfdh.hasFinalizer(&nfdCR)
-		Expect(res).To(BeFalse())
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockCM.EXPECT().SetTopologyUpdaterConfigMapAsDesired(ctx, &nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDS.EXPECT().SetTopologyDaemonsetAsDesired(ctx, &nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
+		)
 
-		By("finalizers exists, but NFD finalizer missing")
-		nfdCR = nfdv1.NodeFeatureDiscovery{
+		err := nfdh.handleTopology(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("if TopologyUpdate not set - topology configmap and daemonset are deleted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		}
+		gomock.InOrder(
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "test-namespace", "nfd-topology-updater").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "test-namespace", "nfd-topology-updater").Return(true, nil),
+		)
+
+		err := nfdh.handleTopology(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("error flow, failed to delete the disabled topology daemonset", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		mockDS.EXPECT().DeleteDaemonSet(ctx, "", "nfd-topology-updater").Return(false, fmt.Errorf("some error"))
+
+		err := nfdh.handleTopology(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("handleGC", func() {
+	var (
+		ctrl           *gomock.Controller
+		clnt           *client.MockClient
+		mockDeployment *deployment.MockDeploymentAPI
+		nfdh           nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, mockDeployment, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(100), scheme, nil)
+	})
+
+	ctx := context.Background()
+
+	It("should create new nfd-gc deployment if it does not exist", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDeployment.EXPECT().SetGCDeploymentAsDesired(&nfdCR, gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+		)
+
+		err := nfdh.handleGC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("nfd-gc deployment exists, no need to create it, update is not executed", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: []string{"some finalizer"},
+				Name:      "nfd-cr",
+				Namespace: "test-namespace",
 			},
 		}
-		res = nfdh.hasFinalizer(&nfdCR)
-		Expect(res).To(BeFalse())
+		existingDeployment := appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: nfdCR.Namespace, Name: "nfd-gc"},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, _ interface{}, dp *appsv1.Deployment, _ ...ctrlclient.GetOption) error {
+					dp.SetName(existingDeployment.Name)
+					dp.SetNamespace(existingDeployment.Namespace)
+					return nil
+				},
+			),
+			mockDeployment.EXPECT().SetGCDeploymentAsDesired(&nfdCR, &existingDeployment).Return(nil),
+		)
 
-		By("finalizers exists, but NFD finalizer present")
-		nfdCR = nfdv1.NodeFeatureDiscovery{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: []string{"some finalizer", finalizerLabel},
+		err := nfdh.handleGC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("gc disabled - nfd-gc deployment is deleted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				GC: nfdv1.GCSpec{Enabled: ptr.To(false)},
 			},
 		}
-		res = nfdh.hasFinalizer(&nfdCR)
-		Expect(res).To(BeTrue())
+		mockDeployment.EXPECT().DeleteDeployment(ctx, "test-namespace", "nfd-gc").Return(true, nil)
+
+		err := nfdh.handleGC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("error flow, failed to populate nfd-gc deployment object", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDeployment.EXPECT().SetGCDeploymentAsDesired(&nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
+		)
+
+		err := nfdh.handleGC(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
 	})
 })
 
-var _ = Describe("setFinalizer", func() {
+var _ = Describe("handleMetrics", func() {
 	var (
-		ctrl *gomock.Controller
-		clnt *client.MockClient
-		nfdh nodeFeatureDiscoveryHelperAPI
+		ctrl               *gomock.Controller
+		clnt               *client.MockClient
+		mockService        *service.MockServiceAPI
+		mockServiceMonitor *servicemonitor.MockServiceMonitorAPI
+		mockPrometheusRule *prometheusrule.MockPrometheusRuleAPI
+		recorder           *record.FakeRecorder
+		nfdh               nodeFeatureDiscoveryHelperAPI
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil)
+		mockService = service.NewMockServiceAPI(ctrl)
+		mockServiceMonitor = servicemonitor.NewMockServiceMonitorAPI(ctrl)
+		mockPrometheusRule = prometheusrule.NewMockPrometheusRuleAPI(ctrl)
+		recorder = record.NewFakeRecorder(20)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, mockService, mockServiceMonitor, mockPrometheusRule, nil, nil, recorder, scheme, nil)
 	})
 
-	It("checking the return status of setFinalizer function", func() {
-		ctx := context.Background()
+	ctx := context.Background()
+	serviceType := gomock.AssignableToTypeOf(&corev1.Service{})
+	serviceMonitorType := gomock.AssignableToTypeOf(&unstructured.Unstructured{})
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{}, "whatever")
+	defaultOperands := []string{"nfd-master", "nfd-worker", "nfd-gc"}
 
-		By("Updating the NFD instance fails, original finalizers was empty")
+	It("metrics not set - the metrics services and servicemonitors are deleted", func() {
 		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		}
+		staleSvc := corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: nil,
+				Namespace: "test-namespace",
+				Name:      "nfd-master-metrics",
+				Labels:    map[string]string{"nfd.kubernetes.io/metrics": "nfd-master"},
 			},
 		}
-		expectedCR := nfdv1.NodeFeatureDiscovery{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: []string{finalizerLabel},
+		staleSM := servicemonitor.NewServiceMonitor("test-namespace", "nfd-master-metrics")
+		staleSM.SetLabels(map[string]string{"nfd.kubernetes.io/metrics": "nfd-master"})
+		gomock.InOrder(
+			mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return([]corev1.Service{staleSvc}, nil),
+			mockService.EXPECT().DeleteService(ctx, "test-namespace", "nfd-master-metrics").Return(true, nil),
+			mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(true, nil),
+			mockServiceMonitor.EXPECT().ListMetricsServiceMonitors(ctx, &nfdCR).Return([]unstructured.Unstructured{*staleSM}, nil),
+			mockServiceMonitor.EXPECT().DeleteServiceMonitor(ctx, "test-namespace", "nfd-master-metrics").Return(true, nil),
+			mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil),
+		)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted Service test-namespace/nfd-master-metrics"))
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted ServiceMonitor test-namespace/nfd-master-metrics"))
+	})
+
+	It("metrics set - a service and a servicemonitor are created per operand", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{},
 			},
 		}
-		clnt.EXPECT().Update(ctx, &expectedCR).Return(fmt.Errorf("some error"))
-		err := nfdh.setFinalizer(ctx, &nfdCR)
-		Expect(err).ToNot(BeNil())
+		for _, operand := range defaultOperands {
+			mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, operand, gomock.Any()).Return(nil)
+			mockServiceMonitor.EXPECT().SetMetricsServiceMonitorAsDesired(&nfdCR, operand, gomock.Any()).Return(nil)
+		}
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceType).Return(notFoundErr).Times(3)
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceMonitorType).Return(notFoundErr).Times(3)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(6)
+		mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil)
+		mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(true, nil)
+		mockServiceMonitor.EXPECT().ListMetricsServiceMonitors(ctx, &nfdCR).Return(nil, nil)
+		mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(recorder.Events).To(HaveLen(6))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Service test-namespace/nfd-master-metrics"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Service test-namespace/nfd-worker-metrics"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created Service test-namespace/nfd-gc-metrics"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created ServiceMonitor test-namespace/nfd-master-metrics"))
+	})
 
-		By("Updating the NFD instance succeeds")
-		nfdCR = nfdv1.NodeFeatureDiscovery{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: []string{"some finalizer"},
+	It("servicemonitor CRD not installed - only the services are created", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{},
 			},
 		}
-		expectedCR = nfdv1.NodeFeatureDiscovery{
+		for _, operand := range defaultOperands {
+			mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, operand, gomock.Any()).Return(nil)
+		}
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceType).Return(notFoundErr).Times(3)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(3)
+		mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil)
+		mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil)
+		mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("servicemonitors disabled - the services of the worker pools are kept, the servicemonitors are deleted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{{Name: "gpu"}},
+				GC:          nfdv1.GCSpec{Enabled: ptr.To(false)},
+				Metrics: &nfdv1.MetricsSpec{
+					ServiceMonitor: nfdv1.ServiceMonitorSpec{Enabled: ptr.To(false)},
+				},
+			},
+		}
+		poolSvc := corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:       "nfd-cr",
-				Finalizers: []string{"some finalizer", finalizerLabel},
+				Namespace: "test-namespace",
+				Name:      "nfd-worker--gpu-metrics",
+				Labels:    map[string]string{"nfd.kubernetes.io/metrics": "nfd-worker--gpu"},
 			},
 		}
-		clnt.EXPECT().Update(ctx, &expectedCR).Return(nil)
-		err = nfdh.setFinalizer(ctx, &nfdCR)
+		poolSM := servicemonitor.NewServiceMonitor("test-namespace", "nfd-worker--gpu-metrics")
+		poolSM.SetLabels(map[string]string{"nfd.kubernetes.io/metrics": "nfd-worker--gpu"})
+		for _, operand := range []string{"nfd-master", "nfd-worker--gpu"} {
+			mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, operand, gomock.Any()).Return(nil)
+		}
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceType).Return(notFoundErr).Times(2)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(2)
+		mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return([]corev1.Service{poolSvc}, nil)
+		mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(true, nil)
+		mockServiceMonitor.EXPECT().ListMetricsServiceMonitors(ctx, &nfdCR).Return([]unstructured.Unstructured{*poolSM}, nil)
+		mockServiceMonitor.EXPECT().DeleteServiceMonitor(ctx, "test-namespace", "nfd-worker--gpu-metrics").Return(true, nil)
+		mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("error flow, failed to check the servicemonitor CRD", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		}
+		gomock.InOrder(
+			mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil),
+			mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, fmt.Errorf("some error")),
+			mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil),
+		)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("error flow, failed to populate a metrics service, the other ones are reconciled", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{},
+			},
+		}
+		mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, "nfd-master", gomock.Any()).Return(fmt.Errorf("some error"))
+		mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, "nfd-worker", gomock.Any()).Return(nil)
+		mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, "nfd-gc", gomock.Any()).Return(nil)
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceType).Return(notFoundErr).Times(3)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(2)
+		mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil)
+		mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil)
+		mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("prometheusrule enabled - the prometheusrule is created", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{
+					ServiceMonitor: nfdv1.ServiceMonitorSpec{Enabled: ptr.To(false)},
+					PrometheusRule: nfdv1.PrometheusRuleSpec{Enabled: true},
+				},
+			},
+		}
+		for _, operand := range defaultOperands {
+			mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, operand, gomock.Any()).Return(nil)
+		}
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceType).Return(notFoundErr).Times(3)
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceMonitorType).Return(notFoundErr)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(4)
+		mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil)
+		mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil)
+		mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(true, nil)
+		mockPrometheusRule.EXPECT().SetPrometheusRuleAsDesired(&nfdCR, gomock.Any()).Return(nil)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(recorder.Events).To(HaveLen(4))
+		for range defaultOperands {
+			<-recorder.Events
+		}
+		Expect(<-recorder.Events).To(Equal("Normal Created created PrometheusRule test-namespace/nfd-alerts"))
+	})
+
+	It("prometheusrule disabled - the prometheusrule is deleted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		}
+		gomock.InOrder(
+			mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil),
+			mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil),
+			mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(true, nil),
+			mockPrometheusRule.EXPECT().DeletePrometheusRule(ctx, "test-namespace", "nfd-alerts").Return(true, nil),
+		)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted PrometheusRule test-namespace/nfd-alerts"))
+	})
+
+	It("prometheusrule CRD not installed - the prometheusrule is skipped", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{{Name: "gpu"}},
+				Metrics: &nfdv1.MetricsSpec{
+					PrometheusRule: nfdv1.PrometheusRuleSpec{Enabled: true},
+				},
+			},
+		}
+		mockService.EXPECT().SetMetricsServiceAsDesired(&nfdCR, gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		clnt.EXPECT().Get(ctx, gomock.Any(), serviceType).Return(notFoundErr).Times(3)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(3)
+		mockService.EXPECT().ListMetricsServices(ctx, &nfdCR).Return(nil, nil)
+		mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil)
+		mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil)
+
+		err := nfdh.handleMetrics(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("hasFinalizer", func() {
+	It("checking return status whether finalizer set or not", func() {
+		nfdh := newNodeFeatureDiscoveryHelperAPI(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(100), nil, nil)
+
+		By("finalizers was empty")
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: nil,
+			},
+		}
+		res := nfdh.hasFinalizer(&nfdCR)
+		Expect(res).To(BeFalse())
+
+		By("finalizers exists, but NFD finalizer missing")
+		nfdCR = nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: []string{"some finalizer"},
+			},
+		}
+		res = nfdh.hasFinalizer(&nfdCR)
+		Expect(res).To(BeFalse())
+
+		By("finalizers exists, but NFD finalizer present")
+		nfdCR = nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: []string{"some finalizer", finalizerLabel},
+			},
+		}
+		res = nfdh.hasFinalizer(&nfdCR)
+		Expect(res).To(BeTrue())
+	})
+})
+
+var _ = Describe("setFinalizer", func() {
+	var (
+		ctrl     *gomock.Controller
+		clnt     *client.MockClient
+		recorder *record.FakeRecorder
+		nfdh     nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		recorder = record.NewFakeRecorder(10)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, recorder, nil, nil)
+	})
+
+	It("checking the return status of setFinalizer function", func() {
+		ctx := context.Background()
+
+		By("Updating the NFD instance fails, original finalizers was empty")
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: nil,
+			},
+		}
+		expectedCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: []string{finalizerLabel},
+			},
+		}
+		clnt.EXPECT().Update(ctx, &expectedCR).Return(fmt.Errorf("some error"))
+		err := nfdh.setFinalizer(ctx, &nfdCR)
+		Expect(err).ToNot(BeNil())
+		Expect(recorder.Events).To(BeEmpty())
+
+		By("Updating the NFD instance succeeds")
+		nfdCR = nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: []string{"some finalizer"},
+			},
+		}
+		expectedCR = nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "nfd-cr",
+				Finalizers: []string{"some finalizer", finalizerLabel},
+			},
+		}
+		clnt.EXPECT().Update(ctx, &expectedCR).Return(nil)
+		err = nfdh.setFinalizer(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal FinalizerAdded added finalizer nfd-finalizer"))
+	})
+})
+
+var _ = Describe("finalizeComponents", func() {
+	var (
+		ctrl               *gomock.Controller
+		clnt               *client.MockClient
+		mockDeployment     *deployment.MockDeploymentAPI
+		mockDS             *daemonset.MockDaemonsetAPI
+		mockCM             *configmap.MockConfigMapAPI
+		mockPDB            *poddisruptionbudget.MockPodDisruptionBudgetAPI
+		mockService        *service.MockServiceAPI
+		mockServiceMonitor *servicemonitor.MockServiceMonitorAPI
+		mockPrometheusRule *prometheusrule.MockPrometheusRuleAPI
+		nfdh               nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
+		mockPDB = poddisruptionbudget.NewMockPodDisruptionBudgetAPI(ctrl)
+		mockService = service.NewMockServiceAPI(ctrl)
+		mockServiceMonitor = servicemonitor.NewMockServiceMonitorAPI(ctrl)
+		mockPrometheusRule = prometheusrule.NewMockPrometheusRuleAPI(ctrl)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, rbac.NewMockRBACAPI(ctrl), mockDeployment, mockDS, mockCM, nil, nil, mockPDB, mockService, mockServiceMonitor,
+			mockPrometheusRule, nil, nil, record.NewFakeRecorder(100), scheme, nil)
+	})
+
+	ctx := context.Background()
+	namespace := "test-namespace"
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+		},
+	}
+
+	DescribeTable("check finalization normal and error flows", func(deleteWorkerDSError,
+		deleteWorkerCMError,
+		deleteTopologyDSError,
+		deleteMasterDeploymentError,
+		deleteGCDeploymentError bool) {
+
+		if deleteWorkerDSError {
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(false, fmt.Errorf("some error"))
+			goto executeTestFunction
+		}
+		mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(true, nil)
+		if deleteWorkerCMError {
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(false, fmt.Errorf("some error"))
+			goto executeTestFunction
+		}
+		mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(true, nil)
+		mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &nfdCR).Return(nil, nil)
+		mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &nfdCR).Return(nil, nil)
+		if deleteTopologyDSError {
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater").Return(false, fmt.Errorf("some error"))
+			goto executeTestFunction
+		}
+		mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater").Return(true, nil)
+		mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-topology-updater").Return(true, nil)
+		if deleteMasterDeploymentError {
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master").Return(false, fmt.Errorf("some error"))
+			goto executeTestFunction
+		}
+		mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master").Return(true, nil)
+		mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-master").Return(true, nil)
+		if deleteGCDeploymentError {
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc").Return(false, fmt.Errorf("some error"))
+			goto executeTestFunction
+		}
+		mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc").Return(true, nil)
+
+	executeTestFunction:
+
+		err := nfdh.finalizeComponents(ctx, &nfdCR)
+
+		if deleteGCDeploymentError || deleteWorkerDSError || deleteWorkerCMError ||
+			deleteTopologyDSError || deleteMasterDeploymentError {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).To(BeNil())
+		}
+	},
+		Entry("delete worker daemonset failed", true, false, false, false, false),
+		Entry("delete worker configmap failed", false, true, false, false, false),
+		Entry("delete topology daemonset failed", false, false, true, false, false),
+		Entry("delete master deployment failed", false, false, false, true, false),
+		Entry("delete gc deployment failed", false, false, false, false, true),
+		Entry("finalization flow was succesful", false, false, false, false, false),
+	)
+
+	It("components of a named instance are finalized", func() {
+		instanceCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance:        "blue",
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+			},
+		}
+		gomock.InOrder(
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker-blue").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker-blue").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &instanceCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &instanceCR).Return(nil, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater-blue").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-topology-updater-blue").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master-blue").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-master-blue").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc-blue").Return(true, nil),
+		)
+
+		err := nfdh.finalizeComponents(ctx, &instanceCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("master pdb is deleted when master is highly available", func() {
+		haCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Master: nfdv1.MasterSpec{Replicas: ptr.To[int32](2)},
+			},
+		}
+		gomock.InOrder(
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &haCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &haCR).Return(nil, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-topology-updater").Return(true, nil),
+			mockPDB.EXPECT().DeletePodDisruptionBudget(ctx, namespace, "nfd-master").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-master").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc").Return(true, nil),
+		)
+
+		err := nfdh.finalizeComponents(ctx, &haCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("operands and metrics deployed to another namespace are deleted", func() {
+		operandCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+			Status: nfdv1.NodeFeatureDiscoveryStatus{OperandNamespace: "nfd-operands"},
+		}
+		gomock.InOrder(
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "nfd-operands", "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "nfd-operands", "nfd-worker").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &operandCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &operandCR).Return(nil, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "nfd-operands", "nfd-topology-updater").Return(false, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "nfd-operands", "nfd-topology-updater").Return(false, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, "nfd-operands", "nfd-master").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "nfd-operands", "nfd-master").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, "nfd-operands", "nfd-gc").Return(true, nil),
+			mockService.EXPECT().ListMetricsServices(ctx, &operandCR).Return(nil, nil),
+			mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(true, nil),
+			mockServiceMonitor.EXPECT().ListMetricsServiceMonitors(ctx, &operandCR).Return(nil, nil),
+			mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(true, nil),
+			mockPrometheusRule.EXPECT().DeletePrometheusRule(ctx, "nfd-operands", "nfd-alerts").Return(false, nil),
+		)
+
+		err := nfdh.finalizeComponents(ctx, &operandCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("operands left in the previous operand namespace are deleted", func() {
+		movedCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Status:     nfdv1.NodeFeatureDiscoveryStatus{OperandNamespace: "nfd-operands"},
+		}
+		prevCR := movedCR.DeepCopy()
+		prevCR.Spec.Operand.Namespace = "nfd-operands"
+		gomock.InOrder(
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "nfd-operands", "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "nfd-operands", "nfd-worker").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, prevCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, prevCR).Return(nil, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "nfd-operands", "nfd-topology-updater").Return(false, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "nfd-operands", "nfd-topology-updater").Return(false, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, "nfd-operands", "nfd-master").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "nfd-operands", "nfd-master").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, "nfd-operands", "nfd-gc").Return(true, nil),
+			mockService.EXPECT().ListMetricsServices(ctx, prevCR).Return(nil, nil),
+			mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil),
+			mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")).Times(11),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, &movedCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, &movedCR).Return(nil, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater").Return(false, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-topology-updater").Return(false, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-master").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc").Return(true, nil),
+		)
+
+		err := nfdh.finalizeComponents(ctx, &movedCR)
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("handleOperandRBAC", func() {
+	var (
+		ctrl     *gomock.Controller
+		clnt     *client.MockClient
+		mockRBAC *rbac.MockRBACAPI
+		recorder *record.FakeRecorder
+		nfdh     nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockRBAC = rbac.NewMockRBACAPI(ctrl)
+		recorder = record.NewFakeRecorder(20)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, mockRBAC, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, recorder, scheme, nil)
+	})
+
+	ctx := context.Background()
+
+	It("nothing is created for operands deployed to the namespace of the CR", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+		}
+
+		err := nfdh.handleOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("serviceaccounts, worker role and clusterrolebindings are created in the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+		clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")).Times(11)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(11)
+		mockRBAC.EXPECT().SetServiceAccountAsDesired(&nfdCR, gomock.Any()).Return(nil).Times(5)
+		mockRBAC.EXPECT().SetWorkerRoleAsDesired(&nfdCR, gomock.Any()).Return(nil)
+		mockRBAC.EXPECT().SetWorkerRoleBindingAsDesired(&nfdCR, gomock.Any()).Return(nil)
+		for _, clusterRole := range []string{"nfd-master", "nfd-gc", "nfd-topology-updater", "nfd-prune"} {
+			mockRBAC.EXPECT().SetClusterRoleBindingAsDesired(&nfdCR, clusterRole, gomock.Any()).Return(nil)
+		}
+
+		err := nfdh.handleOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(recorder.Events).To(HaveLen(11))
+		Expect(<-recorder.Events).To(Equal("Normal Created created ServiceAccount nfd-operands/nfd-worker"))
+	})
+
+	It("serviceaccount not owned by the CR is left as is", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+		clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: "nfd-operands", Name: "nfd-worker"}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ ctrlclient.ObjectKey, sa *corev1.ServiceAccount, _ ...ctrlclient.GetOption) error {
+				sa.ResourceVersion = "1"
+				return nil
+			})
+		clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		err := nfdh.handleOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("failed to create or patch ServiceAccount nfd-operands/nfd-master"))
+	})
+
+	It("RBAC left by a CR taken over is adopted, clusterrolebindings not labelled with an NFD CR are left as is", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+		clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key ctrlclient.ObjectKey, obj ctrlclient.Object, _ ...ctrlclient.GetOption) error {
+				obj.SetNamespace(key.Namespace)
+				obj.SetName(key.Name)
+				obj.SetResourceVersion("1")
+				if _, isCRB := obj.(*rbacv1.ClusterRoleBinding); !isCRB {
+					obj.SetLabels(map[string]string{
+						ownership.OwnerNamespaceLabel: "test-namespace",
+						ownership.OwnerNameLabel:      "nfd-cr-old",
+					})
+				}
+				return nil
+			}).Times(11)
+		mockRBAC.EXPECT().SetServiceAccountAsDesired(&nfdCR, gomock.Any()).Return(nil).Times(5)
+		mockRBAC.EXPECT().SetWorkerRoleAsDesired(&nfdCR, gomock.Any()).Return(nil)
+		mockRBAC.EXPECT().SetWorkerRoleBindingAsDesired(&nfdCR, gomock.Any()).Return(nil)
+
+		err := nfdh.handleOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("deleteOperandRBAC", func() {
+	var (
+		ctrl     *gomock.Controller
+		clnt     *client.MockClient
+		mockRBAC *rbac.MockRBACAPI
+		nfdh     nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockRBAC = rbac.NewMockRBACAPI(ctrl)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, mockRBAC, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(20), scheme, nil)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+		},
+	}
+	ownerLabels := map[string]string{
+		ownership.OwnerNamespaceLabel: "test-namespace",
+		ownership.OwnerNameLabel:      "nfd-cr",
+	}
+
+	It("only the objects owned by the CR are deleted", func() {
+		clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key ctrlclient.ObjectKey, obj ctrlclient.Object, _ ...ctrlclient.GetOption) error {
+				obj.SetNamespace(key.Namespace)
+				obj.SetName(key.Name)
+				// the nfd-master ServiceAccount is not owned by the CR
+				if _, isSA := obj.(*corev1.ServiceAccount); !isSA || key.Name != "nfd-master" {
+					obj.SetLabels(ownerLabels)
+				}
+				return nil
+			}).Times(11)
+		for _, name := range []string{"nfd-master-nfd-operands", "nfd-gc-nfd-operands", "nfd-topology-updater-nfd-operands", "nfd-prune-nfd-operands"} {
+			mockRBAC.EXPECT().DeleteClusterRoleBinding(ctx, name).Return(true, nil)
+		}
+		mockRBAC.EXPECT().DeleteRoleBinding(ctx, "nfd-operands", "nfd-worker").Return(true, nil)
+		mockRBAC.EXPECT().DeleteRole(ctx, "nfd-operands", "nfd-worker").Return(true, nil)
+		for _, name := range []string{"nfd-worker", "nfd-gc", "nfd-topology-updater", "nfd-prune"} {
+			mockRBAC.EXPECT().DeleteServiceAccount(ctx, "nfd-operands", name).Return(true, nil)
+		}
+
+		err := nfdh.deleteOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("objects already deleted are skipped", func() {
+		clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")).Times(11)
+
+		err := nfdh.deleteOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("error flow, failed to delete a clusterrolebinding", func() {
+		clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key ctrlclient.ObjectKey, obj ctrlclient.Object, _ ...ctrlclient.GetOption) error {
+				obj.SetName(key.Name)
+				obj.SetLabels(ownerLabels)
+				return nil
+			})
+		mockRBAC.EXPECT().DeleteClusterRoleBinding(ctx, "nfd-master-nfd-operands").Return(false, fmt.Errorf("some error"))
+
+		err := nfdh.deleteOperandRBAC(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("handleOperandNamespace", func() {
+	var (
+		ctrl               *gomock.Controller
+		clnt               *client.MockClient
+		statusWriter       *client.MockStatusWriter
+		mockNamespace      *namespace.MockNamespaceAPI
+		mockDeployment     *deployment.MockDeploymentAPI
+		mockDS             *daemonset.MockDaemonsetAPI
+		mockCM             *configmap.MockConfigMapAPI
+		mockService        *service.MockServiceAPI
+		mockServiceMonitor *servicemonitor.MockServiceMonitorAPI
+		mockPrometheusRule *prometheusrule.MockPrometheusRuleAPI
+		recorder           *record.FakeRecorder
+		nfdh               nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
+		mockNamespace = namespace.NewMockNamespaceAPI(ctrl)
+		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
+		mockService = service.NewMockServiceAPI(ctrl)
+		mockServiceMonitor = servicemonitor.NewMockServiceMonitorAPI(ctrl)
+		mockPrometheusRule = prometheusrule.NewMockPrometheusRuleAPI(ctrl)
+		recorder = record.NewFakeRecorder(10)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, mockNamespace, nil, mockDeployment, mockDS, mockCM, nil, nil, nil, mockService, mockServiceMonitor,
+			mockPrometheusRule, nil, nil, recorder, scheme, nil)
+	})
+
+	ctx := context.Background()
+
+	It("operand namespace of a new CR is recorded in the status", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, &nfdCR, gomock.Any()).Return(nil),
+		)
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Status.OperandNamespace).To(Equal("test-namespace"))
+	})
+
+	It("operand namespace already recorded, nothing to do", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Status:     nfdv1.NodeFeatureDiscoveryStatus{OperandNamespace: "test-namespace"},
+		}
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("operand namespace is created", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+			Status: nfdv1.NodeFeatureDiscoveryStatus{OperandNamespace: "nfd-operands"},
+		}
+		mockNamespace.EXPECT().CreateNamespaceIfNotExists(ctx, &nfdCR, "nfd-operands").Return(true, nil)
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal Created created Namespace nfd-operands"))
+	})
+
+	It("failed to create the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+		mockNamespace.EXPECT().CreateNamespaceIfNotExists(ctx, &nfdCR, "nfd-operands").Return(false, fmt.Errorf("some error"))
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+		Expect(<-recorder.Events).To(Equal("Warning FailedReconcile failed to create Namespace nfd-operands: some error"))
+		Expect(nfdCR.Status.OperandNamespace).To(BeEmpty())
+	})
+
+	It("operands are removed from the previous operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+			Status: nfdv1.NodeFeatureDiscoveryStatus{OperandNamespace: "test-namespace"},
+		}
+		prevCR := nfdCR.DeepCopy()
+		prevCR.Spec.Operand.Namespace = "test-namespace"
+		gomock.InOrder(
+			mockNamespace.EXPECT().CreateNamespaceIfNotExists(ctx, &nfdCR, "nfd-operands").Return(false, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "test-namespace", "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "test-namespace", "nfd-worker").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, prevCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, prevCR).Return(nil, nil),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, "test-namespace", "nfd-topology-updater").Return(false, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "test-namespace", "nfd-topology-updater").Return(false, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, "test-namespace", "nfd-master").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, "test-namespace", "nfd-master").Return(true, nil),
+			mockDeployment.EXPECT().DeleteDeployment(ctx, "test-namespace", "nfd-gc").Return(false, nil),
+			mockService.EXPECT().ListMetricsServices(ctx, prevCR).Return(nil, nil),
+			mockServiceMonitor.EXPECT().IsServiceMonitorSupported().Return(false, nil),
+			mockPrometheusRule.EXPECT().IsPrometheusRuleSupported().Return(false, nil),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, &nfdCR, gomock.Any()).Return(nil),
+		)
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Status.OperandNamespace).To(Equal("nfd-operands"))
+	})
+
+	It("previous operand namespace is kept in the status until its operands are removed", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Status:     nfdv1.NodeFeatureDiscoveryStatus{OperandNamespace: "nfd-operands"},
+		}
+		mockDS.EXPECT().DeleteDaemonSet(ctx, "nfd-operands", "nfd-worker").Return(false, fmt.Errorf("some error"))
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+		Expect(nfdCR.Status.OperandNamespace).To(Equal("nfd-operands"))
+	})
+
+	It("operand namespace not watched by the operator", func() {
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, mockNamespace, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, recorder, scheme,
+			[]string{"test-namespace"})
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+
+		err := nfdh.handleOperandNamespace(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+		Expect(<-recorder.Events).To(Equal("Warning FailedReconcile operand namespace nfd-operands is not watched by the operator"))
+		Expect(nfdCR.Status.OperandNamespace).To(BeEmpty())
+	})
+})
+
+var _ = Describe("removeFinalizer", func() {
+	var (
+		ctrl     *gomock.Controller
+		clnt     *client.MockClient
+		recorder *record.FakeRecorder
+		nfdh     nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		recorder = record.NewFakeRecorder(10)
+
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, recorder, scheme, nil)
+	})
+
+	ctx := context.Background()
+
+	It("removing existing finalizer", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		controllerutil.AddFinalizer(&nfdCR, finalizerLabel)
+		clnt.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		err := nfdh.removeFinalizer(ctx, &nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(<-recorder.Events).To(Equal("Normal FinalizerRemoved removed finalizer nfd-finalizer"))
+	})
+
+	It("removing existing finalizer failed", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		controllerutil.AddFinalizer(&nfdCR, finalizerLabel)
+		clnt.EXPECT().Update(ctx, gomock.Any()).Return(fmt.Errorf("some error"))
+
+		err := nfdh.removeFinalizer(ctx, &nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("removing non-existing finalizer", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		err := nfdh.removeFinalizer(ctx, &nfdCR)
+
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("handlePrune", func() {
+	var (
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		statusWriter *client.MockStatusWriter
+		mockCM       *configmap.MockConfigMapAPI
+		mockJob      *job.MockJobAPI
+		mockSnapshot *snapshot.MockSnapshotAPI
+		mockStatus   *status.MockStatusAPI
+		mockMetrics  *metrics.MockMetricsAPI
+		recorder     *record.FakeRecorder
+		nfdh         nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
+		mockJob = job.NewMockJobAPI(ctrl)
+		mockSnapshot = snapshot.NewMockSnapshotAPI(ctrl)
+		mockStatus = status.NewMockStatusAPI(ctrl)
+		mockMetrics = metrics.NewMockMetricsAPI(ctrl)
+		recorder = record.NewFakeRecorder(10)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, mockCM, mockJob, mockSnapshot, nil, nil, nil, nil, mockStatus, mockMetrics, recorder, scheme, nil)
+	})
+
+	ctx := context.Background()
+	namespace := "test-namespace"
+	runningCondition := metav1.Condition{Type: "Pruned", Status: metav1.ConditionFalse, Reason: "PruneJobRunning"}
+	newNFDCR := func(onFailure nfdv1.PruneFailureAction) *nfdv1.NodeFeatureDiscovery {
+		return &nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				PruneOnDelete: true,
+				PrunePolicy:   nfdv1.PrunePolicy{OnFailure: onFailure},
+			},
+		}
+	}
+	snapshotCreated := "Normal Created created ConfigMap test-namespace/nfd-snapshot-e3b0c44298"
+	newSnapshot := func(name string) corev1.ConfigMap {
+		return corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	failedJob := func() *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune"},
+			Status: batchv1.JobStatus{
+				Failed: 4,
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+				},
+			},
+		}
+	}
+
+	It("prune not defined in the CR", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		}
+
+		done, err := nfdh.handlePrune(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(done).To(BeTrue())
+	})
+
+	It("deletion policy orphans the labels despite prunerOnDelete", func() {
+		nfdCR := newNFDCR("")
+		nfdCR.Spec.DeletionPolicy = nfdv1.DeletionPolicyOrphan
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+		Expect(err).To(BeNil())
+		Expect(done).To(BeTrue())
+	})
+
+	It("prune job succeeded and removed once its TTL expired, it is not run again", func() {
+		nfdCR := newNFDCR("")
+		deletedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+		nfdCR.DeletionTimestamp = &deletedAt
+		nfdCR.Status.Conditions = []metav1.Condition{
+			{Type: "Pruned", Status: metav1.ConditionTrue, Reason: "PruneJobSucceeded", LastTransitionTime: metav1.NewTime(deletedAt.Add(time.Minute))},
+		}
+		mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+		Expect(err).To(BeNil())
+		Expect(done).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("prune delayed, the job is not created yet", func() {
+		nfdCR := newNFDCR("")
+		nfdCR.Spec.DeletionPolicy = nfdv1.DeletionPolicyPruneAfterDelay
+		deletedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+		nfdCR.SetDeletionTimestamp(&deletedAt)
+		delayedCondition := metav1.Condition{Type: "Pruned", Status: metav1.ConditionFalse, Reason: "PruneDelayed"}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockStatus.EXPECT().GetPruneDelayedCondition(nfdCR, deletedAt.Add(time.Hour)).Return(delayedCondition),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("prune delay has passed, the job is created", func() {
+		nfdCR := newNFDCR("")
+		nfdCR.Spec.DeletionPolicy = nfdv1.DeletionPolicyPruneAfterDelay
+		nfdCR.Spec.PrunePolicy.Delay = &metav1.Duration{Duration: 5 * time.Minute}
+		deletedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+		nfdCR.SetDeletionTimestamp(&deletedAt)
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, string(nfdCR.UID), gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(nil, nil),
+			mockJob.EXPECT().CreatePruneJob(ctx, nfdCR).Return(nil),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, nil).Return(runningCondition),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(Equal(snapshotCreated))
+		Expect(<-recorder.Events).To(Equal("Normal PruneJobStarted started prune job test-namespace/nfd-prune to remove the NFD labels from the nodes"))
+	})
+
+	It("CR deleted during a requested prune, the deletion prune runs its own job", func() {
+		nfdCR := newNFDCR("")
+		nfdCR.UID = "1234"
+		nfdCR.Annotations = map[string]string{nfdv1.PruneRequestedAnnotation: "1"}
+		deletedAt := metav1.Now()
+		nfdCR.SetDeletionTimestamp(&deletedAt)
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, "1234", gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(nil, nil),
+			mockJob.EXPECT().CreatePruneJob(ctx, nfdCR).Return(nil),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, nil).Return(runningCondition),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(HavePrefix("Normal Created created ConfigMap test-namespace/nfd-snapshot-"))
+		Expect(<-recorder.Events).To(Equal("Normal PruneJobStarted started prune job test-namespace/nfd-prune to remove the NFD labels from the nodes"))
+	})
+
+	It("failed to get prune job from the cluster", func() {
+		nfdCR := newNFDCR("")
+		mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, fmt.Errorf("some error"))
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(done).To(BeFalse())
+	})
+
+	It("job does not exists, creating it fails", func() {
+		nfdCR := newNFDCR("")
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, string(nfdCR.UID), gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(nil, nil),
+			mockJob.EXPECT().CreatePruneJob(ctx, nfdCR).Return(fmt.Errorf("some error")),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(Equal(snapshotCreated))
+		Expect(<-recorder.Events).To(Equal("Warning PruneJobFailed failed to create prune job: some error"))
+	})
+
+	It("job does not exists, the snapshot fails and the job is not created", func() {
+		nfdCR := newNFDCR("")
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, string(nfdCR.UID), gomock.Any()).Return(fmt.Errorf("some error")),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(HavePrefix("Warning FailedReconcile failed to create or patch ConfigMap test-namespace/nfd-snapshot-e3b0c44298"))
+		Expect(<-recorder.Events).To(HavePrefix("Warning SnapshotFailed failed to snapshot the NFD labels before pruning them"))
+	})
+
+	It("job does not exists, creating it succeeds", func() {
+		nfdCR := newNFDCR("")
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, string(nfdCR.UID), gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(nil, nil),
+			mockJob.EXPECT().CreatePruneJob(ctx, nfdCR).Return(nil),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, nil).Return(runningCondition),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(Equal(snapshotCreated))
+		Expect(<-recorder.Events).To(Equal("Normal PruneJobStarted started prune job test-namespace/nfd-prune to remove the NFD labels from the nodes"))
+		Expect(meta.FindStatusCondition(nfdCR.Status.Conditions, "Pruned")).NotTo(BeNil())
+	})
+
+	It("job is created, only the newest snapshots are kept", func() {
+		nfdCR := newNFDCR("")
+		snapshots := []corev1.ConfigMap{
+			newSnapshot("nfd-snapshot-0000000003"),
+			newSnapshot("nfd-snapshot-e3b0c44298"),
+			newSnapshot("nfd-snapshot-0000000002"),
+			newSnapshot("nfd-snapshot-0000000001"),
+		}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, string(nfdCR.UID), gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(snapshots, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-snapshot-0000000001").Return(true, nil),
+			mockJob.EXPECT().CreatePruneJob(ctx, nfdCR).Return(nil),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, nil).Return(runningCondition),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(Equal(snapshotCreated))
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted ConfigMap test-namespace/nfd-snapshot-0000000001"))
+		Expect(<-recorder.Events).To(Equal("Normal PruneJobStarted started prune job test-namespace/nfd-prune to remove the NFD labels from the nodes"))
+	})
+
+	It("job does not exists, deleting an old snapshot fails and the job is not created", func() {
+		nfdCR := newNFDCR("")
+		snapshots := []corev1.ConfigMap{
+			newSnapshot("nfd-snapshot-e3b0c44298"),
+			newSnapshot("nfd-snapshot-0000000003"),
+			newSnapshot("nfd-snapshot-0000000002"),
+			newSnapshot("nfd-snapshot-0000000001"),
+		}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, string(nfdCR.UID), gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(snapshots, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-snapshot-0000000001").Return(false, fmt.Errorf("some error")),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(done).To(BeFalse())
+		Expect(<-recorder.Events).To(Equal(snapshotCreated))
+		Expect(<-recorder.Events).To(Equal("Warning FailedDelete failed to delete ConfigMap test-namespace/nfd-snapshot-0000000001: some error"))
+	})
+
+	It("job is running after a pod failure, the condition is unchanged", func() {
+		nfdCR := newNFDCR("")
+		nfdCR.Status.Conditions = []metav1.Condition{runningCondition}
+		foundJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune"},
+			Status:     batchv1.JobStatus{Failed: 1},
+		}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(&foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, &foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, &foundJob).Return(runningCondition),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("job succeeded", func() {
+		nfdCR := newNFDCR("")
+		foundJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune"},
+			Status:     batchv1.JobStatus{Succeeded: 1},
+		}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(&foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, &foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, &foundJob).Return(metav1.Condition{Type: "Pruned", Status: metav1.ConditionTrue}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(done).To(BeTrue())
+		Expect(<-recorder.Events).To(Equal("Normal PruneJobSucceeded prune job test-namespace/nfd-prune succeeded"))
+	})
+
+	DescribeTable("job failed for good", func(onFailure nfdv1.PruneFailureAction, expectedDone bool, expectedEvent string) {
+		nfdCR := newNFDCR(onFailure)
+		foundJob := failedJob()
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, foundJob).Return(metav1.Condition{Type: "Pruned", Status: metav1.ConditionFalse}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		done, err := nfdh.handlePrune(ctx, nfdCR)
+
 		Expect(err).To(BeNil())
+		Expect(done).To(Equal(expectedDone))
+		Expect(<-recorder.Events).To(Equal(expectedEvent))
+	},
+		Entry("deletion is blocked by default", nfdv1.PruneFailureAction(""), false,
+			"Warning PruneJobFailed prune job test-namespace/nfd-prune failed: BackoffLimitExceeded, onFailure is Block"),
+		Entry("the finalizer is removed", nfdv1.PruneFailureActionRemoveFinalizer, true,
+			"Warning PruneJobFailed prune job test-namespace/nfd-prune failed: BackoffLimitExceeded, onFailure is RemoveFinalizer"),
+	)
+
+	It("failed to set the prune condition", func() {
+		nfdCR := newNFDCR(nfdv1.PruneFailureActionRemoveFinalizer)
+		foundJob := failedJob()
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, foundJob).Return(metav1.Condition{Type: "Pruned", Status: metav1.ConditionFalse}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
+		)
+
+		_, err := nfdh.handlePrune(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
 	})
 })
 
-var _ = Describe("finalizeComponents", func() {
+var _ = Describe("handlePruneRequest", func() {
 	var (
-		ctrl           *gomock.Controller
-		clnt           *client.MockClient
-		mockDeployment *deployment.MockDeploymentAPI
-		mockDS         *daemonset.MockDaemonsetAPI
-		mockCM         *configmap.MockConfigMapAPI
-		nfdh           nodeFeatureDiscoveryHelperAPI
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		statusWriter *client.MockStatusWriter
+		mockDS       *daemonset.MockDaemonsetAPI
+		mockCM       *configmap.MockConfigMapAPI
+		mockJob      *job.MockJobAPI
+		mockSnapshot *snapshot.MockSnapshotAPI
+		mockStatus   *status.MockStatusAPI
+		mockMetrics  *metrics.MockMetricsAPI
+		recorder     *record.FakeRecorder
+		nfdh         nodeFeatureDiscoveryHelperAPI
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
 		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
 		mockCM = configmap.NewMockConfigMapAPI(ctrl)
-
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, mockDeployment, mockDS, mockCM, nil, nil, scheme)
+		mockJob = job.NewMockJobAPI(ctrl)
+		mockSnapshot = snapshot.NewMockSnapshotAPI(ctrl)
+		mockStatus = status.NewMockStatusAPI(ctrl)
+		mockMetrics = metrics.NewMockMetricsAPI(ctrl)
+		recorder = record.NewFakeRecorder(10)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, mockDS, mockCM, mockJob, mockSnapshot, nil, nil, nil, nil, mockStatus, mockMetrics, recorder, scheme, nil)
 	})
 
 	ctx := context.Background()
 	namespace := "test-namespace"
-	nfdCR := nfdv1.NodeFeatureDiscovery{
-		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
-		Spec: nfdv1.NodeFeatureDiscoverySpec{
-			TopologyUpdater: true,
-		},
+	runningCondition := metav1.Condition{Type: "Pruned", Status: metav1.ConditionFalse, Reason: "PruneJobRunning"}
+	newNFDCR := func() *nfdv1.NodeFeatureDiscovery {
+		return &nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				UID:         "1234",
+				Annotations: map[string]string{nfdv1.PruneRequestedAnnotation: "1"},
+			},
+		}
 	}
 
-	DescribeTable("check finalization normal and error flows", func(deleteWorkerDSError,
-		deleteWorkerCMError,
-		deleteTopologyDSError,
-		deleteMasterDeploymentError,
-		deleteGCDeploymentError bool) {
-
-		if deleteWorkerDSError {
-			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(fmt.Errorf("some error"))
-			goto executeTestFunction
-		}
-		mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(nil)
-		if deleteWorkerCMError {
-			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(fmt.Errorf("some error"))
-			goto executeTestFunction
-		}
-		mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(nil)
-		if deleteTopologyDSError {
-			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater").Return(fmt.Errorf("some error"))
-			goto executeTestFunction
-		}
-		mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-topology-updater").Return(nil)
-		if deleteMasterDeploymentError {
-			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master").Return(fmt.Errorf("some error"))
-			goto executeTestFunction
-		}
-		mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-master").Return(nil)
-		if deleteGCDeploymentError {
-			mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc").Return(fmt.Errorf("some error"))
-			goto executeTestFunction
+	It("no prune requested", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
 		}
-		mockDeployment.EXPECT().DeleteDeployment(ctx, namespace, "nfd-gc").Return(nil)
 
-	executeTestFunction:
+		pruning, err := nfdh.handlePruneRequest(ctx, &nfdCR)
 
-		err := nfdh.finalizeComponents(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(pruning).To(BeFalse())
+	})
 
-		if deleteGCDeploymentError || deleteWorkerDSError || deleteWorkerCMError ||
-			deleteTopologyDSError || deleteMasterDeploymentError {
-			Expect(err).To(HaveOccurred())
-		} else {
-			Expect(err).To(BeNil())
+	It("failed to get prune job from the cluster", func() {
+		nfdCR := newNFDCR()
+		mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(nil, fmt.Errorf("some error"))
+
+		pruning, err := nfdh.handlePruneRequest(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(pruning).To(BeTrue())
+	})
+
+	It("workers are deleted and the job is created", func() {
+		nfdCR := newNFDCR()
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(true, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, nfdCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, nfdCR).Return(nil, nil),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			mockSnapshot.EXPECT().SetSnapshotConfigMapAsDesired(ctx, nfdCR, "1234/1", gomock.Any()).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+			mockSnapshot.EXPECT().ListSnapshots(ctx, nfdCR).Return(nil, nil),
+			mockJob.EXPECT().CreatePruneRequestJob(ctx, nfdCR, "1").Return(nil),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, nil).Return(runningCondition),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		pruning, err := nfdh.handlePruneRequest(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(pruning).To(BeTrue())
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted DaemonSet test-namespace/nfd-worker"))
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted ConfigMap test-namespace/nfd-worker"))
+		Expect(<-recorder.Events).To(Equal("Normal Created created ConfigMap test-namespace/nfd-snapshot-831d71b17c"))
+		Expect(<-recorder.Events).To(Equal("Normal PruneJobStarted started prune job test-namespace/nfd-prune-6b86b273ff to remove the NFD labels from the nodes"))
+	})
+
+	It("job is running, the workers stay deleted", func() {
+		nfdCR := newNFDCR()
+		nfdCR.Status.Conditions = []metav1.Condition{runningCondition}
+		foundJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune-6b86b273ff"},
 		}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(&foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, &foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, &foundJob).Return(runningCondition),
+			mockDS.EXPECT().DeleteDaemonSet(ctx, namespace, "nfd-worker").Return(false, nil),
+			mockCM.EXPECT().DeleteConfigMap(ctx, namespace, "nfd-worker").Return(false, nil),
+			mockDS.EXPECT().ListWorkerPoolDaemonSets(ctx, nfdCR).Return(nil, nil),
+			mockCM.EXPECT().ListWorkerPoolConfigMaps(ctx, nfdCR).Return(nil, nil),
+		)
+
+		pruning, err := nfdh.handlePruneRequest(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(pruning).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	DescribeTable("job completed, the job and the annotation are removed", func(foundJob *batchv1.Job, expectedEvent string) {
+		nfdCR := newNFDCR()
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, foundJob).Return(metav1.Condition{Type: "Pruned", Status: metav1.ConditionTrue}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+			mockJob.EXPECT().DeleteJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(true, nil),
+			clnt.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		pruning, err := nfdh.handlePruneRequest(ctx, nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(pruning).To(BeFalse())
+		Expect(nfdCR.Annotations).NotTo(HaveKey(nfdv1.PruneRequestedAnnotation))
+		Expect(<-recorder.Events).To(Equal(expectedEvent))
+		Expect(<-recorder.Events).To(Equal("Normal Deleted deleted Job test-namespace/nfd-prune-6b86b273ff"))
 	},
-		Entry("delete worker daemonset failed", true, false, false, false, false),
-		Entry("delete worker configmap failed", false, true, false, false, false),
-		Entry("delete topology daemonset failed", false, false, true, false, false),
-		Entry("delete master deployment failed", false, false, false, true, false),
-		Entry("delete gc deployment failed", false, false, false, false, true),
-		Entry("finalization flow was succesful", false, false, false, false, false),
+		Entry("job succeeded",
+			&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune-6b86b273ff"},
+				Status:     batchv1.JobStatus{Succeeded: 1},
+			},
+			"Normal PruneJobSucceeded prune job test-namespace/nfd-prune-6b86b273ff succeeded"),
+		Entry("job failed",
+			&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune-6b86b273ff"},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}},
+				},
+			},
+			"Warning PruneJobFailed prune job test-namespace/nfd-prune-6b86b273ff failed: BackoffLimitExceeded"),
 	)
+
+	It("failed to remove the annotation, the workers stay deleted", func() {
+		nfdCR := newNFDCR()
+		foundJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-prune-6b86b273ff"},
+			Status:     batchv1.JobStatus{Succeeded: 1},
+		}
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(&foundJob, nil),
+			mockMetrics.EXPECT().ObservePruneJob(nfdCR, &foundJob),
+			mockStatus.EXPECT().GetPruneCondition(nfdCR, &foundJob).Return(metav1.Condition{Type: "Pruned", Status: metav1.ConditionTrue}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+			mockJob.EXPECT().DeleteJob(ctx, namespace, "nfd-prune-6b86b273ff").Return(true, nil),
+			clnt.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
+		)
+
+		pruning, err := nfdh.handlePruneRequest(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
+		Expect(pruning).To(BeTrue())
+	})
 })
 
-var _ = Describe("removeFinalizer", func() {
+var _ = Describe("handleRestoreSnapshot", func() {
 	var (
-		ctrl *gomock.Controller
-		clnt *client.MockClient
-		nfdh nodeFeatureDiscoveryHelperAPI
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		mockSnapshot *snapshot.MockSnapshotAPI
+		recorder     *record.FakeRecorder
+		nfdh         nodeFeatureDiscoveryHelperAPI
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, scheme)
+		mockSnapshot = snapshot.NewMockSnapshotAPI(ctrl)
+		recorder = record.NewFakeRecorder(10)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, mockSnapshot, nil, nil, nil, nil, nil, nil, recorder, scheme, nil)
 	})
 
 	ctx := context.Background()
+	namespace := "test-namespace"
+	newNFDCR := func() *nfdv1.NodeFeatureDiscovery {
+		return &nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Annotations: map[string]string{nfdv1.RestoreSnapshotAnnotation: "nfd-snapshot"},
+			},
+		}
+	}
 
-	It("removing existing finalizer", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{}
-		controllerutil.AddFinalizer(&nfdCR, finalizerLabel)
-		clnt.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+	It("no restore annotation", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		}
 
-		err := nfdh.removeFinalizer(ctx, &nfdCR)
+		err := nfdh.handleRestoreSnapshot(ctx, &nfdCR)
 
 		Expect(err).To(BeNil())
+		Expect(recorder.Events).To(BeEmpty())
 	})
 
-	It("removing existing finalizer failed", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{}
-		controllerutil.AddFinalizer(&nfdCR, finalizerLabel)
-		clnt.EXPECT().Update(ctx, gomock.Any()).Return(fmt.Errorf("some error"))
+	It("restore failed, the annotation is kept", func() {
+		nfdCR := newNFDCR()
+		mockSnapshot.EXPECT().RestoreSnapshot(ctx, nfdCR, "nfd-snapshot").Return(0, fmt.Errorf("some error"))
 
-		err := nfdh.removeFinalizer(ctx, &nfdCR)
+		err := nfdh.handleRestoreSnapshot(ctx, nfdCR)
 
 		Expect(err).To(HaveOccurred())
+		Expect(nfdCR.Annotations).To(HaveKey(nfdv1.RestoreSnapshotAnnotation))
+		Expect(<-recorder.Events).To(Equal("Warning SnapshotRestoreFailed failed to restore snapshot test-namespace/nfd-snapshot: some error"))
 	})
 
-	It("removing non-existing finalizer", func() {
-		nfdCR := nfdv1.NodeFeatureDiscovery{}
+	It("restore succeeded, the annotation is removed", func() {
+		nfdCR := newNFDCR()
+		gomock.InOrder(
+			mockSnapshot.EXPECT().RestoreSnapshot(ctx, nfdCR, "nfd-snapshot").Return(3, nil),
+			clnt.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
 
-		err := nfdh.removeFinalizer(ctx, &nfdCR)
+		err := nfdh.handleRestoreSnapshot(ctx, nfdCR)
 
 		Expect(err).To(BeNil())
+		Expect(nfdCR.Annotations).NotTo(HaveKey(nfdv1.RestoreSnapshotAnnotation))
+		Expect(<-recorder.Events).To(Equal("Normal SnapshotRestored restored snapshot test-namespace/nfd-snapshot on 3 nodes"))
+	})
+
+	It("failed to remove the annotation", func() {
+		nfdCR := newNFDCR()
+		gomock.InOrder(
+			mockSnapshot.EXPECT().RestoreSnapshot(ctx, nfdCR, "nfd-snapshot").Return(3, nil),
+			clnt.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
+		)
+
+		err := nfdh.handleRestoreSnapshot(ctx, nfdCR)
+
+		Expect(err).To(HaveOccurred())
 	})
 })
 
-var _ = Describe("handlePrune", func() {
+var _ = Describe("isInstanceTakenOver", func() {
 	var (
-		ctrl    *gomock.Controller
-		mockJob *job.MockJobAPI
-		nfdh    nodeFeatureDiscoveryHelperAPI
+		ctrl     *gomock.Controller
+		clnt     *client.MockClient
+		recorder *record.FakeRecorder
+		nfdh     nodeFeatureDiscoveryHelperAPI
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
-		mockJob = job.NewMockJobAPI(ctrl)
-		nfdh = newNodeFeatureDiscoveryHelperAPI(nil, nil, nil, nil, mockJob, nil, scheme)
+		clnt = client.NewMockClient(ctrl)
+		recorder = record.NewFakeRecorder(10)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, recorder, scheme, nil)
 	})
 
 	ctx := context.Background()
-	namespace := "test-namespace"
+	deletedAt := metav1.Now()
 	nfdCR := nfdv1.NodeFeatureDiscovery{
-		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd", UID: "old-uid", DeletionTimestamp: &deletedAt},
+		Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "blue"},
 	}
 
-	It("prune not defined in the CR", func() {
-		done, err := nfdh.handlePrune(ctx, &nfdCR)
-		Expect(err).To(BeNil())
-		Expect(done).To(BeTrue())
-	})
+	DescribeTable("another CR of the namespace runs the same instance", func(others []nfdv1.NodeFeatureDiscovery, expected bool) {
+		clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.InNamespace("test-namespace")).DoAndReturn(
+			func(_ context.Context, list *nfdv1.NodeFeatureDiscoveryList, _ ...ctrlclient.ListOption) error {
+				list.Items = append([]nfdv1.NodeFeatureDiscovery{nfdCR}, others...)
+				return nil
+			},
+		)
 
-	It("failed to get prune job from the cluster", func() {
-		nfdCR.Spec.PruneOnDelete = true
-		mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, fmt.Errorf("some error"))
+		takenOver, err := nfdh.isInstanceTakenOver(ctx, &nfdCR)
 
-		done, err := nfdh.handlePrune(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(takenOver).To(Equal(expected))
+		if expected {
+			Expect(<-recorder.Events).To(Equal("Normal InstanceTakenOver NodeFeatureDiscovery test-namespace/nfd-new took over the instance, its objects and the NFD labels are kept"))
+		}
+	},
+		Entry("no other CR", nil, false),
+		Entry("other CR runs another instance", []nfdv1.NodeFeatureDiscovery{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-new", UID: "new-uid"}, Spec: nfdv1.NodeFeatureDiscoverySpec{Instance: "green"}},
+		}, false),
+		Entry("other CR is being deleted as well", []nfdv1.NodeFeatureDiscovery{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-new", UID: "new-uid", DeletionTimestamp: &deletedAt}, Spec: nfdv1.NodeFeatureDiscoverySpec{Instance: "blue"}},
+		}, false),
+		Entry("other CR runs the instance in another operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-new", UID: "new-uid"},
+				Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "blue", Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+			},
+		}, false),
+		Entry("other CR took over the instance", []nfdv1.NodeFeatureDiscovery{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-new", UID: "new-uid"}, Spec: nfdv1.NodeFeatureDiscoverySpec{Instance: "blue"}},
+		}, true),
+	)
+
+	It("failed to list the CRs", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 
+		_, err := nfdh.isInstanceTakenOver(ctx, &nfdCR)
 		Expect(err).To(HaveOccurred())
-		Expect(done).To(BeFalse())
+	})
+})
+
+var _ = Describe("getOperandNamespaceOwner", func() {
+	var (
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+		nfdh nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, record.NewFakeRecorder(10), scheme, nil)
 	})
 
-	It("job does not exists, creating it fails", func() {
-		nfdCR.Spec.PruneOnDelete = true
-		gomock.InOrder(
-			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
-			mockJob.EXPECT().CreatePruneJob(ctx, &nfdCR).Return(fmt.Errorf("some error")),
+	ctx := context.Background()
+	createdAt := metav1.NewTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	before := metav1.NewTime(createdAt.Add(-time.Hour))
+	after := metav1.NewTime(createdAt.Add(time.Hour))
+	deletedAt := metav1.Now()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd", UID: "uid", CreationTimestamp: createdAt},
+		Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "blue", Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+	}
+
+	DescribeTable("the oldest CR using the operand namespace owns it", func(others []nfdv1.NodeFeatureDiscovery, expectedOwner string) {
+		clnt.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, list *nfdv1.NodeFeatureDiscoveryList, _ ...ctrlclient.ListOption) error {
+				list.Items = append(others, nfdCR)
+				return nil
+			},
 		)
 
-		done, err := nfdh.handlePrune(ctx, &nfdCR)
+		owner, err := nfdh.getOperandNamespaceOwner(ctx, &nfdCR)
+
+		Expect(err).To(BeNil())
+		if expectedOwner == "" {
+			Expect(owner).To(BeNil())
+		} else {
+			Expect(owner).NotTo(BeNil())
+			Expect(owner.Namespace + "/" + owner.Name).To(Equal(expectedOwner))
+		}
+	},
+		Entry("no other CR", nil, ""),
+		Entry("older CR uses another operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "nfd", UID: "other-uid", CreationTimestamp: before}},
+		}, ""),
+		Entry("newer CR uses the operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "nfd", UID: "other-uid", CreationTimestamp: after},
+				Spec:       nfdv1.NodeFeatureDiscoverySpec{Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+			},
+		}, ""),
+		Entry("older CR uses the operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "nfd", UID: "other-uid", CreationTimestamp: before},
+				Spec:       nfdv1.NodeFeatureDiscoverySpec{Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+			},
+		}, "other-namespace/nfd"),
+		Entry("default instance of another CR uses the operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "nfd-operands", Name: "nfd", UID: "other-uid", CreationTimestamp: before}},
+		}, "nfd-operands/nfd"),
+		Entry("CR created at the same time with a lower name uses the operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "a-nfd", UID: "other-uid", CreationTimestamp: createdAt},
+				Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "green", Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+			},
+		}, "test-namespace/a-nfd"),
+		Entry("older CR of another namespace being deleted still uses the operand namespace", []nfdv1.NodeFeatureDiscovery{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "nfd", UID: "other-uid", CreationTimestamp: before, DeletionTimestamp: &deletedAt},
+				Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "blue", Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+			},
+		}, "other-namespace/nfd"),
+		Entry("older CR of the same instance being deleted is taken over", []nfdv1.NodeFeatureDiscovery{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-old", UID: "other-uid", CreationTimestamp: before, DeletionTimestamp: &deletedAt},
+				Spec:       nfdv1.NodeFeatureDiscoverySpec{Instance: "blue", Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+			},
+		}, ""),
+	)
+
+	It("failed to list the CRs", func() {
+		clnt.EXPECT().List(ctx, gomock.Any()).Return(fmt.Errorf("some error"))
 
+		_, err := nfdh.getOperandNamespaceOwner(ctx, &nfdCR)
 		Expect(err).To(HaveOccurred())
-		Expect(done).To(BeFalse())
 	})
+})
 
-	It("job does not exists, creating it succeeds", func() {
-		nfdCR.Spec.PruneOnDelete = true
+var _ = Describe("handleOperandNamespaceConflict", func() {
+	var (
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		statusWriter *client.MockStatusWriter
+		mockStatus   *status.MockStatusAPI
+		recorder     *record.FakeRecorder
+		nfdh         nodeFeatureDiscoveryHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
+		mockStatus = status.NewMockStatusAPI(ctrl)
+		recorder = record.NewFakeRecorder(10)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockStatus, nil, recorder, scheme, nil)
+	})
+
+	ctx := context.Background()
+	owner := nfdv1.NodeFeatureDiscovery{ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "other-cr"}}
+	degraded := []metav1.Condition{{Type: "Degraded", Status: metav1.ConditionTrue, Reason: "OperandNamespaceConflict", ObservedGeneration: 2}}
+
+	It("conflict is reported in the status and the reconcile fails", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr", Generation: 2},
+			Spec:       nfdv1.NodeFeatureDiscoverySpec{Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"}},
+		}
 		gomock.InOrder(
-			mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
-			mockJob.EXPECT().CreatePruneJob(ctx, &nfdCR).Return(nil),
+			mockStatus.EXPECT().GetOperandNamespaceConflictConditions(&nfdCR, &owner).Return(degraded),
+			mockStatus.EXPECT().MergeConditions(nil, degraded, int64(2)).Return(degraded),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, &nfdCR, gomock.Any()).Return(nil),
 		)
 
-		done, err := nfdh.handlePrune(ctx, &nfdCR)
+		err := nfdh.handleOperandNamespaceConflict(ctx, &nfdCR, &owner)
 
-		Expect(err).To(BeNil())
-		Expect(done).To(BeFalse())
+		Expect(err).To(MatchError("operand namespace nfd-operands is used by NodeFeatureDiscovery other-namespace/other-cr"))
+		Expect(nfdCR.Status.Conditions).To(Equal(degraded))
+		Expect(nfdCR.Status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(<-recorder.Events).To(Equal("Warning OperandNamespaceConflict operand namespace nfd-operands is used by NodeFeatureDiscovery other-namespace/other-cr"))
 	})
 
-	DescribeTable("prune job exsists flows", func(podFailed, podSucceeded bool) {
-		nfdCR.Spec.PruneOnDelete = true
-		foundJob := batchv1.Job{}
-		if podFailed {
-			foundJob.Status.Failed = 1
-		}
-		if podSucceeded {
-			foundJob.Status.Succeeded = 1
+	It("status already reports the conflict, it is not patched", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr", Generation: 2},
+			Status:     nfdv1.NodeFeatureDiscoveryStatus{Conditions: degraded, ObservedGeneration: 2},
 		}
-		mockJob.EXPECT().GetJob(ctx, namespace, "nfd-prune").Return(&foundJob, nil)
-
-		done, err := nfdh.handlePrune(ctx, &nfdCR)
+		gomock.InOrder(
+			mockStatus.EXPECT().GetOperandNamespaceConflictConditions(&nfdCR, &owner).Return(degraded),
+			mockStatus.EXPECT().MergeConditions(degraded, degraded, int64(2)).Return(degraded),
+		)
 
-		switch {
-		case !podFailed && !podSucceeded:
-			Expect(err).To(BeNil())
-			Expect(done).To(BeFalse())
-		case podFailed:
-			Expect(err).To(HaveOccurred())
-			Expect(done).To(BeFalse())
-		case podSucceeded:
-			Expect(err).To(BeNil())
-			Expect(done).To(BeTrue())
-		}
-	},
-		Entry("job has not finished yet", false, false),
-		Entry("job finished, its pod successfull", false, true),
-		Entry("job finished, its pod failed", true, false),
-	)
+		err := nfdh.handleOperandNamespaceConflict(ctx, &nfdCR, &owner)
+		Expect(err).To(HaveOccurred())
+	})
 })
 
 var _ = Describe("handleStatus", func() {
 	var (
-		ctrl       *gomock.Controller
-		clnt       *client.MockClient
-		mockStatus *status.MockStatusAPI
-		nfdh       nodeFeatureDiscoveryHelperAPI
+		ctrl        *gomock.Controller
+		clnt        *client.MockClient
+		mockStatus  *status.MockStatusAPI
+		mockMetrics *metrics.MockMetricsAPI
+		nfdh        nodeFeatureDiscoveryHelperAPI
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockStatus = status.NewMockStatusAPI(ctrl)
-		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, mockStatus, scheme)
+		mockMetrics = metrics.NewMockMetricsAPI(ctrl)
+		nfdh = newNodeFeatureDiscoveryHelperAPI(clnt, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockStatus, mockMetrics, record.NewFakeRecorder(100), scheme, nil)
 	})
 
 	ctx := context.Background()
-	nfdCR := nfdv1.NodeFeatureDiscovery{
-		Status: nfdv1.NodeFeatureDiscoveryStatus{
-			Conditions: []metav1.Condition{},
-		},
+	availableCondition := metav1.Condition{Type: "Available", Status: metav1.ConditionTrue, ObservedGeneration: 2}
+	componentsCondition := metav1.Condition{Type: "ComponentsDeployed", Status: metav1.ConditionTrue}
+	mergedConditions := []metav1.Condition{availableCondition}
+	components := []nfdv1.ComponentStatus{{Name: "master", Kind: "Deployment", Desired: 1, Ready: 1, Updated: 1}}
+
+	newNFDCR := func() *nfdv1.NodeFeatureDiscovery {
+		return &nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Status: nfdv1.NodeFeatureDiscoveryStatus{
+				ObservedGeneration: 2,
+				Conditions:         mergedConditions,
+				Components:         components,
+			},
+		}
 	}
-	newConditions := []metav1.Condition{}
 
-	It("conditions are equal, no status update is needed", func() {
+	expectStatus := func(nfdCR *nfdv1.NodeFeatureDiscovery, merged []metav1.Condition, poolsStatus []nfdv1.WorkerPoolStatus) {
+		prevConditions := nfdCR.Status.Conditions
 		gomock.InOrder(
-			mockStatus.EXPECT().GetConditions(ctx, &nfdCR).Return(newConditions),
-			mockStatus.EXPECT().AreConditionsEqual(newConditions, nfdCR.Status.Conditions).Return(true),
+			mockStatus.EXPECT().GetConditions(ctx, nfdCR).Return([]metav1.Condition{availableCondition}),
+			mockStatus.EXPECT().GetComponentsCondition(ctx, nfdCR).Return(componentsCondition),
+			mockStatus.EXPECT().MergeConditions(prevConditions, []metav1.Condition{availableCondition, componentsCondition}, int64(2)).Return(merged),
+			mockStatus.EXPECT().GetWorkerPoolsStatus(ctx, nfdCR).Return(poolsStatus),
+			mockStatus.EXPECT().GetComponentsStatus(ctx, nfdCR).Return(components),
+			mockMetrics.EXPECT().SetComponentsStatus(nfdCR, components),
+			mockMetrics.EXPECT().SetConditions(nfdCR, merged),
 		)
+	}
+
+	It("status is unchanged, no status update is needed", func() {
+		nfdCR := newNFDCR()
+		expectStatus(nfdCR, mergedConditions, nil)
 
-		err := nfdh.handleStatus(ctx, &nfdCR)
+		err := nfdh.handleStatus(ctx, nfdCR)
 		Expect(err).To(BeNil())
 	})
 
-	It("conditions are not equal, status update is needed", func() {
+	It("conditions changed, status update is needed", func() {
+		nfdCR := newNFDCR()
 		statusWriter := client.NewMockStatusWriter(ctrl)
-		expectedNFD := nfdv1.NodeFeatureDiscovery{
-			Status: nfdv1.NodeFeatureDiscoveryStatus{
-				Conditions: newConditions,
-			},
-		}
+		progressingConditions := []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, ObservedGeneration: 2}}
+		expectStatus(nfdCR, progressingConditions, nil)
 		gomock.InOrder(
-			mockStatus.EXPECT().GetConditions(ctx, &nfdCR).Return(newConditions),
-			mockStatus.EXPECT().AreConditionsEqual(newConditions, nfdCR.Status.Conditions).Return(false),
 			clnt.EXPECT().Status().Return(statusWriter),
-			statusWriter.EXPECT().Patch(ctx, &expectedNFD, gomock.Any()).Return(nil),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
 		)
 
-		err := nfdh.handleStatus(ctx, &nfdCR)
+		err := nfdh.handleStatus(ctx, nfdCR)
 		Expect(err).To(BeNil())
+		Expect(nfdCR.Status.Conditions).To(Equal(progressingConditions))
 	})
 
-	It("conditions are not equal, status update failed", func() {
+	It("conditions changed, status update failed", func() {
+		nfdCR := newNFDCR()
 		statusWriter := client.NewMockStatusWriter(ctrl)
-		expectedNFD := nfdv1.NodeFeatureDiscovery{
-			Status: nfdv1.NodeFeatureDiscoveryStatus{
-				Conditions: newConditions,
-			},
-		}
+		expectStatus(nfdCR, nil, nil)
 		gomock.InOrder(
-			mockStatus.EXPECT().GetConditions(ctx, &nfdCR).Return(newConditions),
-			mockStatus.EXPECT().AreConditionsEqual(newConditions, nfdCR.Status.Conditions).Return(false),
 			clnt.EXPECT().Status().Return(statusWriter),
-			statusWriter.EXPECT().Patch(ctx, &expectedNFD, gomock.Any()).Return(fmt.Errorf("some error")),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(fmt.Errorf("some error")),
 		)
 
-		err := nfdh.handleStatus(ctx, &nfdCR)
+		err := nfdh.handleStatus(ctx, nfdCR)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("worker pools status changed", func() {
+		nfdCR := newNFDCR()
+		statusWriter := client.NewMockStatusWriter(ctrl)
+		poolsStatus := []nfdv1.WorkerPoolStatus{{Name: "gpu", DesiredNumberScheduled: 2, NumberReady: 1}}
+		expectStatus(nfdCR, mergedConditions, poolsStatus)
+		gomock.InOrder(
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		err := nfdh.handleStatus(ctx, nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Status.WorkerPools).To(Equal(poolsStatus))
+	})
+
+	It("spec changed, the new generation is recorded", func() {
+		nfdCR := newNFDCR()
+		nfdCR.Generation = 3
+		statusWriter := client.NewMockStatusWriter(ctrl)
+		gomock.InOrder(
+			mockStatus.EXPECT().GetConditions(ctx, nfdCR).Return([]metav1.Condition{availableCondition}),
+			mockStatus.EXPECT().GetComponentsCondition(ctx, nfdCR).Return(componentsCondition),
+			mockStatus.EXPECT().MergeConditions(mergedConditions, gomock.Any(), int64(3)).Return(mergedConditions),
+			mockStatus.EXPECT().GetWorkerPoolsStatus(ctx, nfdCR).Return(nil),
+			mockStatus.EXPECT().GetComponentsStatus(ctx, nfdCR).Return(components),
+			mockMetrics.EXPECT().SetComponentsStatus(nfdCR, components),
+			mockMetrics.EXPECT().SetConditions(nfdCR, mergedConditions),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, nfdCR, gomock.Any()).Return(nil),
+		)
+
+		err := nfdh.handleStatus(ctx, nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Status.ObservedGeneration).To(Equal(int64(3)))
+	})
 })
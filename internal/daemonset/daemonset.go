@@ -26,9 +26,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
 )
 
 //go:generate mockgen -source=daemonset.go -package=daemonset -destination=mock_daemonset.go DaemonsetAPI
@@ -36,26 +37,32 @@ import (
 type DaemonsetAPI interface {
 	SetTopologyDaemonsetAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, topologyDS *appsv1.DaemonSet) error
 	SetWorkerDaemonsetAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, workerDS *appsv1.DaemonSet) error
-	DeleteDaemonSet(ctx context.Context, namespace, name string) error
+	SetWorkerPoolDaemonsetAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pool *nfdv1.WorkerPool, workerDS *appsv1.DaemonSet) error
+	ListWorkerPoolDaemonSets(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]appsv1.DaemonSet, error)
+	DeleteDaemonSet(ctx context.Context, namespace, name string) (bool, error)
 	GetDaemonSet(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error)
 }
 
 type daemonset struct {
 	client client.Client
 	scheme *runtime.Scheme
+	// operandImage is the image of the operands of the CRs that do not
+	// set one
+	operandImage string
 }
 
-func NewDaemonsetAPI(client client.Client, scheme *runtime.Scheme) DaemonsetAPI {
+func NewDaemonsetAPI(client client.Client, scheme *runtime.Scheme, operandImage string) DaemonsetAPI {
 	return &daemonset{
-		client: client,
-		scheme: scheme,
+		client:       client,
+		scheme:       scheme,
+		operandImage: operandImage,
 	}
 }
 
 func (d *daemonset) SetTopologyDaemonsetAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, topologyDS *appsv1.DaemonSet) error {
 	topologyDS.ObjectMeta.Labels = map[string]string{"app": "nfd"}
 
-	podLabels := map[string]string{"app": "nfd-topology-updater"}
+	podLabels := map[string]string{"app": names.TopologyUpdater(nfdInstance)}
 	topologyDS.Spec = appsv1.DaemonSetSpec{
 		Selector: &metav1.LabelSelector{
 			MatchLabels: podLabels,
@@ -70,25 +77,29 @@ func (d *daemonset) SetTopologyDaemonsetAsDesired(ctx context.Context, nfdInstan
 				Containers: []corev1.Container{
 					{
 						Name:            "nfd-topology-updater",
-						Image:           nfdInstance.Spec.Operand.ImagePath(),
-						ImagePullPolicy: getImagePullPolicy(nfdInstance),
+						Image:           nfdInstance.Spec.Operand.ImagePath(d.operandImage),
+						ImagePullPolicy: nfdInstance.Spec.Operand.GetImagePullPolicy(),
 						Command: []string{
 							"nfd-topology-updater",
 						},
 						Args:            getArgs(nfdInstance),
-						Env:             getTopologyEnvs(),
+						Env:             getTopologyEnvs(nfdInstance),
+						Resources:       nfdInstance.Spec.Operand.GetTopologyUpdaterResources(),
 						SecurityContext: getSecurityContext(),
 						VolumeMounts:    getVolumeMounts(),
+						Ports:           getPorts(),
 					},
 				},
-				Volumes: getVolumes(),
+				Tolerations: nfdInstance.Spec.TopologyUpdater.Tolerations,
+				Volumes:     getVolumes(nfdInstance),
 			},
 		},
 	}
-	return controllerutil.SetControllerReference(nfdInstance, topologyDS, d.scheme)
+	return ownership.SetOwner(nfdInstance, topologyDS, d.scheme)
 }
 
-func (d *daemonset) DeleteDaemonSet(ctx context.Context, namespace, name string) error {
+// DeleteDaemonSet deletes the DaemonSet, if it exists, and reports whether it existed
+func (d *daemonset) DeleteDaemonSet(ctx context.Context, namespace, name string) (bool, error) {
 	ds := appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -96,10 +107,10 @@ func (d *daemonset) DeleteDaemonSet(ctx context.Context, namespace, name string)
 		},
 	}
 	err := d.client.Delete(ctx, &ds)
-	if err != nil && client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to delete daemonset %s/%s: %w", namespace, name, err)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete daemonset %s/%s: %w", namespace, name, err)
 	}
-	return nil
+	return err == nil, nil
 }
 
 func (d *daemonset) GetDaemonSet(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error) {
@@ -108,18 +119,16 @@ func (d *daemonset) GetDaemonSet(ctx context.Context, namespace, name string) (*
 	return ds, err
 }
 
-func getImagePullPolicy(nfdInstance *nfdv1.NodeFeatureDiscovery) corev1.PullPolicy {
-	if nfdInstance.Spec.Operand.ImagePullPolicy != "" {
-		return corev1.PullPolicy(nfdInstance.Spec.Operand.ImagePullPolicy)
-	}
-	return corev1.PullAlways
-}
-
 func getArgs(nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
-	return []string{
+	topologyUpdater := &nfdInstance.Spec.TopologyUpdater
+	args := []string{
 		"-podresources-socket=/host-var/lib/kubelet/pod-resources/kubelet.sock",
-		"-sleep-interval=3s",
+		fmt.Sprintf("-sleep-interval=%s", topologyUpdater.GetSleepInterval()),
+	}
+	if topologyUpdater.KubeletConfigURI != "" {
+		args = append(args, fmt.Sprintf("-kubelet-config-uri=%s", topologyUpdater.KubeletConfigURI))
 	}
+	return args
 }
 
 func getWorkerEnvs(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.EnvVar {
@@ -157,7 +166,7 @@ func getBasicEnvs() []corev1.EnvVar {
 
 }
 
-func getTopologyEnvs() []corev1.EnvVar {
+func getTopologyEnvs(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.EnvVar {
 	nodeAddressEnv := corev1.EnvVar{
 		Name: "NODE_ADDRESS",
 		ValueFrom: &corev1.EnvVarSource{
@@ -166,7 +175,7 @@ func getTopologyEnvs() []corev1.EnvVar {
 			},
 		},
 	}
-	return append(getBasicEnvs(), nodeAddressEnv)
+	return append(append(getBasicEnvs(), nodeAddressEnv), nfdInstance.Spec.TopologyUpdater.Envs...)
 }
 
 func getSecurityContext() *corev1.SecurityContext {
@@ -198,16 +207,25 @@ func getVolumeMounts() []corev1.VolumeMount {
 			MountPath: "/host-var/lib/kubelet",
 			ReadOnly:  true,
 		},
+		{
+			Name:      "nfd-topology-updater-conf",
+			MountPath: "/etc/kubernetes/node-feature-discovery",
+			ReadOnly:  true,
+		},
 	}
 }
 
-func getVolumes() []corev1.Volume {
+// getVolumes returns the volumes of the topology updater. The kubelet files
+// are always mounted under /host-var/lib/kubelet in the container whatever
+// their location on the nodes
+func getVolumes(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.Volume {
+	topologyUpdater := &nfdInstance.Spec.TopologyUpdater
 	return []corev1.Volume{
 		{
 			Name: "kubelet-podresources-sock",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: "/var/lib/kubelet/pod-resources/kubelet.sock",
+					Path: topologyUpdater.GetPodResourcesSocketPath(),
 					Type: ptr.To[corev1.HostPathType](corev1.HostPathSocket),
 				},
 			},
@@ -225,46 +243,120 @@ func getVolumes() []corev1.Volume {
 			Name: "kubelet-state-files",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: "/var/lib/kubelet",
+					Path: topologyUpdater.GetKubeletStateDir(),
 					Type: ptr.To[corev1.HostPathType](corev1.HostPathDirectory),
 				},
 			},
 		},
+		{
+			Name: "nfd-topology-updater-conf",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: names.TopologyUpdater(nfdInstance),
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  "nfd-topology-updater.conf",
+							Path: "nfd-topology-updater.conf",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
 func (d *daemonset) SetWorkerDaemonsetAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, workerDS *appsv1.DaemonSet) error {
 	workerDS.ObjectMeta.Labels = map[string]string{"app": "nfd"}
 
+	name := names.Worker(nfdInstance)
+	d.setWorkerDaemonSetSpec(nfdInstance, workerDS, name, nfdInstance.Spec.Operand.Scheduling.Worker.NodeSelector,
+		getWorkerTolerations(nfdInstance),
+		getWorkerConfigVolumeSource(name, &nfdInstance.Spec.WorkerConfig))
+
+	return ownership.SetOwner(nfdInstance, workerDS, d.scheme)
+}
+
+func (d *daemonset) SetWorkerPoolDaemonsetAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pool *nfdv1.WorkerPool, workerDS *appsv1.DaemonSet) error {
+	workerDS.ObjectMeta.Labels = map[string]string{
+		"app":                 "nfd",
+		names.WorkerPoolLabel: pool.Name,
+	}
+
+	name := names.WorkerPool(nfdInstance, pool.Name)
+	d.setWorkerDaemonSetSpec(nfdInstance, workerDS, name, getWorkerPoolNodeSelector(nfdInstance, pool),
+		append(getWorkerTolerations(nfdInstance), pool.Tolerations...),
+		getWorkerConfigVolumeSource(name, &pool.Config))
+
+	return ownership.SetOwner(nfdInstance, workerDS, d.scheme)
+}
+
+func (d *daemonset) ListWorkerPoolDaemonSets(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]appsv1.DaemonSet, error) {
+	dsList := appsv1.DaemonSetList{}
+	err := d.client.List(ctx, &dsList, client.InNamespace(nfdInstance.GetOperandNamespace()), client.HasLabels{names.WorkerPoolLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worker pool daemonsets in namespace %s: %w", nfdInstance.GetOperandNamespace(), err)
+	}
+	// several NFD instances can share the namespace
+	owned := make([]appsv1.DaemonSet, 0, len(dsList.Items))
+	for _, ds := range dsList.Items {
+		if ownership.IsOwnedBy(&ds, nfdInstance) {
+			owned = append(owned, ds)
+		}
+	}
+	return owned, nil
+}
+
+// setWorkerDaemonSetSpec populates the spec of a worker DaemonSet, shared by
+// the default worker and the worker pools. nfd-worker has no instance flag,
+// the instances are told apart by the configuration of their nfd-master
+func (d *daemonset) setWorkerDaemonSetSpec(nfdInstance *nfdv1.NodeFeatureDiscovery, workerDS *appsv1.DaemonSet, name string,
+	nodeSelector map[string]string, tolerations []corev1.Toleration, configSource *corev1.ConfigMapVolumeSource) {
+	workerScheduling := &nfdInstance.Spec.Operand.Scheduling.Worker
 	workerDS.Spec = appsv1.DaemonSetSpec{
 		Selector: &metav1.LabelSelector{
-			MatchLabels: getWorkerLabelsAForApp("nfd-worker"),
+			MatchLabels: getWorkerLabelsAForApp(name),
 		},
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: getWorkerLabelsAForApp("nfd-worker"),
+				Labels: getWorkerLabelsAForApp(name),
 			},
 			Spec: corev1.PodSpec{
-				Tolerations: getWorkerTolerations(nfdInstance),
-				Affinity:    getWorkerAffinity(),
+				Tolerations:               tolerations,
+				Affinity:                  workerScheduling.GetAffinity(getWorkerAffinity()),
+				NodeSelector:              nodeSelector,
+				PriorityClassName:         workerScheduling.PriorityClassName,
+				TopologySpreadConstraints: workerScheduling.TopologySpreadConstraints,
 
 				ServiceAccountName: "nfd-worker",
 				DNSPolicy:          corev1.DNSClusterFirstWithHostNet,
 				Containers: []corev1.Container{
 					{
 						Env:             getWorkerEnvs(nfdInstance),
-						Image:           nfdInstance.Spec.Operand.ImagePath(),
+						Image:           nfdInstance.Spec.Operand.ImagePath(d.operandImage),
 						Name:            "nfd-worker",
 						Command:         []string{"nfd-worker"},
 						Args:            []string{},
 						VolumeMounts:    *getWorkerVolumeMounts(),
-						ImagePullPolicy: getImagePullPolicy(nfdInstance),
+						Resources:       nfdInstance.Spec.Operand.GetWorkerResources(),
+						ImagePullPolicy: nfdInstance.Spec.Operand.GetImagePullPolicy(),
 						SecurityContext: getWorkerSecurityContext(),
+						Ports:           getPorts(),
 					},
 				},
-				Volumes: getWorkerVolumes(),
+				Volumes: getWorkerVolumes(configSource),
 			},
 		},
 	}
-	return controllerutil.SetControllerReference(nfdInstance, workerDS, d.scheme)
+}
+
+// getPorts returns the port the operand serves its metrics on
+func getPorts() []corev1.ContainerPort {
+	return []corev1.ContainerPort{
+		{
+			ContainerPort: 8080,
+			Name:          "http",
+		},
+	}
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -27,8 +28,10 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
@@ -41,7 +44,7 @@ var _ = Describe("SetTopologyDaemonsetAsDesired", func() {
 	)
 
 	BeforeEach(func() {
-		daemonsetAPI = NewDaemonsetAPI(nil, scheme)
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -85,7 +88,7 @@ var _ = Describe("SetWorkerDaemonsetAsDesired", func() {
 	)
 
 	BeforeEach(func() {
-		daemonsetAPI = NewDaemonsetAPI(nil, scheme)
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -121,6 +124,166 @@ var _ = Describe("SetWorkerDaemonsetAsDesired", func() {
 		Expect(err).To(BeNil())
 		Expect(&expectedWorkerDS).To(BeComparableTo(&actualWorkerDS))
 	})
+
+	It("worker of a named instance is not passed the instance", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{Instance: "blue"},
+		}
+		workerDS := appsv1.DaemonSet{}
+
+		err := daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, &nfdCR, &workerDS)
+		Expect(err).To(BeNil())
+		Expect(workerDS.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"nfd-worker"}))
+		Expect(workerDS.Spec.Template.Spec.Containers[0].Args).To(BeEmpty())
+	})
+
+	It("worker runs the operator image when the CR does not set one", func() {
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "operator-image")
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		workerDS := appsv1.DaemonSet{}
+
+		err := daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, &nfdCR, &workerDS)
+		Expect(err).To(BeNil())
+		Expect(workerDS.Spec.Template.Spec.Containers[0].Image).To(Equal("operator-image"))
+
+		nfdCR.Spec.Operand.Image = "test-image"
+		err = daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, &nfdCR, &workerDS)
+		Expect(err).To(BeNil())
+		Expect(workerDS.Spec.Template.Spec.Containers[0].Image).To(Equal("test-image"))
+	})
+})
+
+var _ = Describe("SetWorkerPoolDaemonsetAsDesired", func() {
+	var (
+		daemonsetAPI DaemonsetAPI
+	)
+
+	BeforeEach(func() {
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "")
+	})
+
+	ctx := context.Background()
+
+	It("worker pool daemonset populated with correct values", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					Image: "test-image",
+				},
+			},
+		}
+		pool := nfdv1.WorkerPool{
+			Name:         "gpu",
+			NodeSelector: map[string]string{"node-role.kubernetes.io/gpu": ""},
+			Tolerations: []corev1.Toleration{
+				{
+					Key:      "nvidia.com/gpu",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+		}
+		actualWorkerDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-worker--gpu",
+				Namespace: "test-namespace",
+			},
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DaemonSet",
+				APIVersion: "apps/v1",
+			},
+		}
+
+		err := daemonsetAPI.SetWorkerPoolDaemonsetAsDesired(ctx, &nfdCR, &pool, &actualWorkerDS)
+
+		Expect(err).To(BeNil())
+		expectedYAMLFile, err := os.ReadFile("testdata/test_worker_pool_daemonset.yaml")
+		Expect(err).To(BeNil())
+		expectedJSON, err := yaml.YAMLToJSON(expectedYAMLFile)
+		Expect(err).To(BeNil())
+		expectedWorkerDS := appsv1.DaemonSet{}
+		err = yaml.Unmarshal(expectedJSON, &expectedWorkerDS)
+		Expect(err).To(BeNil())
+		Expect(&expectedWorkerDS).To(BeComparableTo(&actualWorkerDS))
+	})
+})
+
+var _ = Describe("ListWorkerPoolDaemonSets", func() {
+	var (
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		daemonsetAPI DaemonsetAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		daemonsetAPI = NewDaemonsetAPI(clnt, scheme, "")
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace", UID: "nfd-uid"},
+	}
+
+	It("only the daemonsets controlled by the NFD CR are returned", func() {
+		ownedDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nfd-worker--gpu",
+				OwnerReferences: []metav1.OwnerReference{{UID: "nfd-uid", Controller: ptr.To(true)}},
+			},
+		}
+		otherDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nfd-worker-blue--gpu",
+				OwnerReferences: []metav1.OwnerReference{{UID: "other-uid", Controller: ptr.To(true)}},
+			},
+		}
+		clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.InNamespace("test-namespace"), ctrlclient.HasLabels{"nfd.kubernetes.io/worker-pool"}).DoAndReturn(
+			func(_ interface{}, list *appsv1.DaemonSetList, _ ...ctrlclient.ListOption) error {
+				list.Items = []appsv1.DaemonSet{ownedDS, otherDS}
+				return nil
+			},
+		)
+
+		res, err := daemonsetAPI.ListWorkerPoolDaemonSets(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(res).To(Equal([]appsv1.DaemonSet{ownedDS}))
+	})
+
+	It("daemonsets of the operand namespace are owned through their labels", func() {
+		operandCR := nfdCR.DeepCopy()
+		operandCR.Spec.Operand.Namespace = "nfd-operands"
+		ownedDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "nfd-worker--gpu",
+				Labels: map[string]string{"nfd.kubernetes.io/owner-namespace": "test-namespace", "nfd.kubernetes.io/owner-name": "nfd-cr"},
+			},
+		}
+		otherDS := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "nfd-worker-blue--gpu",
+				Labels: map[string]string{"nfd.kubernetes.io/owner-namespace": "test-namespace", "nfd.kubernetes.io/owner-name": "other-cr"},
+			},
+		}
+		clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.InNamespace("nfd-operands"), ctrlclient.HasLabels{"nfd.kubernetes.io/worker-pool"}).DoAndReturn(
+			func(_ interface{}, list *appsv1.DaemonSetList, _ ...ctrlclient.ListOption) error {
+				list.Items = []appsv1.DaemonSet{ownedDS, otherDS}
+				return nil
+			},
+		)
+
+		res, err := daemonsetAPI.ListWorkerPoolDaemonSets(ctx, operandCR)
+		Expect(err).To(BeNil())
+		Expect(res).To(Equal([]appsv1.DaemonSet{ownedDS}))
+	})
+
+	It("error flow", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := daemonsetAPI.ListWorkerPoolDaemonSets(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
 })
 
 var _ = Describe("DeleteDaemonSet", func() {
@@ -133,7 +296,7 @@ var _ = Describe("DeleteDaemonSet", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		daemonsetAPI = NewDaemonsetAPI(clnt, scheme)
+		daemonsetAPI = NewDaemonsetAPI(clnt, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -150,22 +313,24 @@ var _ = Describe("DeleteDaemonSet", func() {
 	It("failure to delete daemonset from the cluster", func() {
 		clnt.EXPECT().Delete(ctx, expectedDS).Return(fmt.Errorf("some error"))
 
-		err := daemonsetAPI.DeleteDaemonSet(ctx, namespace, name)
+		_, err := daemonsetAPI.DeleteDaemonSet(ctx, namespace, name)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("daemonset is not present in the cluster", func() {
 		clnt.EXPECT().Delete(ctx, expectedDS).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
 
-		err := daemonsetAPI.DeleteDaemonSet(ctx, namespace, name)
+		deleted, err := daemonsetAPI.DeleteDaemonSet(ctx, namespace, name)
 		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
 	})
 
 	It("daemonset deleted successfully", func() {
 		clnt.EXPECT().Delete(ctx, expectedDS).Return(nil)
 
-		err := daemonsetAPI.DeleteDaemonSet(ctx, namespace, name)
+		deleted, err := daemonsetAPI.DeleteDaemonSet(ctx, namespace, name)
 		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
 	})
 })
 
@@ -179,7 +344,7 @@ var _ = Describe("GetDaemonSet", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		daemonsetAPI = NewDaemonsetAPI(clnt, scheme)
+		daemonsetAPI = NewDaemonsetAPI(clnt, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -260,3 +425,176 @@ var _ = Describe("getWorkerEnvs", func() {
 		Expect(res).To(Equal(expectedRes))
 	})
 })
+
+var _ = Describe("operand resources", func() {
+	var (
+		daemonsetAPI DaemonsetAPI
+	)
+
+	BeforeEach(func() {
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "")
+	})
+
+	ctx := context.Background()
+	workerResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Resources: nfdv1.OperandResources{
+					Worker: &workerResources,
+				},
+			},
+		},
+	}
+
+	It("worker resources defined in the NFD CR are applied to the worker and the worker pools", func() {
+		workerDS := appsv1.DaemonSet{}
+		err := daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, &nfdCR, &workerDS)
+		Expect(err).To(BeNil())
+		Expect(workerDS.Spec.Template.Spec.Containers[0].Resources).To(Equal(workerResources))
+
+		poolDS := appsv1.DaemonSet{}
+		err = daemonsetAPI.SetWorkerPoolDaemonsetAsDesired(ctx, &nfdCR, &nfdv1.WorkerPool{Name: "gpu"}, &poolDS)
+		Expect(err).To(BeNil())
+		Expect(poolDS.Spec.Template.Spec.Containers[0].Resources).To(Equal(workerResources))
+	})
+
+	It("topology updater gets the default resources", func() {
+		topologyDS := appsv1.DaemonSet{}
+		err := daemonsetAPI.SetTopologyDaemonsetAsDesired(ctx, &nfdCR, &topologyDS)
+		Expect(err).To(BeNil())
+		Expect(topologyDS.Spec.Template.Spec.Containers[0].Resources).To(Equal(nfdv1.DefaultTopologyUpdaterResources))
+	})
+})
+
+var _ = Describe("worker scheduling", func() {
+	var (
+		daemonsetAPI DaemonsetAPI
+	)
+
+	BeforeEach(func() {
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "")
+	})
+
+	ctx := context.Background()
+
+	It("worker scheduling defined in the NFD CR", func() {
+		affinity := corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{
+									Key:      "kubernetes.io/os",
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"linux"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					Scheduling: nfdv1.OperandScheduling{
+						Worker: nfdv1.PodScheduling{
+							NodeSelector:      map[string]string{"example.com/nfd": "true"},
+							Affinity:          &affinity,
+							PriorityClassName: "system-node-critical",
+						},
+					},
+				},
+			},
+		}
+		workerDS := appsv1.DaemonSet{}
+
+		err := daemonsetAPI.SetWorkerDaemonsetAsDesired(ctx, &nfdCR, &workerDS)
+
+		Expect(err).To(BeNil())
+		podSpec := workerDS.Spec.Template.Spec
+		Expect(podSpec.NodeSelector).To(Equal(map[string]string{"example.com/nfd": "true"}))
+		Expect(podSpec.Affinity).To(Equal(&affinity))
+		Expect(podSpec.PriorityClassName).To(Equal("system-node-critical"))
+	})
+})
+
+var _ = Describe("topology updater configuration", func() {
+	var (
+		daemonsetAPI DaemonsetAPI
+	)
+
+	BeforeEach(func() {
+		daemonsetAPI = NewDaemonsetAPI(nil, scheme, "")
+	})
+
+	ctx := context.Background()
+
+	It("topology updater settings defined in the NFD CR", func() {
+		toleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists}
+		env := corev1.EnvVar{Name: "GOMAXPROCS", Value: "1"}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{
+					Enabled:          true,
+					SleepInterval:    &metav1.Duration{Duration: time.Minute},
+					KubeletStateDir:  "/var/data/kubelet",
+					KubeletConfigURI: "https://${NODE_ADDRESS}:10250/configz",
+					Tolerations:      []corev1.Toleration{toleration},
+					Envs:             []corev1.EnvVar{env},
+				},
+			},
+		}
+
+		topologyDS := appsv1.DaemonSet{}
+		err := daemonsetAPI.SetTopologyDaemonsetAsDesired(ctx, &nfdCR, &topologyDS)
+		Expect(err).To(BeNil())
+
+		podSpec := topologyDS.Spec.Template.Spec
+		Expect(podSpec.Containers[0].Args).To(Equal([]string{
+			"-podresources-socket=/host-var/lib/kubelet/pod-resources/kubelet.sock",
+			"-sleep-interval=1m0s",
+			"-kubelet-config-uri=https://${NODE_ADDRESS}:10250/configz",
+		}))
+		Expect(podSpec.Containers[0].Env).To(ContainElement(env))
+		Expect(podSpec.Tolerations).To(Equal([]corev1.Toleration{toleration}))
+		Expect(podSpec.Volumes[0].HostPath.Path).To(Equal("/var/data/kubelet/pod-resources/kubelet.sock"))
+		Expect(podSpec.Volumes[2].HostPath.Path).To(Equal("/var/data/kubelet"))
+		Expect(podSpec.Volumes[3].ConfigMap.Name).To(Equal("nfd-topology-updater"))
+	})
+
+	DescribeTable("topologyUpdater accepts the legacy boolean", func(spec string, expected nfdv1.TopologyUpdaterSpec) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		err := yaml.Unmarshal([]byte(spec), &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.TopologyUpdater).To(Equal(expected))
+	},
+		Entry("enabled boolean", "spec:\n  topologyUpdater: true\n", nfdv1.TopologyUpdaterSpec{Enabled: true}),
+		Entry("disabled boolean", "spec:\n  topologyUpdater: false\n", nfdv1.TopologyUpdaterSpec{}),
+		Entry("object", "spec:\n  topologyUpdater:\n    enabled: true\n    kubeletStateDir: /var/data/kubelet\n",
+			nfdv1.TopologyUpdaterSpec{Enabled: true, KubeletStateDir: "/var/data/kubelet"}),
+	)
+
+	It("the podresources socket path can be set independently", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{
+					Enabled:                true,
+					PodResourcesSocketPath: "/run/kubelet/pod-resources.sock",
+				},
+			},
+		}
+
+		topologyDS := appsv1.DaemonSet{}
+		err := daemonsetAPI.SetTopologyDaemonsetAsDesired(ctx, &nfdCR, &topologyDS)
+		Expect(err).To(BeNil())
+		Expect(topologyDS.Spec.Template.Spec.Volumes[0].HostPath.Path).To(Equal("/run/kubelet/pod-resources.sock"))
+		Expect(topologyDS.Spec.Template.Spec.Volumes[2].HostPath.Path).To(Equal("/var/lib/kubelet"))
+	})
+})
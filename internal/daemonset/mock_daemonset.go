@@ -41,11 +41,12 @@ func (m *MockDaemonsetAPI) EXPECT() *MockDaemonsetAPIMockRecorder {
 }
 
 // DeleteDaemonSet mocks base method.
-func (m *MockDaemonsetAPI) DeleteDaemonSet(ctx context.Context, namespace, name string) error {
+func (m *MockDaemonsetAPI) DeleteDaemonSet(ctx context.Context, namespace, name string) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteDaemonSet", ctx, namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteDaemonSet indicates an expected call of DeleteDaemonSet.
@@ -69,6 +70,21 @@ func (mr *MockDaemonsetAPIMockRecorder) GetDaemonSet(ctx, namespace, name any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaemonSet", reflect.TypeOf((*MockDaemonsetAPI)(nil).GetDaemonSet), ctx, namespace, name)
 }
 
+// ListWorkerPoolDaemonSets mocks base method.
+func (m *MockDaemonsetAPI) ListWorkerPoolDaemonSets(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) ([]v1.DaemonSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkerPoolDaemonSets", ctx, nfdInstance)
+	ret0, _ := ret[0].([]v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkerPoolDaemonSets indicates an expected call of ListWorkerPoolDaemonSets.
+func (mr *MockDaemonsetAPIMockRecorder) ListWorkerPoolDaemonSets(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkerPoolDaemonSets", reflect.TypeOf((*MockDaemonsetAPI)(nil).ListWorkerPoolDaemonSets), ctx, nfdInstance)
+}
+
 // SetTopologyDaemonsetAsDesired mocks base method.
 func (m *MockDaemonsetAPI) SetTopologyDaemonsetAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, topologyDS *v1.DaemonSet) error {
 	m.ctrl.T.Helper()
@@ -96,3 +112,17 @@ func (mr *MockDaemonsetAPIMockRecorder) SetWorkerDaemonsetAsDesired(ctx, nfdInst
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerDaemonsetAsDesired", reflect.TypeOf((*MockDaemonsetAPI)(nil).SetWorkerDaemonsetAsDesired), ctx, nfdInstance, workerDS)
 }
+
+// SetWorkerPoolDaemonsetAsDesired mocks base method.
+func (m *MockDaemonsetAPI) SetWorkerPoolDaemonsetAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, pool *v10.WorkerPool, workerDS *v1.DaemonSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkerPoolDaemonsetAsDesired", ctx, nfdInstance, pool, workerDS)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkerPoolDaemonsetAsDesired indicates an expected call of SetWorkerPoolDaemonsetAsDesired.
+func (mr *MockDaemonsetAPIMockRecorder) SetWorkerPoolDaemonsetAsDesired(ctx, nfdInstance, pool, workerDS any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerPoolDaemonsetAsDesired", reflect.TypeOf((*MockDaemonsetAPI)(nil).SetWorkerPoolDaemonsetAsDesired), ctx, nfdInstance, pool, workerDS)
+}
@@ -128,7 +128,7 @@ func getWorkerVolumeMounts() *[]corev1.VolumeMount {
 	return &containerVolumeMounts
 }
 
-func getWorkerVolumes() []corev1.Volume {
+func getWorkerVolumes(configSource *corev1.ConfigMapVolumeSource) []corev1.Volume {
 	containerVolume := []corev1.Volume{
 		{
 			Name: "host-boot",
@@ -173,15 +173,7 @@ func getWorkerVolumes() []corev1.Volume {
 		{
 			Name: "nfd-worker-config",
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{Name: "nfd-worker"},
-					Items: []corev1.KeyToPath{
-						{
-							Key:  "nfd-worker-conf",
-							Path: "nfd-worker.conf",
-						},
-					},
-				},
+				ConfigMap: configSource,
 			},
 		},
 		{
@@ -220,6 +212,27 @@ func getWorkerVolumes() []corev1.Volume {
 	return containerVolume
 }
 
+// getWorkerConfigVolumeSource returns the ConfigMap holding nfd-worker.conf:
+// either the one referenced by the worker config, or the operator owned one
+// named ownedName
+func getWorkerConfigVolumeSource(ownedName string, workerConfig *nfdv1.ConfigMap) *corev1.ConfigMapVolumeSource {
+	cmName := ownedName
+	cmKey := "nfd-worker-conf"
+	if ref := workerConfig.ConfigMapRef; ref != nil {
+		cmName = ref.Name
+		cmKey = ref.GetKey()
+	}
+	return &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+		Items: []corev1.KeyToPath{
+			{
+				Key:  cmKey,
+				Path: "nfd-worker.conf",
+			},
+		},
+	}
+}
+
 func getWorkerLabelsAForApp(name string) map[string]string {
 	return map[string]string{"app": name}
 }
@@ -232,5 +245,22 @@ func getWorkerTolerations(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.Tole
 		},
 	}
 
-	return append(basicTolerations, nfdInstance.Spec.Operand.WorkerTolerations...)
+	return nfdInstance.Spec.Operand.Scheduling.Worker.GetTolerations(basicTolerations, nfdInstance.Spec.Operand.WorkerTolerations)
+}
+
+// getWorkerPoolNodeSelector merges the node selector of the pool into the
+// one of the worker, the pool wins on conflicting keys
+func getWorkerPoolNodeSelector(nfdInstance *nfdv1.NodeFeatureDiscovery, pool *nfdv1.WorkerPool) map[string]string {
+	workerNodeSelector := nfdInstance.Spec.Operand.Scheduling.Worker.NodeSelector
+	if len(workerNodeSelector) == 0 {
+		return pool.NodeSelector
+	}
+	nodeSelector := make(map[string]string, len(workerNodeSelector)+len(pool.NodeSelector))
+	for k, v := range workerNodeSelector {
+		nodeSelector[k] = v
+	}
+	for k, v := range pool.NodeSelector {
+		nodeSelector[k] = v
+	}
+	return nodeSelector
 }
@@ -68,4 +68,106 @@ var _ = Describe("getWorkerToleration", func() {
 		res := getWorkerTolerations(&nfdCR)
 		Expect(res).To(Equal(expectedTolerations))
 	})
+
+	It("worker tolerations defined in NFD CR replace the built-in ones", func() {
+		workerTolerations := []corev1.Toleration{
+			{
+				Key:      "key1",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					WorkerTolerations: workerTolerations,
+					Scheduling: nfdv1.OperandScheduling{
+						Worker: nfdv1.PodScheduling{TolerationsPolicy: nfdv1.TolerationsPolicyReplace},
+					},
+				},
+			},
+		}
+
+		res := getWorkerTolerations(&nfdCR)
+		Expect(res).To(Equal(workerTolerations))
+	})
+})
+
+var _ = Describe("getWorkerPoolNodeSelector", func() {
+	pool := nfdv1.WorkerPool{
+		Name:         "gpu",
+		NodeSelector: map[string]string{"node-role.kubernetes.io/gpu": "", "example.com/zone": "b"},
+	}
+
+	It("no worker node selector defined in NFD CR", func() {
+		res := getWorkerPoolNodeSelector(&nfdv1.NodeFeatureDiscovery{}, &pool)
+		Expect(res).To(Equal(pool.NodeSelector))
+	})
+
+	It("pool node selector is merged into the worker one", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					Scheduling: nfdv1.OperandScheduling{
+						Worker: nfdv1.PodScheduling{
+							NodeSelector: map[string]string{"kubernetes.io/os": "linux", "example.com/zone": "a"},
+						},
+					},
+				},
+			},
+		}
+
+		res := getWorkerPoolNodeSelector(&nfdCR, &pool)
+		Expect(res).To(Equal(map[string]string{
+			"kubernetes.io/os":            "linux",
+			"node-role.kubernetes.io/gpu": "",
+			"example.com/zone":            "b",
+		}))
+	})
+})
+
+var _ = Describe("getWorkerVolumes", func() {
+	It("worker config volume uses the given configmap", func() {
+		configSource := &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "nfd-worker-blue"},
+		}
+
+		res := getWorkerVolumes(configSource)
+		var configVolume *corev1.Volume
+		for i := range res {
+			if res[i].Name == "nfd-worker-config" {
+				configVolume = &res[i]
+			}
+		}
+		Expect(configVolume).ToNot(BeNil())
+		Expect(configVolume.ConfigMap).To(Equal(configSource))
+	})
+})
+
+var _ = Describe("getWorkerConfigVolumeSource", func() {
+	It("operator owned configmap", func() {
+		res := getWorkerConfigVolumeSource("nfd-worker", &nfdv1.ConfigMap{})
+		Expect(res.Name).To(Equal("nfd-worker"))
+		Expect(res.Items).To(Equal([]corev1.KeyToPath{{Key: "nfd-worker-conf", Path: "nfd-worker.conf"}}))
+	})
+
+	It("referenced configmap with the default key", func() {
+		workerConfig := nfdv1.ConfigMap{
+			ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm"},
+		}
+
+		res := getWorkerConfigVolumeSource("nfd-worker", &workerConfig)
+		Expect(res.Name).To(Equal("user-cm"))
+		Expect(res.Items).To(Equal([]corev1.KeyToPath{{Key: "nfd-worker.conf", Path: "nfd-worker.conf"}}))
+	})
+
+	It("referenced configmap with a custom key", func() {
+		workerConfig := nfdv1.ConfigMap{
+			ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm", Key: "worker.yaml"},
+		}
+
+		res := getWorkerConfigVolumeSource("nfd-worker", &workerConfig)
+		Expect(res.Name).To(Equal("user-cm"))
+		Expect(res.Items).To(Equal([]corev1.KeyToPath{{Key: "worker.yaml", Path: "nfd-worker.conf"}}))
+	})
 })
@@ -28,13 +28,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
-)
-
-const (
-	defaultServicePort int = 12000
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
 )
 
 //go:generate mockgen -source=deployment.go -package=deployment -destination=mock_deployment.go DeploymentAPI
@@ -42,28 +39,33 @@ const (
 type DeploymentAPI interface {
 	SetMasterDeploymentAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, masterDep *v1.Deployment) error
 	SetGCDeploymentAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, gcDep *v1.Deployment) error
-	DeleteDeployment(ctx context.Context, namespace, name string) error
+	DeleteDeployment(ctx context.Context, namespace, name string) (bool, error)
 	GetDeployment(ctx context.Context, namespace, name string) (*v1.Deployment, error)
 }
 
 type deployment struct {
 	client client.Client
 	scheme *runtime.Scheme
+	// operandImage is the image of the operands of the CRs that do not
+	// set one
+	operandImage string
 }
 
-func NewDeploymentAPI(client client.Client, scheme *runtime.Scheme) DeploymentAPI {
+func NewDeploymentAPI(client client.Client, scheme *runtime.Scheme, operandImage string) DeploymentAPI {
 	return &deployment{
-		client: client,
-		scheme: scheme,
+		client:       client,
+		scheme:       scheme,
+		operandImage: operandImage,
 	}
 }
 
 func (d *deployment) SetMasterDeploymentAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, masterDep *v1.Deployment) error {
-	standartLabels := map[string]string{"app": "nfd-master"}
+	standartLabels := map[string]string{"app": names.Master(nfdInstance)}
 	masterDep.ObjectMeta.Labels = standartLabels
 
+	masterScheduling := &nfdInstance.Spec.Operand.Scheduling.Master
 	masterDep.Spec = v1.DeploymentSpec{
-		Replicas: ptr.To[int32](1),
+		Replicas: ptr.To(nfdInstance.Spec.Master.GetReplicas()),
 		Selector: &metav1.LabelSelector{
 			MatchLabels: standartLabels,
 		},
@@ -72,21 +74,25 @@ func (d *deployment) SetMasterDeploymentAsDesired(nfdInstance *nfdv1.NodeFeature
 				Labels: standartLabels,
 			},
 			Spec: corev1.PodSpec{
-				ServiceAccountName: "nfd-master",
-				DNSPolicy:          corev1.DNSClusterFirstWithHostNet,
-				RestartPolicy:      corev1.RestartPolicyAlways,
-				Tolerations:        getPodsTolerations(nfdInstance),
-				Affinity:           getPodsAffinity(),
+				ServiceAccountName:        "nfd-master",
+				DNSPolicy:                 corev1.DNSClusterFirstWithHostNet,
+				RestartPolicy:             corev1.RestartPolicyAlways,
+				Tolerations:               getPodsTolerations(nfdInstance),
+				Affinity:                  getMasterAffinity(nfdInstance),
+				NodeSelector:              masterScheduling.NodeSelector,
+				PriorityClassName:         masterScheduling.PriorityClassName,
+				TopologySpreadConstraints: masterScheduling.TopologySpreadConstraints,
 				Containers: []corev1.Container{
 					{
 						Name:            "nfd-master",
-						Image:           nfdInstance.Spec.Operand.ImagePath(),
-						ImagePullPolicy: getImagePullPolicy(nfdInstance),
+						Image:           nfdInstance.Spec.Operand.ImagePath(d.operandImage),
+						ImagePullPolicy: nfdInstance.Spec.Operand.GetImagePullPolicy(),
 						Command: []string{
 							"nfd-master",
 						},
 						Args:            getArgs(nfdInstance),
 						Env:             getMasterEnvs(nfdInstance),
+						Resources:       nfdInstance.Spec.Operand.GetMasterResources(),
 						SecurityContext: getMasterSecurityContext(),
 						LivenessProbe:   getLivenessProbe(),
 						ReadinessProbe:  getReadinessProbe(),
@@ -96,12 +102,46 @@ func (d *deployment) SetMasterDeploymentAsDesired(nfdInstance *nfdv1.NodeFeature
 			},
 		},
 	}
-	return controllerutil.SetControllerReference(nfdInstance, masterDep, d.scheme)
+	// the master reads the nfd-master.conf restricting it to the
+	// NodeFeature objects of its own workers
+	masterDep.Spec.Template.Spec.Volumes = getMasterVolumes(nfdInstance)
+	masterDep.Spec.Template.Spec.Containers[0].VolumeMounts = getMasterVolumeMounts()
+	return ownership.SetOwner(nfdInstance, masterDep, d.scheme)
+}
+
+func getMasterVolumes(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: "nfd-master-conf",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: names.Master(nfdInstance)},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  "nfd-master.conf",
+							Path: "nfd-master.conf",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getMasterVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      "nfd-master-conf",
+			MountPath: "/etc/kubernetes/node-feature-discovery",
+			ReadOnly:  true,
+		},
+	}
 }
 
 func (d *deployment) SetGCDeploymentAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, gcDep *v1.Deployment) error {
 	gcDep.ObjectMeta.Labels = map[string]string{"app": "nfd"}
-	matchLabels := map[string]string{"app": "nfd-gc"}
+	matchLabels := map[string]string{"app": names.GC(nfdInstance)}
+	gcScheduling := &nfdInstance.Spec.Operand.Scheduling.GC
 	gcDep.Spec = v1.DeploymentSpec{
 		Replicas: ptr.To[int32](1),
 		Selector: &metav1.LabelSelector{
@@ -112,18 +152,24 @@ func (d *deployment) SetGCDeploymentAsDesired(nfdInstance *nfdv1.NodeFeatureDisc
 				Labels: matchLabels,
 			},
 			Spec: corev1.PodSpec{
-				ServiceAccountName: "nfd-gc",
-				DNSPolicy:          corev1.DNSClusterFirstWithHostNet,
-				RestartPolicy:      corev1.RestartPolicyAlways,
+				ServiceAccountName:        "nfd-gc",
+				DNSPolicy:                 corev1.DNSClusterFirstWithHostNet,
+				RestartPolicy:             corev1.RestartPolicyAlways,
+				Tolerations:               getGCTolerations(nfdInstance),
+				Affinity:                  gcScheduling.GetAffinity(nil),
+				NodeSelector:              gcScheduling.NodeSelector,
+				PriorityClassName:         gcScheduling.PriorityClassName,
+				TopologySpreadConstraints: gcScheduling.TopologySpreadConstraints,
 				Containers: []corev1.Container{
 					{
 						Name:            "nfd-gc",
-						Image:           nfdInstance.Spec.Operand.ImagePath(),
-						ImagePullPolicy: corev1.PullAlways,
+						Image:           nfdInstance.Spec.Operand.ImagePath(d.operandImage),
+						ImagePullPolicy: nfdInstance.Spec.Operand.GetImagePullPolicy(),
 						Command: []string{
 							"nfd-gc",
 						},
 						Env:             getEnvs(),
+						Resources:       nfdInstance.Spec.Operand.GetGCResources(),
 						SecurityContext: getGCSecurityContext(),
 						LivenessProbe:   getLivenessProbe(),
 						ReadinessProbe:  getReadinessProbe(),
@@ -133,10 +179,11 @@ func (d *deployment) SetGCDeploymentAsDesired(nfdInstance *nfdv1.NodeFeatureDisc
 			},
 		},
 	}
-	return controllerutil.SetControllerReference(nfdInstance, gcDep, d.scheme)
+	return ownership.SetOwner(nfdInstance, gcDep, d.scheme)
 }
 
-func (d *deployment) DeleteDeployment(ctx context.Context, namespace, name string) error {
+// DeleteDeployment deletes the Deployment, if it exists, and reports whether it existed
+func (d *deployment) DeleteDeployment(ctx context.Context, namespace, name string) (bool, error) {
 	dep := v1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -144,10 +191,10 @@ func (d *deployment) DeleteDeployment(ctx context.Context, namespace, name strin
 		},
 	}
 	err := d.client.Delete(ctx, &dep)
-	if err != nil && client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to delete deployment %s/%s: %w", namespace, name, err)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete deployment %s/%s: %w", namespace, name, err)
 	}
-	return nil
+	return err == nil, nil
 }
 
 func (d *deployment) GetDeployment(ctx context.Context, namespace, name string) (*v1.Deployment, error) {
@@ -170,7 +217,13 @@ func getPodsTolerations(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.Tolera
 		},
 	}
 
-	return append(basicTolerations, nfdInstance.Spec.Operand.MasterTolerations...)
+	return nfdInstance.Spec.Operand.Scheduling.Master.GetTolerations(basicTolerations, nfdInstance.Spec.Operand.MasterTolerations)
+}
+
+// getGCTolerations returns the tolerations of nfd-gc, which has no
+// built-in ones
+func getGCTolerations(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.Toleration {
+	return nfdInstance.Spec.Operand.Scheduling.GC.GetTolerations(nil, nfdInstance.Spec.Operand.GCTolerations)
 }
 
 func getPodsAffinity() *corev1.Affinity {
@@ -206,20 +259,12 @@ func getPodsAffinity() *corev1.Affinity {
 	}
 }
 
-func getImagePullPolicy(nfdInstance *nfdv1.NodeFeatureDiscovery) corev1.PullPolicy {
-	if nfdInstance.Spec.Operand.ImagePullPolicy != "" {
-		return corev1.PullPolicy(nfdInstance.Spec.Operand.ImagePullPolicy)
-	}
-	return corev1.PullAlways
-}
-
 func getArgs(nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
-	port := defaultServicePort
-	if nfdInstance.Spec.Operand.ServicePort != 0 {
-		port = nfdInstance.Spec.Operand.ServicePort
-	}
 	args := make([]string, 0, 4)
-	args = append(args, fmt.Sprintf("--port=%d", port))
+	args = append(args, fmt.Sprintf("--port=%d", nfdInstance.Spec.Operand.GetServicePort()))
+	if nfdInstance.Spec.Instance != "" {
+		args = append(args, fmt.Sprintf("--instance=%s", nfdInstance.Spec.Instance))
+	}
 	if len(nfdInstance.Spec.ExtraLabelNs) != 0 {
 		args = append(args, fmt.Sprintf("--extra-label-ns=%s", strings.Join(nfdInstance.Spec.ExtraLabelNs, ",")))
 	}
@@ -234,10 +279,37 @@ func getArgs(nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
 	if nfdInstance.Spec.EnableTaints {
 		args = append(args, "--enable-taints")
 	}
+	if nfdInstance.Spec.Master.IsHighlyAvailable() {
+		args = append(args, "--enable-leader-election")
+	}
 
 	return args
 }
 
+// getMasterAffinity returns the affinity of nfd-master. With several
+// replicas the pods preferably run on different nodes, unless the
+// affinity given in the CR already sets a pod anti-affinity
+func getMasterAffinity(nfdInstance *nfdv1.NodeFeatureDiscovery) *corev1.Affinity {
+	affinity := nfdInstance.Spec.Operand.Scheduling.Master.GetAffinity(getPodsAffinity())
+	if !nfdInstance.Spec.Master.IsHighlyAvailable() || affinity.PodAntiAffinity != nil {
+		return affinity
+	}
+	affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+			{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": names.Master(nfdInstance)},
+					},
+					TopologyKey: corev1.LabelHostname,
+				},
+			},
+		},
+	}
+	return affinity
+}
+
 func getEnvs() []corev1.EnvVar {
 	return []corev1.EnvVar{
 		{
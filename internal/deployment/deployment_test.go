@@ -27,8 +27,10 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
@@ -41,7 +43,7 @@ var _ = Describe("SetMasterDeploymentAsDesired", func() {
 	)
 
 	BeforeEach(func() {
-		deploymentAPI = NewDeploymentAPI(nil, scheme)
+		deploymentAPI = NewDeploymentAPI(nil, scheme, "")
 	})
 
 	It("good flow, master deployment object populated with correct values", func() {
@@ -75,6 +77,16 @@ var _ = Describe("SetMasterDeploymentAsDesired", func() {
 		Expect(err).To(BeNil())
 		Expect(masterDep).To(BeComparableTo(testMasterDep))
 	})
+
+	It("master runs the operator image when the CR does not set one", func() {
+		deploymentAPI = NewDeploymentAPI(nil, scheme, "operator-image")
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		masterDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetMasterDeploymentAsDesired(&nfdCR, &masterDep)
+		Expect(err).To(BeNil())
+		Expect(masterDep.Spec.Template.Spec.Containers[0].Image).To(Equal("operator-image"))
+	})
 })
 
 var _ = Describe("SetGCDeploymentAsDesired", func() {
@@ -83,7 +95,7 @@ var _ = Describe("SetGCDeploymentAsDesired", func() {
 	)
 
 	BeforeEach(func() {
-		deploymentAPI = NewDeploymentAPI(nil, scheme)
+		deploymentAPI = NewDeploymentAPI(nil, scheme, "")
 	})
 
 	It("good flow, GC deployment object populated with correct values", func() {
@@ -129,7 +141,7 @@ var _ = Describe("DeleteDeployment", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		deploymentAPI = NewDeploymentAPI(clnt, scheme)
+		deploymentAPI = NewDeploymentAPI(clnt, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -145,22 +157,24 @@ var _ = Describe("DeleteDeployment", func() {
 	It("failure to delete deployment from the cluster", func() {
 		clnt.EXPECT().Delete(ctx, expectedDep).Return(fmt.Errorf("some error"))
 
-		err := deploymentAPI.DeleteDeployment(ctx, namespace, name)
+		_, err := deploymentAPI.DeleteDeployment(ctx, namespace, name)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("deployment is not present in the cluster", func() {
 		clnt.EXPECT().Delete(ctx, expectedDep).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
 
-		err := deploymentAPI.DeleteDeployment(ctx, namespace, name)
+		deleted, err := deploymentAPI.DeleteDeployment(ctx, namespace, name)
 		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
 	})
 
 	It("deployment deleted successfully", func() {
 		clnt.EXPECT().Delete(ctx, expectedDep).Return(nil)
 
-		err := deploymentAPI.DeleteDeployment(ctx, namespace, name)
+		deleted, err := deploymentAPI.DeleteDeployment(ctx, namespace, name)
 		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
 	})
 })
 
@@ -174,7 +188,7 @@ var _ = Describe("GetDeployment", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		deploymentAPI = NewDeploymentAPI(clnt, scheme)
+		deploymentAPI = NewDeploymentAPI(clnt, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -276,6 +290,56 @@ var _ = Describe("getPodsTolerations", func() {
 		res := getPodsTolerations(&nfdCR)
 		Expect(res).To(Equal(expectedTolerations))
 	})
+
+	It("tolerations defined in the NFD CR replace the built-in ones", func() {
+		masterTolerations := []corev1.Toleration{
+			{
+				Key:      "key1",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					MasterTolerations: masterTolerations,
+					Scheduling: nfdv1.OperandScheduling{
+						Master: nfdv1.PodScheduling{TolerationsPolicy: nfdv1.TolerationsPolicyReplace},
+					},
+				},
+			},
+		}
+
+		res := getPodsTolerations(&nfdCR)
+		Expect(res).To(Equal(masterTolerations))
+	})
+})
+
+var _ = Describe("getGCTolerations", func() {
+	It("no tolerations defined in the NFD CR", func() {
+		res := getGCTolerations(&nfdv1.NodeFeatureDiscovery{})
+		Expect(res).To(BeEmpty())
+	})
+
+	It("tolerations defined in the NFD CR", func() {
+		gcTolerations := []corev1.Toleration{
+			{
+				Key:      "key1",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					GCTolerations: gcTolerations,
+				},
+			},
+		}
+
+		res := getGCTolerations(&nfdCR)
+		Expect(res).To(Equal(gcTolerations))
+	})
 })
 
 var _ = Describe("getMasterEnvs", func() {
@@ -328,3 +392,268 @@ var _ = Describe("getMasterEnvs", func() {
 		Expect(res).To(Equal(expectedRes))
 	})
 })
+
+var _ = Describe("getArgs", func() {
+	It("default args", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		res := getArgs(&nfdCR)
+		Expect(res).To(Equal([]string{"--port=12000"}))
+	})
+
+	It("instance is passed to nfd-master", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+				Operand: nfdv1.OperandSpec{
+					ServicePort: 12001,
+				},
+			},
+		}
+
+		res := getArgs(&nfdCR)
+		Expect(res).To(Equal([]string{"--port=12001", "--instance=blue"}))
+	})
+
+	It("leader election is enabled for several master replicas", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Master: nfdv1.MasterSpec{Replicas: ptr.To[int32](2)},
+			},
+		}
+
+		res := getArgs(&nfdCR)
+		Expect(res).To(Equal([]string{"--port=12000", "--enable-leader-election"}))
+	})
+})
+
+var _ = Describe("getMasterAffinity", func() {
+	It("single master replica", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		res := getMasterAffinity(&nfdCR)
+		Expect(res).To(Equal(getPodsAffinity()))
+	})
+
+	It("several master replicas are spread across nodes", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+				Master:   nfdv1.MasterSpec{Replicas: ptr.To[int32](3)},
+			},
+		}
+
+		res := getMasterAffinity(&nfdCR)
+		Expect(res.NodeAffinity).To(Equal(getPodsAffinity().NodeAffinity))
+		Expect(res.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(Equal([]corev1.WeightedPodAffinityTerm{
+			{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "nfd-master-blue"},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+		}))
+	})
+
+	It("pod anti-affinity defined in the NFD CR is kept", func() {
+		antiAffinity := corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "nfd-master"},
+					},
+					TopologyKey: "topology.kubernetes.io/zone",
+				},
+			},
+		}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Master: nfdv1.MasterSpec{Replicas: ptr.To[int32](3)},
+				Operand: nfdv1.OperandSpec{
+					Scheduling: nfdv1.OperandScheduling{
+						Master: nfdv1.PodScheduling{
+							Affinity: &corev1.Affinity{PodAntiAffinity: &antiAffinity},
+						},
+					},
+				},
+			},
+		}
+
+		res := getMasterAffinity(&nfdCR)
+		Expect(res).To(Equal(&corev1.Affinity{PodAntiAffinity: &antiAffinity}))
+	})
+})
+
+var _ = Describe("instance scoped deployments", func() {
+	var (
+		deploymentAPI DeploymentAPI
+	)
+
+	BeforeEach(func() {
+		deploymentAPI = NewDeploymentAPI(nil, scheme, "")
+	})
+
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Instance: "blue",
+		},
+	}
+
+	It("master selector and labels contain the instance name", func() {
+		masterDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetMasterDeploymentAsDesired(&nfdCR, &masterDep)
+
+		Expect(err).To(BeNil())
+		Expect(masterDep.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "nfd-master-blue"}))
+		Expect(masterDep.Spec.Template.Labels).To(Equal(map[string]string{"app": "nfd-master-blue"}))
+	})
+
+	It("master reads the configuration of its instance", func() {
+		masterDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetMasterDeploymentAsDesired(&nfdCR, &masterDep)
+
+		Expect(err).To(BeNil())
+		podSpec := masterDep.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(HaveLen(1))
+		Expect(podSpec.Volumes[0].ConfigMap.Name).To(Equal("nfd-master-blue"))
+		Expect(podSpec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+			{Name: "nfd-master-conf", MountPath: "/etc/kubernetes/node-feature-discovery", ReadOnly: true},
+		}))
+	})
+
+	It("gc selector and labels contain the instance name", func() {
+		gcDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetGCDeploymentAsDesired(&nfdCR, &gcDep)
+
+		Expect(err).To(BeNil())
+		Expect(gcDep.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "nfd-gc-blue"}))
+		Expect(gcDep.Spec.Template.Labels).To(Equal(map[string]string{"app": "nfd-gc-blue"}))
+	})
+})
+
+var _ = Describe("operand resources", func() {
+	var (
+		deploymentAPI DeploymentAPI
+	)
+
+	BeforeEach(func() {
+		deploymentAPI = NewDeploymentAPI(nil, scheme, "")
+	})
+
+	masterResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("200m"),
+		},
+	}
+
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Resources: nfdv1.OperandResources{
+					Master: &masterResources,
+					GC:     &corev1.ResourceRequirements{},
+				},
+			},
+		},
+	}
+
+	It("master resources defined in the NFD CR replace the default ones", func() {
+		masterDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetMasterDeploymentAsDesired(&nfdCR, &masterDep)
+
+		Expect(err).To(BeNil())
+		Expect(masterDep.Spec.Template.Spec.Containers[0].Resources).To(Equal(masterResources))
+	})
+
+	It("empty gc resources disable the default ones", func() {
+		gcDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetGCDeploymentAsDesired(&nfdCR, &gcDep)
+
+		Expect(err).To(BeNil())
+		Expect(gcDep.Spec.Template.Spec.Containers[0].Resources).To(Equal(corev1.ResourceRequirements{}))
+	})
+})
+
+var _ = Describe("operand scheduling", func() {
+	var (
+		deploymentAPI DeploymentAPI
+	)
+
+	BeforeEach(func() {
+		deploymentAPI = NewDeploymentAPI(nil, scheme, "")
+	})
+
+	affinity := corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "node-role.kubernetes.io/infra",
+								Operator: corev1.NodeSelectorOpExists,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	spreadConstraints := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		},
+	}
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Scheduling: nfdv1.OperandScheduling{
+					Master: nfdv1.PodScheduling{
+						NodeSelector:              map[string]string{"node-role.kubernetes.io/infra": ""},
+						Affinity:                  &affinity,
+						PriorityClassName:         "system-cluster-critical",
+						TopologySpreadConstraints: spreadConstraints,
+					},
+					GC: nfdv1.PodScheduling{
+						PriorityClassName: "low-priority",
+					},
+				},
+			},
+		},
+	}
+
+	It("master scheduling defined in the NFD CR", func() {
+		masterDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetMasterDeploymentAsDesired(&nfdCR, &masterDep)
+
+		Expect(err).To(BeNil())
+		podSpec := masterDep.Spec.Template.Spec
+		Expect(podSpec.NodeSelector).To(Equal(map[string]string{"node-role.kubernetes.io/infra": ""}))
+		Expect(podSpec.Affinity).To(Equal(&affinity))
+		Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+		Expect(podSpec.TopologySpreadConstraints).To(Equal(spreadConstraints))
+	})
+
+	It("gc scheduling defined in the NFD CR", func() {
+		gcDep := appsv1.Deployment{}
+
+		err := deploymentAPI.SetGCDeploymentAsDesired(&nfdCR, &gcDep)
+
+		Expect(err).To(BeNil())
+		podSpec := gcDep.Spec.Template.Spec
+		Expect(podSpec.PriorityClassName).To(Equal("low-priority"))
+		Expect(podSpec.Affinity).To(BeNil())
+		Expect(podSpec.NodeSelector).To(BeNil())
+	})
+})
@@ -41,11 +41,12 @@ func (m *MockDeploymentAPI) EXPECT() *MockDeploymentAPIMockRecorder {
 }
 
 // DeleteDeployment mocks base method.
-func (m *MockDeploymentAPI) DeleteDeployment(ctx context.Context, namespace, name string) error {
+func (m *MockDeploymentAPI) DeleteDeployment(ctx context.Context, namespace, name string) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteDeployment", ctx, namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteDeployment indicates an expected call of DeleteDeployment.
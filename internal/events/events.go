@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// DefaultAggregationInterval is the interval during which an event
+	// identical to one already recorded is dropped
+	DefaultAggregationInterval = 5 * time.Minute
+
+	// maxTrackedEvents bounds the memory used to remember the recorded
+	// events, the expired ones are forgotten beyond it
+	maxTrackedEvents = 1000
+)
+
+// aggregatingRecorder drops the events identical to one recorded for the
+// same object less than interval ago, so that a reconcile hot loop does not
+// flood the API server. The wrapped recorder still aggregates the events
+// that are let through into a single event with a count
+type aggregatingRecorder struct {
+	recorder record.EventRecorder
+	interval time.Duration
+	now      func() time.Time
+
+	mutex    sync.Mutex
+	recorded map[string]time.Time
+}
+
+func NewAggregatingRecorder(recorder record.EventRecorder, interval time.Duration) record.EventRecorder {
+	return &aggregatingRecorder{
+		recorder: recorder,
+		interval: interval,
+		now:      time.Now,
+		recorded: make(map[string]time.Time),
+	}
+}
+
+func (r *aggregatingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.shouldRecord(object, eventtype, reason, message) {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *aggregatingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *aggregatingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.shouldRecord(object, eventtype, reason, message) {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// shouldRecord remembers when an event is recorded, and reports whether
+// the same event was not recorded during the last interval
+func (r *aggregatingRecorder) shouldRecord(object runtime.Object, eventtype, reason, message string) bool {
+	key := getEventKey(object, eventtype, reason, message)
+	now := r.now()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if last, ok := r.recorded[key]; ok && now.Sub(last) < r.interval {
+		return false
+	}
+	if len(r.recorded) >= maxTrackedEvents {
+		for k, last := range r.recorded {
+			if now.Sub(last) >= r.interval {
+				delete(r.recorded, k)
+			}
+		}
+	}
+	r.recorded[key] = now
+	return true
+}
+
+// getEventKey identifies an event by its object, type, reason and message
+func getEventKey(object runtime.Object, eventtype, reason, message string) string {
+	objectKey := ""
+	if accessor, err := meta.Accessor(object); err == nil {
+		objectKey = fmt.Sprintf("%s/%s/%s", accessor.GetNamespace(), accessor.GetName(), accessor.GetUID())
+	}
+	return fmt.Sprintf("%s|%s|%s|%s", objectKey, eventtype, reason, message)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+var _ = Describe("aggregatingRecorder", func() {
+	var (
+		fakeRecorder *record.FakeRecorder
+		recorder     *aggregatingRecorder
+		now          time.Time
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(10)
+		recorder = NewAggregatingRecorder(fakeRecorder, time.Minute).(*aggregatingRecorder)
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		recorder.now = func() time.Time { return now }
+	})
+
+	nfdCR := &nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd", UID: "uid-1"},
+	}
+	otherCR := &nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "other", UID: "uid-2"},
+	}
+
+	It("identical events are recorded once per interval", func() {
+		recorder.Eventf(nfdCR, corev1.EventTypeWarning, "FailedReconcile", "failed: %s", "some error")
+		recorder.Eventf(nfdCR, corev1.EventTypeWarning, "FailedReconcile", "failed: %s", "some error")
+		now = now.Add(59 * time.Second)
+		recorder.Event(nfdCR, corev1.EventTypeWarning, "FailedReconcile", "failed: some error")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+		Expect(<-fakeRecorder.Events).To(Equal("Warning FailedReconcile failed: some error"))
+
+		now = now.Add(time.Second)
+		recorder.Eventf(nfdCR, corev1.EventTypeWarning, "FailedReconcile", "failed: %s", "some error")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+	})
+
+	It("events that differ are all recorded", func() {
+		recorder.Event(nfdCR, corev1.EventTypeNormal, "Created", "created DaemonSet test-namespace/nfd-worker")
+		recorder.Event(nfdCR, corev1.EventTypeNormal, "Created", "created Deployment test-namespace/nfd-master")
+		recorder.Event(nfdCR, corev1.EventTypeNormal, "Updated", "created Deployment test-namespace/nfd-master")
+		recorder.Event(nfdCR, corev1.EventTypeWarning, "Updated", "created Deployment test-namespace/nfd-master")
+		recorder.Event(otherCR, corev1.EventTypeWarning, "Updated", "created Deployment test-namespace/nfd-master")
+		Expect(fakeRecorder.Events).To(HaveLen(5))
+	})
+
+	It("expired events are forgotten when too many are tracked", func() {
+		for i := 0; i < maxTrackedEvents; i++ {
+			recorder.recorded[string(rune(i))] = now
+		}
+		now = now.Add(time.Minute)
+		recorder.Event(nfdCR, corev1.EventTypeNormal, "Created", "created")
+		Expect(recorder.recorded).To(HaveLen(1))
+	})
+})
@@ -27,9 +27,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
 )
 
 //go:generate mockgen -source=job.go -package=job -destination=mock_job.go JobAPI
@@ -37,17 +38,23 @@ import (
 type JobAPI interface {
 	GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error)
 	CreatePruneJob(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error
+	CreatePruneRequestJob(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, request string) error
+	DeleteJob(ctx context.Context, namespace, name string) (bool, error)
 }
 
 type job struct {
 	client client.Client
 	scheme *runtime.Scheme
+	// operandImage is the image of the operands of the CRs that do not
+	// set one
+	operandImage string
 }
 
-func NewJobAPI(client client.Client, scheme *runtime.Scheme) JobAPI {
+func NewJobAPI(client client.Client, scheme *runtime.Scheme, operandImage string) JobAPI {
 	return &job{
-		client: client,
-		scheme: scheme,
+		client:       client,
+		scheme:       scheme,
+		operandImage: operandImage,
 	}
 }
 
@@ -61,34 +68,82 @@ func (j *job) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job,
 	return pruneJob, nil
 }
 
-func (j *job) CreatePruneJob(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+// DeleteJob deletes the Job and its pods, if it exists, and reports whether
+// it existed
+func (j *job) DeleteJob(ctx context.Context, namespace, name string) (bool, error) {
 	pruneJob := batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "nfd-prune",
-			Namespace: nfdInstance.Namespace,
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err := j.client.Delete(ctx, &pruneJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete job %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
+
+// CreatePruneJob creates the job pruning the NFD labels on deletion of the
+// CR, removed once prunePolicy.ttlAfterFinished expired
+func (j *job) CreatePruneJob(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	pruneJob := j.newPruneJob(nfdInstance, names.Prune(nfdInstance))
+	pruneJob.Spec.TTLSecondsAfterFinished = ptr.To(getTTLSecondsAfterFinished(&nfdInstance.Spec.PrunePolicy))
+	return j.createPruneJob(ctx, nfdInstance, pruneJob)
+}
+
+// CreatePruneRequestJob creates the job of the prune requested by the prune
+// annotation of the CR. It is named after the request, and has no TTL since
+// the operator deletes it once it read its outcome
+func (j *job) CreatePruneRequestJob(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, request string) error {
+	return j.createPruneJob(ctx, nfdInstance, j.newPruneJob(nfdInstance, names.PruneRequest(nfdInstance, request)))
+}
+
+func (j *job) createPruneJob(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pruneJob *batchv1.Job) error {
+	err := ownership.SetOwner(nfdInstance, pruneJob, j.scheme)
+	if err != nil {
+		return fmt.Errorf("failed to set controller reference for prune job: %w", err)
+	}
+
+	return j.client.Create(ctx, pruneJob)
+}
+
+func (j *job) newPruneJob(nfdInstance *nfdv1.NodeFeatureDiscovery, name string) *batchv1.Job {
+	masterScheduling := &nfdInstance.Spec.Operand.Scheduling.Master
+	prunePolicy := &nfdInstance.Spec.PrunePolicy
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: nfdInstance.GetOperandNamespace(),
 			Labels:    map[string]string{"app": "nfd"},
 		},
 		Spec: batchv1.JobSpec{
-			Completions: ptr.To[int32](1),
+			Completions:           ptr.To[int32](1),
+			BackoffLimit:          ptr.To(prunePolicy.GetRetries()),
+			ActiveDeadlineSeconds: ptr.To(getActiveDeadlineSeconds(prunePolicy)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"app": "nfd-prune"},
+					Labels: map[string]string{"app": names.Prune(nfdInstance)},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: "nfd-prune",
-					Affinity:           getPodsAffinity(),
-					RestartPolicy:      corev1.RestartPolicyNever,
-					Tolerations:        getPodsTolerations(),
+					ServiceAccountName:        "nfd-prune",
+					Affinity:                  masterScheduling.GetAffinity(getPodsAffinity()),
+					RestartPolicy:             corev1.RestartPolicyNever,
+					Tolerations:               getPodsTolerations(nfdInstance),
+					NodeSelector:              masterScheduling.NodeSelector,
+					PriorityClassName:         masterScheduling.PriorityClassName,
+					TopologySpreadConstraints: masterScheduling.TopologySpreadConstraints,
 					Containers: []corev1.Container{
 						{
 							Name:            "nfd-prune",
-							Image:           nfdInstance.Spec.Operand.ImagePath(),
-							ImagePullPolicy: corev1.PullAlways,
+							Image:           nfdInstance.Spec.Operand.ImagePath(j.operandImage),
+							ImagePullPolicy: nfdInstance.Spec.Operand.GetImagePullPolicy(),
 							Command: []string{
 								"nfd-master",
 							},
-							Args:            []string{"-prune"},
+							Args:            getPruneArgs(nfdInstance),
 							Env:             getEnvs(),
+							Resources:       nfdInstance.Spec.Operand.GetPruneResources(),
 							SecurityContext: getSecurityContext(),
 						},
 					},
@@ -96,17 +151,51 @@ func (j *job) CreatePruneJob(ctx context.Context, nfdInstance *nfdv1.NodeFeature
 			},
 		},
 	}
+}
 
-	err := controllerutil.SetControllerReference(nfdInstance, &pruneJob, j.scheme)
-	if err != nil {
-		return fmt.Errorf("failed to set controller reference for prune job: %w", err)
+// IsSucceeded reports whether the pod of the job succeeded
+func IsSucceeded(j *batchv1.Job) bool {
+	return j.Status.Succeeded > 0
+}
+
+// GetFailedCondition returns the Failed condition of the job once it failed
+// for good, because its retries are exhausted or its deadline passed, and
+// nil while it is running
+func GetFailedCondition(j *batchv1.Job) *batchv1.JobCondition {
+	for i := range j.Status.Conditions {
+		condition := &j.Status.Conditions[i]
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
 	}
+	return nil
+}
+
+// getActiveDeadlineSeconds returns the deadline of the prune job, at least
+// one second since the API server rejects a deadline of 0
+func getActiveDeadlineSeconds(prunePolicy *nfdv1.PrunePolicy) int64 {
+	return max(int64(prunePolicy.GetTimeout().Seconds()), 1)
+}
 
-	return j.client.Create(ctx, &pruneJob)
+// getTTLSecondsAfterFinished returns how long the finished prune job is
+// kept. A CR stored before the minimum TTL was enforced could remove the job
+// before the operator read its outcome, which would then run it again
+func getTTLSecondsAfterFinished(prunePolicy *nfdv1.PrunePolicy) int32 {
+	return int32(max(prunePolicy.GetTTLAfterFinished(), nfdv1.MinPruneTTLAfterFinished).Seconds())
 }
 
-func getPodsTolerations() []corev1.Toleration {
-	return []corev1.Toleration{
+func getPruneArgs(nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
+	args := []string{"--prune"}
+	if nfdInstance.Spec.Instance != "" {
+		args = append(args, fmt.Sprintf("--instance=%s", nfdInstance.Spec.Instance))
+	}
+	return args
+}
+
+// getPodsTolerations returns the tolerations of the prune job, which
+// follows the scheduling of nfd-master
+func getPodsTolerations(nfdInstance *nfdv1.NodeFeatureDiscovery) []corev1.Toleration {
+	basicTolerations := []corev1.Toleration{
 		{
 			Key:      "node-role.kubernetes.io/master",
 			Operator: corev1.TolerationOpEqual,
@@ -118,6 +207,8 @@ func getPodsTolerations() []corev1.Toleration {
 			Effect:   corev1.TaintEffectNoSchedule,
 		},
 	}
+
+	return nfdInstance.Spec.Operand.Scheduling.Master.GetTolerations(basicTolerations, nfdInstance.Spec.Operand.MasterTolerations)
 }
 
 func getPodsAffinity() *corev1.Affinity {
@@ -20,13 +20,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
 	"sigs.k8s.io/yaml"
@@ -42,7 +48,7 @@ var _ = Describe("GetJob", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		jobAPI = NewJobAPI(clnt, scheme)
+		jobAPI = NewJobAPI(clnt, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -63,6 +69,55 @@ var _ = Describe("GetJob", func() {
 	})
 })
 
+var _ = Describe("DeleteJob", func() {
+	var (
+		ctrl   *gomock.Controller
+		clnt   *client.MockClient
+		jobAPI JobAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		jobAPI = NewJobAPI(clnt, scheme, "")
+	})
+
+	ctx := context.Background()
+	name := "nfd-prune"
+	namespace := "test-namespace"
+
+	expectedJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	It("failure to delete job from the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedJob, ctrlclient.PropagationPolicy(metav1.DeletePropagationBackground)).Return(fmt.Errorf("some error"))
+
+		_, err := jobAPI.DeleteJob(ctx, namespace, name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("job is not present in the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedJob, ctrlclient.PropagationPolicy(metav1.DeletePropagationBackground)).
+			Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		deleted, err := jobAPI.DeleteJob(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("job and its pods deleted successfully", func() {
+		clnt.EXPECT().Delete(ctx, expectedJob, ctrlclient.PropagationPolicy(metav1.DeletePropagationBackground)).Return(nil)
+
+		deleted, err := jobAPI.DeleteJob(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
+
 var _ = Describe("CreatePruneJob", func() {
 	var (
 		ctrl   *gomock.Controller
@@ -73,7 +128,7 @@ var _ = Describe("CreatePruneJob", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		jobAPI = NewJobAPI(clnt, scheme)
+		jobAPI = NewJobAPI(clnt, scheme, "")
 	})
 
 	ctx := context.Background()
@@ -104,4 +159,194 @@ var _ = Describe("CreatePruneJob", func() {
 		err = jobAPI.CreatePruneJob(ctx, &nfdCR)
 		Expect(err).To(BeNil())
 	})
+
+	It("prune policy sets the retries, the deadline and the TTL of the job", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-namespace",
+				Name:      "nfd",
+			},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				PrunePolicy: nfdv1.PrunePolicy{
+					Timeout:          &metav1.Duration{Duration: 2 * time.Minute},
+					Retries:          ptr.To[int32](0),
+					TTLAfterFinished: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		}
+
+		clnt.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, pruneJob *batchv1.Job, _ ...ctrlclient.CreateOption) error {
+				Expect(pruneJob.Spec.BackoffLimit).To(Equal(ptr.To[int32](0)))
+				Expect(pruneJob.Spec.ActiveDeadlineSeconds).To(Equal(ptr.To[int64](120)))
+				Expect(pruneJob.Spec.TTLSecondsAfterFinished).To(Equal(ptr.To[int32](300)))
+				return nil
+			},
+		)
+
+		err := jobAPI.CreatePruneJob(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("deadline the API server would reject and TTL removing the job too early are clamped", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-namespace",
+				Name:      "nfd",
+			},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				PrunePolicy: nfdv1.PrunePolicy{
+					Timeout:          &metav1.Duration{},
+					TTLAfterFinished: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+		}
+
+		clnt.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, pruneJob *batchv1.Job, _ ...ctrlclient.CreateOption) error {
+				Expect(pruneJob.Spec.ActiveDeadlineSeconds).To(Equal(ptr.To[int64](1)))
+				Expect(pruneJob.Spec.TTLSecondsAfterFinished).To(Equal(ptr.To[int32](60)))
+				return nil
+			},
+		)
+
+		err := jobAPI.CreatePruneJob(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("prune job is created in the operand namespace with the owner labels", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-namespace",
+				Name:      "nfd",
+			},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					Namespace: "nfd-operands",
+				},
+			},
+		}
+
+		clnt.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, pruneJob *batchv1.Job, _ ...ctrlclient.CreateOption) error {
+				Expect(pruneJob.Namespace).To(Equal("nfd-operands"))
+				Expect(pruneJob.OwnerReferences).To(BeEmpty())
+				Expect(pruneJob.Labels).To(Equal(map[string]string{
+					"app":                               "nfd",
+					"nfd.kubernetes.io/owner-namespace": "test-namespace",
+					"nfd.kubernetes.io/owner-name":      "nfd",
+				}))
+				return nil
+			},
+		)
+
+		err := jobAPI.CreatePruneJob(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("requested prune job is named after the request and has no TTL", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-namespace",
+				Name:      "nfd",
+			},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				PrunePolicy: nfdv1.PrunePolicy{
+					TTLAfterFinished: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		}
+
+		clnt.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, pruneJob *batchv1.Job, _ ...ctrlclient.CreateOption) error {
+				Expect(pruneJob.Name).To(Equal("nfd-prune-6b86b273ff"))
+				Expect(pruneJob.Namespace).To(Equal("test-namespace"))
+				Expect(pruneJob.Spec.TTLSecondsAfterFinished).To(BeNil())
+				return nil
+			},
+		)
+
+		err := jobAPI.CreatePruneRequestJob(ctx, &nfdCR, "1")
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("GetFailedCondition", func() {
+	It("job is still running after a pod failure", func() {
+		j := batchv1.Job{
+			Status: batchv1.JobStatus{Failed: 1},
+		}
+		Expect(GetFailedCondition(&j)).To(BeNil())
+	})
+
+	It("job failed for good", func() {
+		failed := batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}
+		j := batchv1.Job{
+			Status: batchv1.JobStatus{
+				Failed:     4,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobSuspended, Status: corev1.ConditionFalse}, failed},
+			},
+		}
+		Expect(GetFailedCondition(&j)).To(Equal(&failed))
+	})
+})
+
+var _ = Describe("getPruneArgs", func() {
+	It("instance is not defined in NFD CR", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		res := getPruneArgs(&nfdCR)
+		Expect(res).To(Equal([]string{"--prune"}))
+	})
+
+	It("instance is defined in NFD CR", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+			},
+		}
+
+		res := getPruneArgs(&nfdCR)
+		Expect(res).To(Equal([]string{"--prune", "--instance=blue"}))
+	})
+})
+
+var _ = Describe("getPodsTolerations", func() {
+	masterTolerations := []corev1.Toleration{
+		{
+			Key:      "key1",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}
+
+	It("prune job follows the master tolerations", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					MasterTolerations: masterTolerations,
+				},
+			},
+		}
+
+		res := getPodsTolerations(&nfdCR)
+		Expect(res).To(HaveLen(3))
+		Expect(res[2]).To(Equal(masterTolerations[0]))
+	})
+
+	It("master tolerations replace the built-in ones", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{
+					MasterTolerations: masterTolerations,
+					Scheduling: nfdv1.OperandScheduling{
+						Master: nfdv1.PodScheduling{TolerationsPolicy: nfdv1.TolerationsPolicyReplace},
+					},
+				},
+			},
+		}
+
+		res := getPodsTolerations(&nfdCR)
+		Expect(res).To(Equal(masterTolerations))
+	})
 })
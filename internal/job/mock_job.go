@@ -54,6 +54,35 @@ func (mr *MockJobAPIMockRecorder) CreatePruneJob(ctx, nfdInstance any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePruneJob", reflect.TypeOf((*MockJobAPI)(nil).CreatePruneJob), ctx, nfdInstance)
 }
 
+// CreatePruneRequestJob mocks base method.
+func (m *MockJobAPI) CreatePruneRequestJob(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, request string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePruneRequestJob", ctx, nfdInstance, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePruneRequestJob indicates an expected call of CreatePruneRequestJob.
+func (mr *MockJobAPIMockRecorder) CreatePruneRequestJob(ctx, nfdInstance, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePruneRequestJob", reflect.TypeOf((*MockJobAPI)(nil).CreatePruneRequestJob), ctx, nfdInstance, request)
+}
+
+// DeleteJob mocks base method.
+func (m *MockJobAPI) DeleteJob(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJob", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJob indicates an expected call of DeleteJob.
+func (mr *MockJobAPIMockRecorder) DeleteJob(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJob", reflect.TypeOf((*MockJobAPI)(nil).DeleteJob), ctx, namespace, name)
+}
+
 // GetJob mocks base method.
 func (m *MockJobAPI) GetJob(ctx context.Context, namespace, name string) (*v1.Job, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+)
+
+const (
+	metricsNamespace = "nfd_operator"
+
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// conditionTypes are the conditions of the NFD CR exported as a gauge
+var conditionTypes = []string{"Available", "Degraded", "Progressing"}
+
+//go:generate mockgen -source=metrics.go -package=metrics -destination=mock_metrics.go MetricsAPI
+
+type MetricsAPI interface {
+	ObserveReconcile(nfdInstance *nfdv1.NodeFeatureDiscovery, component string, err error)
+	SetComponentsStatus(nfdInstance *nfdv1.NodeFeatureDiscovery, components []nfdv1.ComponentStatus)
+	SetConditions(nfdInstance *nfdv1.NodeFeatureDiscovery, conditions []metav1.Condition)
+	ObservePruneJob(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneJob *batchv1.Job)
+	DeleteInstance(nfdInstance *nfdv1.NodeFeatureDiscovery)
+}
+
+type metrics struct {
+	reconcileTotal   *prometheus.CounterVec
+	desiredPods      *prometheus.GaugeVec
+	readyPods        *prometheus.GaugeVec
+	operandInfo      *prometheus.GaugeVec
+	condition        *prometheus.GaugeVec
+	pruneJobDuration *prometheus.HistogramVec
+	now              func() time.Time
+
+	mutex sync.Mutex
+	// images holds the image of every component exported for each NFD CR,
+	// so that the series of a component no longer enabled, or of an image
+	// no longer run, are deleted
+	images map[types.NamespacedName]map[string]string
+	// pruneJobs holds the UID of the prune job observed for each NFD CR,
+	// since the job is checked on every reconcile until the CR is deleted
+	pruneJobs map[types.NamespacedName]types.UID
+}
+
+// NewMetricsAPI creates the operator metrics and registers them with
+// registerer, usually the registry of controller-runtime served by the
+// metrics server of the manager
+func NewMetricsAPI(registerer prometheus.Registerer) MetricsAPI {
+	m := &metrics{
+		reconcileTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_total",
+			Help:      "Number of reconciliations of a component of the NFD CR, by result.",
+		}, []string{"namespace", "name", "component", "result"}),
+		desiredPods: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_desired_pods",
+			Help:      "Number of pods a component of the NFD CR should run.",
+		}, []string{"namespace", "name", "component"}),
+		readyPods: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_ready_pods",
+			Help:      "Number of ready pods of a component of the NFD CR.",
+		}, []string{"namespace", "name", "component"}),
+		operandInfo: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "operand_info",
+			Help:      "Image run by a component of the NFD CR, the value is always 1.",
+		}, []string{"namespace", "name", "component", "image"}),
+		condition: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "condition",
+			Help:      "Whether a condition of the NFD CR is True (1) or not (0).",
+		}, []string{"namespace", "name", "type"}),
+		pruneJobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "prune_job_duration_seconds",
+			Help:      "Duration of the prune jobs run on deletion of an NFD CR, by result.",
+			Buckets:   prometheus.ExponentialBuckets(5, 2, 8),
+		}, []string{"result"}),
+		now:       time.Now,
+		images:    make(map[types.NamespacedName]map[string]string),
+		pruneJobs: make(map[types.NamespacedName]types.UID),
+	}
+	registerer.MustRegister(m.reconcileTotal, m.desiredPods, m.readyPods, m.operandInfo, m.condition, m.pruneJobDuration)
+	return m
+}
+
+// ObserveReconcile counts the reconciliation of a component, which failed
+// if err is not nil
+func (m *metrics) ObserveReconcile(nfdInstance *nfdv1.NodeFeatureDiscovery, component string, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+	m.reconcileTotal.WithLabelValues(nfdInstance.Namespace, nfdInstance.Name, component, result).Inc()
+}
+
+// SetComponentsStatus exports the pods and the image of the components of
+// the NFD CR, and deletes the series of the components no longer listed
+func (m *metrics) SetComponentsStatus(nfdInstance *nfdv1.NodeFeatureDiscovery, components []nfdv1.ComponentStatus) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := types.NamespacedName{Namespace: nfdInstance.Namespace, Name: nfdInstance.Name}
+	prevImages := m.images[key]
+	images := make(map[string]string, len(components))
+	for _, component := range components {
+		m.desiredPods.WithLabelValues(nfdInstance.Namespace, nfdInstance.Name, component.Name).Set(float64(component.Desired))
+		m.readyPods.WithLabelValues(nfdInstance.Namespace, nfdInstance.Name, component.Name).Set(float64(component.Ready))
+		prevImage, found := prevImages[component.Name]
+		if found && prevImage != component.Image {
+			m.operandInfo.DeleteLabelValues(nfdInstance.Namespace, nfdInstance.Name, component.Name, prevImage)
+		}
+		if component.Image != "" {
+			m.operandInfo.WithLabelValues(nfdInstance.Namespace, nfdInstance.Name, component.Name, component.Image).Set(1)
+		}
+		images[component.Name] = component.Image
+	}
+	for name, image := range prevImages {
+		if _, found := images[name]; !found {
+			m.desiredPods.DeleteLabelValues(nfdInstance.Namespace, nfdInstance.Name, name)
+			m.readyPods.DeleteLabelValues(nfdInstance.Namespace, nfdInstance.Name, name)
+			m.operandInfo.DeleteLabelValues(nfdInstance.Namespace, nfdInstance.Name, name, image)
+		}
+	}
+	m.images[key] = images
+}
+
+// SetConditions exports the Available, Degraded and Progressing conditions
+// of the NFD CR
+func (m *metrics) SetConditions(nfdInstance *nfdv1.NodeFeatureDiscovery, conditions []metav1.Condition) {
+	for _, conditionType := range conditionTypes {
+		value := 0.0
+		for _, condition := range conditions {
+			if condition.Type == conditionType && condition.Status == metav1.ConditionTrue {
+				value = 1
+			}
+		}
+		m.condition.WithLabelValues(nfdInstance.Namespace, nfdInstance.Name, conditionType).Set(value)
+	}
+}
+
+// ObservePruneJob records the duration and the result of the prune job of
+// the NFD CR once it has succeeded or failed for good. A job still retrying
+// a failed pod is not observed yet, and a job is observed only once
+func (m *metrics) ObservePruneJob(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneJob *batchv1.Job) {
+	if pruneJob.Status.StartTime == nil {
+		return
+	}
+	result := ""
+	var finishTime time.Time
+	if job.IsSucceeded(pruneJob) {
+		result = resultSuccess
+		finishTime = m.now()
+		if pruneJob.Status.CompletionTime != nil {
+			finishTime = pruneJob.Status.CompletionTime.Time
+		}
+	} else if failedCondition := job.GetFailedCondition(pruneJob); failedCondition != nil {
+		result = resultFailure
+		finishTime = failedCondition.LastTransitionTime.Time
+	} else {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := types.NamespacedName{Namespace: nfdInstance.Namespace, Name: nfdInstance.Name}
+	if m.pruneJobs[key] == pruneJob.UID {
+		return
+	}
+	m.pruneJobs[key] = pruneJob.UID
+	m.pruneJobDuration.WithLabelValues(result).Observe(finishTime.Sub(pruneJob.Status.StartTime.Time).Seconds())
+}
+
+// DeleteInstance deletes the series of a deleted NFD CR
+func (m *metrics) DeleteInstance(nfdInstance *nfdv1.NodeFeatureDiscovery) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	labels := prometheus.Labels{"namespace": nfdInstance.Namespace, "name": nfdInstance.Name}
+	m.reconcileTotal.DeletePartialMatch(labels)
+	m.desiredPods.DeletePartialMatch(labels)
+	m.readyPods.DeletePartialMatch(labels)
+	m.operandInfo.DeletePartialMatch(labels)
+	m.condition.DeletePartialMatch(labels)
+
+	key := types.NamespacedName{Namespace: nfdInstance.Namespace, Name: nfdInstance.Name}
+	delete(m.images, key)
+	delete(m.pruneJobs, key)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+var _ = Describe("ObserveReconcile", func() {
+	var (
+		registry *prometheus.Registry
+		m        MetricsAPI
+	)
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		m = NewMetricsAPI(registry)
+	})
+
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+	}
+
+	It("reconciliations are counted by component and result", func() {
+		m.ObserveReconcile(&nfdCR, "master", nil)
+		m.ObserveReconcile(&nfdCR, "master", nil)
+		m.ObserveReconcile(&nfdCR, "worker", fmt.Errorf("some error"))
+
+		expected := `
+# HELP nfd_operator_reconcile_total Number of reconciliations of a component of the NFD CR, by result.
+# TYPE nfd_operator_reconcile_total counter
+nfd_operator_reconcile_total{component="master",name="nfd-instance",namespace="test-namespace",result="success"} 2
+nfd_operator_reconcile_total{component="worker",name="nfd-instance",namespace="test-namespace",result="failure"} 1
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "nfd_operator_reconcile_total")
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("SetComponentsStatus", func() {
+	var (
+		registry *prometheus.Registry
+		m        MetricsAPI
+	)
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		m = NewMetricsAPI(registry)
+	})
+
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+	}
+
+	It("pods and image of every component are exported", func() {
+		m.SetComponentsStatus(&nfdCR, []nfdv1.ComponentStatus{
+			{Name: "master", Desired: 2, Ready: 1, Image: "nfd:v0.15.0"},
+			{Name: "worker", Desired: 3, Ready: 3, Image: "nfd:v0.15.0"},
+		})
+
+		expected := `
+# HELP nfd_operator_component_desired_pods Number of pods a component of the NFD CR should run.
+# TYPE nfd_operator_component_desired_pods gauge
+nfd_operator_component_desired_pods{component="master",name="nfd-instance",namespace="test-namespace"} 2
+nfd_operator_component_desired_pods{component="worker",name="nfd-instance",namespace="test-namespace"} 3
+# HELP nfd_operator_component_ready_pods Number of ready pods of a component of the NFD CR.
+# TYPE nfd_operator_component_ready_pods gauge
+nfd_operator_component_ready_pods{component="master",name="nfd-instance",namespace="test-namespace"} 1
+nfd_operator_component_ready_pods{component="worker",name="nfd-instance",namespace="test-namespace"} 3
+# HELP nfd_operator_operand_info Image run by a component of the NFD CR, the value is always 1.
+# TYPE nfd_operator_operand_info gauge
+nfd_operator_operand_info{component="master",image="nfd:v0.15.0",name="nfd-instance",namespace="test-namespace"} 1
+nfd_operator_operand_info{component="worker",image="nfd:v0.15.0",name="nfd-instance",namespace="test-namespace"} 1
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"nfd_operator_component_desired_pods", "nfd_operator_component_ready_pods", "nfd_operator_operand_info")
+		Expect(err).To(BeNil())
+	})
+
+	It("series of a new image and of a removed component are replaced", func() {
+		m.SetComponentsStatus(&nfdCR, []nfdv1.ComponentStatus{
+			{Name: "master", Desired: 1, Ready: 1, Image: "nfd:v0.15.0"},
+			{Name: "gc", Desired: 1, Ready: 1, Image: "nfd:v0.15.0"},
+		})
+		m.SetComponentsStatus(&nfdCR, []nfdv1.ComponentStatus{
+			{Name: "master", Desired: 1, Ready: 0, Image: "nfd:v0.16.0"},
+		})
+
+		expected := `
+# HELP nfd_operator_component_ready_pods Number of ready pods of a component of the NFD CR.
+# TYPE nfd_operator_component_ready_pods gauge
+nfd_operator_component_ready_pods{component="master",name="nfd-instance",namespace="test-namespace"} 0
+# HELP nfd_operator_operand_info Image run by a component of the NFD CR, the value is always 1.
+# TYPE nfd_operator_operand_info gauge
+nfd_operator_operand_info{component="master",image="nfd:v0.16.0",name="nfd-instance",namespace="test-namespace"} 1
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"nfd_operator_component_ready_pods", "nfd_operator_operand_info")
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("SetConditions", func() {
+	It("Available, Degraded and Progressing are exported", func() {
+		registry := prometheus.NewRegistry()
+		m := NewMetricsAPI(registry)
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+		}
+
+		m.SetConditions(&nfdCR, []metav1.Condition{
+			{Type: "Available", Status: metav1.ConditionFalse},
+			{Type: "Degraded", Status: metav1.ConditionTrue},
+			{Type: "Upgradeable", Status: metav1.ConditionTrue},
+		})
+
+		expected := `
+# HELP nfd_operator_condition Whether a condition of the NFD CR is True (1) or not (0).
+# TYPE nfd_operator_condition gauge
+nfd_operator_condition{name="nfd-instance",namespace="test-namespace",type="Available"} 0
+nfd_operator_condition{name="nfd-instance",namespace="test-namespace",type="Degraded"} 1
+nfd_operator_condition{name="nfd-instance",namespace="test-namespace",type="Progressing"} 0
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "nfd_operator_condition")
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("ObservePruneJob", func() {
+	var (
+		registry *prometheus.Registry
+		m        MetricsAPI
+	)
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		m = NewMetricsAPI(registry)
+	})
+
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+	}
+	startTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	It("running job is not observed", func() {
+		pruneJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{UID: "job-uid"},
+			Status:     batchv1.JobStatus{StartTime: &startTime, Active: 1},
+		}
+
+		m.ObservePruneJob(&nfdCR, &pruneJob)
+
+		Expect(testutil.CollectAndCount(m.(*metrics).pruneJobDuration)).To(Equal(0))
+	})
+
+	It("succeeded job is observed once", func() {
+		completionTime := metav1.NewTime(startTime.Add(30 * time.Second))
+		pruneJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{UID: "job-uid"},
+			Status:     batchv1.JobStatus{StartTime: &startTime, CompletionTime: &completionTime, Succeeded: 1},
+		}
+
+		m.ObservePruneJob(&nfdCR, &pruneJob)
+		m.ObservePruneJob(&nfdCR, &pruneJob)
+
+		expected := `
+# HELP nfd_operator_prune_job_duration_seconds Duration of the prune jobs run on deletion of an NFD CR, by result.
+# TYPE nfd_operator_prune_job_duration_seconds histogram
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="5"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="10"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="20"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="40"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="80"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="160"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="320"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="640"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="+Inf"} 1
+nfd_operator_prune_job_duration_seconds_sum{result="success"} 30
+nfd_operator_prune_job_duration_seconds_count{result="success"} 1
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "nfd_operator_prune_job_duration_seconds")
+		Expect(err).To(BeNil())
+	})
+
+	It("failed job is observed until the time it failed", func() {
+		failedTime := metav1.NewTime(startTime.Add(2 * time.Minute))
+		pruneJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{UID: "job-uid"},
+			Status: batchv1.JobStatus{
+				StartTime: &startTime,
+				Failed:    1,
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: failedTime},
+				},
+			},
+		}
+
+		m.ObservePruneJob(&nfdCR, &pruneJob)
+
+		expected := `
+# HELP nfd_operator_prune_job_duration_seconds Duration of the prune jobs run on deletion of an NFD CR, by result.
+# TYPE nfd_operator_prune_job_duration_seconds histogram
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="5"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="10"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="20"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="40"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="80"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="160"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="320"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="640"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="failure",le="+Inf"} 1
+nfd_operator_prune_job_duration_seconds_sum{result="failure"} 120
+nfd_operator_prune_job_duration_seconds_count{result="failure"} 1
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "nfd_operator_prune_job_duration_seconds")
+		Expect(err).To(BeNil())
+	})
+
+	It("failed pod, then succeeded job is observed as a success", func() {
+		pruneJob := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{UID: "job-uid"},
+			Status:     batchv1.JobStatus{StartTime: &startTime, Active: 1, Failed: 1},
+		}
+
+		m.ObservePruneJob(&nfdCR, &pruneJob)
+		Expect(testutil.CollectAndCount(m.(*metrics).pruneJobDuration)).To(Equal(0))
+
+		completionTime := metav1.NewTime(startTime.Add(30 * time.Second))
+		pruneJob.Status = batchv1.JobStatus{StartTime: &startTime, CompletionTime: &completionTime, Failed: 1, Succeeded: 1}
+		m.ObservePruneJob(&nfdCR, &pruneJob)
+
+		expected := `
+# HELP nfd_operator_prune_job_duration_seconds Duration of the prune jobs run on deletion of an NFD CR, by result.
+# TYPE nfd_operator_prune_job_duration_seconds histogram
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="5"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="10"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="20"} 0
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="40"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="80"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="160"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="320"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="640"} 1
+nfd_operator_prune_job_duration_seconds_bucket{result="success",le="+Inf"} 1
+nfd_operator_prune_job_duration_seconds_sum{result="success"} 30
+nfd_operator_prune_job_duration_seconds_count{result="success"} 1
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "nfd_operator_prune_job_duration_seconds")
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("DeleteInstance", func() {
+	It("series of the deleted CR are deleted, the other ones are kept", func() {
+		registry := prometheus.NewRegistry()
+		m := NewMetricsAPI(registry)
+		deletedCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "deleted"},
+		}
+		keptCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "kept"},
+		}
+		for _, nfdCR := range []*nfdv1.NodeFeatureDiscovery{&deletedCR, &keptCR} {
+			m.ObserveReconcile(nfdCR, "master", nil)
+			m.SetComponentsStatus(nfdCR, []nfdv1.ComponentStatus{{Name: "master", Desired: 1, Ready: 1, Image: "nfd:v0.15.0"}})
+			m.SetConditions(nfdCR, nil)
+		}
+
+		m.DeleteInstance(&deletedCR)
+
+		count, err := testutil.GatherAndCount(registry)
+		Expect(err).To(BeNil())
+		// reconcile, desired pods, ready pods, operand image and 3 conditions
+		Expect(count).To(Equal(7))
+	})
+})
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=metrics.go -package=metrics -destination=mock_metrics.go MetricsAPI
+//
+// Package metrics is a generated GoMock package.
+package metrics
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/batch/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v11 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockMetricsAPI is a mock of MetricsAPI interface.
+type MockMetricsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsAPIMockRecorder
+}
+
+// MockMetricsAPIMockRecorder is the mock recorder for MockMetricsAPI.
+type MockMetricsAPIMockRecorder struct {
+	mock *MockMetricsAPI
+}
+
+// NewMockMetricsAPI creates a new mock instance.
+func NewMockMetricsAPI(ctrl *gomock.Controller) *MockMetricsAPI {
+	mock := &MockMetricsAPI{ctrl: ctrl}
+	mock.recorder = &MockMetricsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsAPI) EXPECT() *MockMetricsAPIMockRecorder {
+	return m.recorder
+}
+
+// DeleteInstance mocks base method.
+func (m *MockMetricsAPI) DeleteInstance(nfdInstance *v11.NodeFeatureDiscovery) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteInstance", nfdInstance)
+}
+
+// DeleteInstance indicates an expected call of DeleteInstance.
+func (mr *MockMetricsAPIMockRecorder) DeleteInstance(nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstance", reflect.TypeOf((*MockMetricsAPI)(nil).DeleteInstance), nfdInstance)
+}
+
+// ObservePruneJob mocks base method.
+func (m *MockMetricsAPI) ObservePruneJob(nfdInstance *v11.NodeFeatureDiscovery, pruneJob *v1.Job) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObservePruneJob", nfdInstance, pruneJob)
+}
+
+// ObservePruneJob indicates an expected call of ObservePruneJob.
+func (mr *MockMetricsAPIMockRecorder) ObservePruneJob(nfdInstance, pruneJob any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObservePruneJob", reflect.TypeOf((*MockMetricsAPI)(nil).ObservePruneJob), nfdInstance, pruneJob)
+}
+
+// ObserveReconcile mocks base method.
+func (m *MockMetricsAPI) ObserveReconcile(nfdInstance *v11.NodeFeatureDiscovery, component string, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveReconcile", nfdInstance, component, err)
+}
+
+// ObserveReconcile indicates an expected call of ObserveReconcile.
+func (mr *MockMetricsAPIMockRecorder) ObserveReconcile(nfdInstance, component, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveReconcile", reflect.TypeOf((*MockMetricsAPI)(nil).ObserveReconcile), nfdInstance, component, err)
+}
+
+// SetComponentsStatus mocks base method.
+func (m *MockMetricsAPI) SetComponentsStatus(nfdInstance *v11.NodeFeatureDiscovery, components []v11.ComponentStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetComponentsStatus", nfdInstance, components)
+}
+
+// SetComponentsStatus indicates an expected call of SetComponentsStatus.
+func (mr *MockMetricsAPIMockRecorder) SetComponentsStatus(nfdInstance, components any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetComponentsStatus", reflect.TypeOf((*MockMetricsAPI)(nil).SetComponentsStatus), nfdInstance, components)
+}
+
+// SetConditions mocks base method.
+func (m *MockMetricsAPI) SetConditions(nfdInstance *v11.NodeFeatureDiscovery, conditions []v10.Condition) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConditions", nfdInstance, conditions)
+}
+
+// SetConditions indicates an expected call of SetConditions.
+func (mr *MockMetricsAPIMockRecorder) SetConditions(nfdInstance, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConditions", reflect.TypeOf((*MockMetricsAPI)(nil).SetConditions), nfdInstance, conditions)
+}
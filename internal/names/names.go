@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+const (
+	masterName          = "nfd-master"
+	workerName          = "nfd-worker"
+	gcName              = "nfd-gc"
+	topologyUpdaterName = "nfd-topology-updater"
+	pruneName           = "nfd-prune"
+	prometheusRuleName  = "nfd-alerts"
+	snapshotName        = "nfd-snapshot"
+
+	// WorkerPoolLabel is set on the DaemonSet and ConfigMap of a worker
+	// pool, its value is the name of the pool
+	WorkerPoolLabel = "nfd.kubernetes.io/worker-pool"
+
+	// MetricsLabel is set on the metrics Services and ServiceMonitors, its
+	// value is the name of the Deployment or DaemonSet they expose
+	MetricsLabel = "nfd.kubernetes.io/metrics"
+)
+
+// Master returns the name of the nfd-master Deployment of the NFD instance
+func Master(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	return forInstance(nfdInstance, masterName)
+}
+
+// Worker returns the name of the nfd-worker DaemonSet and ConfigMap of the NFD instance
+func Worker(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	return forInstance(nfdInstance, workerName)
+}
+
+// WorkerPool returns the name of the nfd-worker DaemonSet and ConfigMap of
+// a worker pool of the NFD instance. The pool name is appended with a double
+// hyphen, which instance names cannot contain, so that the pool "b" of the
+// instance "a" does not collide with the default worker of the instance "a-b"
+func WorkerPool(nfdInstance *nfdv1.NodeFeatureDiscovery, poolName string) string {
+	return Worker(nfdInstance) + "--" + poolName
+}
+
+// GC returns the name of the nfd-gc Deployment of the NFD instance
+func GC(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	return forInstance(nfdInstance, gcName)
+}
+
+// TopologyUpdater returns the name of the nfd-topology-updater DaemonSet and ConfigMap of the NFD instance
+func TopologyUpdater(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	return forInstance(nfdInstance, topologyUpdaterName)
+}
+
+// Prune returns the name of the nfd-prune Job of the NFD instance
+func Prune(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	return forInstance(nfdInstance, pruneName)
+}
+
+// PruneRequest returns the name of the nfd-prune Job run for the prune
+// annotation of the NFD instance. It is suffixed with a hash of the request,
+// so that it is never mistaken for the Job pruning on deletion
+func PruneRequest(nfdInstance *nfdv1.NodeFeatureDiscovery, request string) string {
+	return Prune(nfdInstance) + "-" + hashSuffix(request)
+}
+
+// Metrics returns the name of the metrics Service and ServiceMonitor of an
+// operand Deployment or DaemonSet
+func Metrics(operandName string) string {
+	return operandName + "-metrics"
+}
+
+// PrometheusRule returns the name of the PrometheusRule alerting on the
+// health of the operands of the NFD instance
+func PrometheusRule(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	return forInstance(nfdInstance, prometheusRuleName)
+}
+
+// Snapshot returns the name of the ConfigMap holding the snapshot of the NFD
+// labels taken before the nfd-prune Job of the NFD instance runs. Each prune
+// gets its own snapshot, suffixed with a hash of the prune ID
+func Snapshot(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneID string) string {
+	return forInstance(nfdInstance, snapshotName) + "-" + hashSuffix(pruneID)
+}
+
+// hashSuffix returns the first 10 hexadecimal digits of the hash of s
+func hashSuffix(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:10]
+}
+
+// OperandClusterRoleBinding returns the name of the ClusterRoleBinding
+// granting an operand ClusterRole to its ServiceAccount in the operand
+// namespace of the NFD instance
+func OperandClusterRoleBinding(nfdInstance *nfdv1.NodeFeatureDiscovery, clusterRole string) string {
+	return clusterRole + "-" + nfdInstance.GetOperandNamespace()
+}
+
+// forInstance suffixes the component name with the instance name, so that
+// several NFD CRs can be deployed side by side in the same namespace. If the
+// instance is not set, the plain component name is used, which keeps the
+// names of the objects created before instances were supported.
+func forInstance(nfdInstance *nfdv1.NodeFeatureDiscovery, component string) string {
+	if nfdInstance.Spec.Instance == "" {
+		return component
+	}
+	return component + "-" + nfdInstance.Spec.Instance
+}
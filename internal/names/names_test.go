@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+var _ = Describe("component names", func() {
+	DescribeTable("names are scoped to the NFD instance", func(instance, master, worker, gc, topology, prune string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: instance,
+			},
+		}
+
+		Expect(Master(&nfdCR)).To(Equal(master))
+		Expect(Worker(&nfdCR)).To(Equal(worker))
+		Expect(GC(&nfdCR)).To(Equal(gc))
+		Expect(TopologyUpdater(&nfdCR)).To(Equal(topology))
+		Expect(Prune(&nfdCR)).To(Equal(prune))
+	},
+		Entry("instance not set", "", "nfd-master", "nfd-worker", "nfd-gc", "nfd-topology-updater", "nfd-prune"),
+		Entry("instance set", "blue", "nfd-master-blue", "nfd-worker-blue", "nfd-gc-blue", "nfd-topology-updater-blue", "nfd-prune-blue"),
+	)
+})
+
+var _ = Describe("WorkerPool", func() {
+	DescribeTable("pool names are scoped to the NFD instance", func(instance, expected string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: instance,
+			},
+		}
+
+		Expect(WorkerPool(&nfdCR, "gpu")).To(Equal(expected))
+	},
+		Entry("instance not set", "", "nfd-worker--gpu"),
+		Entry("instance set", "blue", "nfd-worker-blue--gpu"),
+	)
+})
+
+var _ = Describe("Metrics", func() {
+	It("metrics objects are named after the operand they expose", func() {
+		Expect(Metrics("nfd-worker-blue--gpu")).To(Equal("nfd-worker-blue--gpu-metrics"))
+	})
+})
+
+var _ = Describe("PrometheusRule", func() {
+	DescribeTable("the prometheusrule name is scoped to the NFD instance", func(instance, expected string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: instance,
+			},
+		}
+
+		Expect(PrometheusRule(&nfdCR)).To(Equal(expected))
+	},
+		Entry("instance not set", "", "nfd-alerts"),
+		Entry("instance set", "blue", "nfd-alerts-blue"),
+	)
+})
+
+var _ = Describe("Snapshot", func() {
+	DescribeTable("the snapshot name is scoped to the NFD instance and the prune", func(instance, pruneID, expected string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: instance,
+			},
+		}
+
+		Expect(Snapshot(&nfdCR, pruneID)).To(Equal(expected))
+	},
+		Entry("instance not set", "", "1234", "nfd-snapshot-03ac674216"),
+		Entry("instance set", "blue", "1234", "nfd-snapshot-blue-03ac674216"),
+		Entry("prune on demand", "", "1234/1", "nfd-snapshot-831d71b17c"),
+		Entry("another prune on demand", "", "1234/2", "nfd-snapshot-8960f35afb"),
+	)
+})
+
+var _ = Describe("PruneRequest", func() {
+	DescribeTable("the requested prune job is named after the NFD instance and the request", func(instance, request, expected string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: instance,
+			},
+		}
+
+		Expect(PruneRequest(&nfdCR, request)).To(Equal(expected))
+	},
+		Entry("instance not set", "", "1", "nfd-prune-6b86b273ff"),
+		Entry("instance set", "blue", "1", "nfd-prune-blue-6b86b273ff"),
+		Entry("another request", "", "2", "nfd-prune-d4735e3a26"),
+	)
+})
+
+var _ = Describe("OperandClusterRoleBinding", func() {
+	It("the clusterrolebinding name is scoped to the operand namespace", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-blue"},
+			},
+		}
+
+		Expect(OperandClusterRoleBinding(&nfdCR, "nfd-master")).To(Equal("nfd-master-nfd-blue"))
+	})
+})
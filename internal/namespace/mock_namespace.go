@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: namespace.go
+//
+// Generated by this command:
+//
+//	mockgen -source=namespace.go -package=namespace -destination=mock_namespace.go NamespaceAPI
+//
+// Package namespace is a generated GoMock package.
+package namespace
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockNamespaceAPI is a mock of NamespaceAPI interface.
+type MockNamespaceAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNamespaceAPIMockRecorder
+}
+
+// MockNamespaceAPIMockRecorder is the mock recorder for MockNamespaceAPI.
+type MockNamespaceAPIMockRecorder struct {
+	mock *MockNamespaceAPI
+}
+
+// NewMockNamespaceAPI creates a new mock instance.
+func NewMockNamespaceAPI(ctrl *gomock.Controller) *MockNamespaceAPI {
+	mock := &MockNamespaceAPI{ctrl: ctrl}
+	mock.recorder = &MockNamespaceAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNamespaceAPI) EXPECT() *MockNamespaceAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateNamespaceIfNotExists mocks base method.
+func (m *MockNamespaceAPI) CreateNamespaceIfNotExists(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespaceIfNotExists", ctx, nfdInstance, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNamespaceIfNotExists indicates an expected call of CreateNamespaceIfNotExists.
+func (mr *MockNamespaceAPIMockRecorder) CreateNamespaceIfNotExists(ctx, nfdInstance, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespaceIfNotExists", reflect.TypeOf((*MockNamespaceAPI)(nil).CreateNamespaceIfNotExists), ctx, nfdInstance, name)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+)
+
+// the operands mount host paths and the worker runs privileged, so the
+// namespace enforces the privileged Pod Security level
+var podSecurityLabels = map[string]string{
+	"pod-security.kubernetes.io/enforce": "privileged",
+	"pod-security.kubernetes.io/audit":   "privileged",
+	"pod-security.kubernetes.io/warn":    "privileged",
+}
+
+//go:generate mockgen -source=namespace.go -package=namespace -destination=mock_namespace.go NamespaceAPI
+
+type NamespaceAPI interface {
+	CreateNamespaceIfNotExists(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, name string) (bool, error)
+}
+
+type namespace struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewNamespaceAPI(client client.Client, scheme *runtime.Scheme) NamespaceAPI {
+	return &namespace{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// CreateNamespaceIfNotExists creates the operand namespace with the
+// privileged Pod Security labels, and reports whether it was created. An
+// existing namespace is left as is, and the namespace is never deleted by
+// the operator, since it may hold objects that are not its own
+func (n *namespace) CreateNamespaceIfNotExists(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, name string) (bool, error) {
+	ns := corev1.Namespace{}
+	err := n.client.Get(ctx, client.ObjectKey{Name: name}, &ns)
+	if err == nil {
+		return false, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+
+	labels := make(map[string]string, len(podSecurityLabels)+2)
+	for key, value := range podSecurityLabels {
+		labels[key] = value
+	}
+	labels[ownership.OwnerNamespaceLabel] = nfdInstance.Namespace
+	labels[ownership.OwnerNameLabel] = nfdInstance.Name
+	ns = corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+	err = n.client.Create(ctx, &ns)
+	if err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// ParseWatchNamespaces parses a comma-separated list of the namespaces the
+// operator watches. Blanks and duplicates are dropped, and an empty list
+// means that all the namespaces are watched
+func ParseWatchNamespaces(value string) []string {
+	var namespaces []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		namespaces = append(namespaces, name)
+	}
+	return namespaces
+}
+
+// IsWatched reports whether a namespace is part of the watched namespaces,
+// an empty list meaning all of them
+func IsWatched(watchNamespaces []string, name string) bool {
+	if len(watchNamespaces) == 0 {
+		return true
+	}
+	for _, watched := range watchNamespaces {
+		if watched == name {
+			return true
+		}
+	}
+	return false
+}
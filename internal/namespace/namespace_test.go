@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespace
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+)
+
+var _ = Describe("CreateNamespaceIfNotExists", func() {
+	var (
+		ctrl  *gomock.Controller
+		clnt  *client.MockClient
+		nsAPI NamespaceAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		nsAPI = NewNamespaceAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	key := ctrlclient.ObjectKey{Name: "nfd-operands"}
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+	}
+
+	It("existing namespace is left as is", func() {
+		clnt.EXPECT().Get(ctx, key, gomock.Any()).Return(nil)
+
+		created, err := nsAPI.CreateNamespaceIfNotExists(ctx, &nfdCR, key.Name)
+		Expect(err).To(BeNil())
+		Expect(created).To(BeFalse())
+	})
+
+	It("missing namespace is created with the privileged Pod Security labels", func() {
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, key, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, key.Name)),
+			clnt.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, ns *corev1.Namespace, _ ...ctrlclient.CreateOption) error {
+					Expect(ns.Name).To(Equal(key.Name))
+					Expect(ns.Labels).To(Equal(map[string]string{
+						"pod-security.kubernetes.io/enforce": "privileged",
+						"pod-security.kubernetes.io/audit":   "privileged",
+						"pod-security.kubernetes.io/warn":    "privileged",
+						"nfd.kubernetes.io/owner-namespace":  "test-namespace",
+						"nfd.kubernetes.io/owner-name":       "nfd-cr",
+					}))
+					return nil
+				}),
+		)
+
+		created, err := nsAPI.CreateNamespaceIfNotExists(ctx, &nfdCR, key.Name)
+		Expect(err).To(BeNil())
+		Expect(created).To(BeTrue())
+	})
+
+	It("namespace created concurrently is not reported as created", func() {
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, key, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, key.Name)),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(apierrors.NewAlreadyExists(schema.GroupResource{}, key.Name)),
+		)
+
+		created, err := nsAPI.CreateNamespaceIfNotExists(ctx, &nfdCR, key.Name)
+		Expect(err).To(BeNil())
+		Expect(created).To(BeFalse())
+	})
+
+	It("failure to get the namespace", func() {
+		clnt.EXPECT().Get(ctx, key, gomock.Any()).Return(errors.New("some error"))
+
+		_, err := nsAPI.CreateNamespaceIfNotExists(ctx, &nfdCR, key.Name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("failure to create the namespace", func() {
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, key, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, key.Name)),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("some error")),
+		)
+
+		_, err := nsAPI.CreateNamespaceIfNotExists(ctx, &nfdCR, key.Name)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = DescribeTable("ParseWatchNamespaces", func(value string, expected []string) {
+	Expect(ParseWatchNamespaces(value)).To(Equal(expected))
+},
+	Entry("empty value watches all namespaces", "", nil),
+	Entry("blank entries are dropped", " , ,", nil),
+	Entry("single namespace", "nfd", []string{"nfd"}),
+	Entry("list of namespaces", "nfd, team-a ,team-b", []string{"nfd", "team-a", "team-b"}),
+	Entry("duplicates are dropped", "nfd,team-a,nfd", []string{"nfd", "team-a"}),
+)
+
+var _ = DescribeTable("IsWatched", func(watchNamespaces []string, name string, expected bool) {
+	Expect(IsWatched(watchNamespaces, name)).To(Equal(expected))
+},
+	Entry("all namespaces are watched", nil, "nfd", true),
+	Entry("namespace in the list", []string{"team-a", "nfd"}, "nfd", true),
+	Entry("namespace not in the list", []string{"team-a", "team-b"}, "nfd", false),
+)
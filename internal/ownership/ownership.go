@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+const (
+	// OwnerNamespaceLabel is set on the operand objects deployed outside of
+	// the namespace of the NFD CR, its value is the namespace of the CR
+	OwnerNamespaceLabel = "nfd.kubernetes.io/owner-namespace"
+
+	// OwnerNameLabel is set on the operand objects deployed outside of the
+	// namespace of the NFD CR, its value is the name of the CR
+	OwnerNameLabel = "nfd.kubernetes.io/owner-name"
+)
+
+// SetOwner makes the NFD CR the owner of an operand object. Owner references
+// cannot cross namespaces, so the objects of a CR deploying its operands to
+// another namespace are labelled with the CR instead, and are deleted by the
+// finalizer of the CR rather than by the garbage collector
+func SetOwner(nfdInstance *nfdv1.NodeFeatureDiscovery, obj client.Object, scheme *runtime.Scheme) error {
+	if nfdInstance.GetOperandNamespace() == nfdInstance.Namespace {
+		return controllerutil.SetControllerReference(nfdInstance, obj, scheme)
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 2)
+	}
+	labels[OwnerNamespaceLabel] = nfdInstance.Namespace
+	labels[OwnerNameLabel] = nfdInstance.Name
+	obj.SetLabels(labels)
+	return nil
+}
+
+// GetOwner returns the NFD CR an object is labelled with, if any
+func GetOwner(obj metav1.Object) (types.NamespacedName, bool) {
+	labels := obj.GetLabels()
+	namespace, hasNamespace := labels[OwnerNamespaceLabel]
+	name, hasName := labels[OwnerNameLabel]
+	if !hasNamespace || !hasName {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// IsOwnedBy reports whether an object is owned by the NFD CR, either through
+// its controller reference or through the owner labels
+func IsOwnedBy(obj metav1.Object, nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
+	if metav1.IsControlledBy(obj, nfdInstance) {
+		return true
+	}
+	owner, ok := GetOwner(obj)
+	return ok && owner == types.NamespacedName{Namespace: nfdInstance.Namespace, Name: nfdInstance.Name}
+}
+
+// IsOwnedByLabels reports whether an object is labelled with an NFD CR
+func IsOwnedByLabels(obj metav1.Object) bool {
+	_, ok := GetOwner(obj)
+	return ok
+}
+
+// EnqueueRequestForOwner returns an event handler reconciling the NFD CR an
+// object is labelled with, the counterpart of handler.EnqueueRequestForOwner
+// for the objects deployed outside of the namespace of the CR
+func EnqueueRequestForOwner() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		owner, ok := GetOwner(obj)
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: owner}}
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+var _ = Describe("SetOwner", func() {
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace", UID: "nfd-uid"},
+	}
+
+	It("object in the namespace of the CR gets a controller reference", func() {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "test-namespace"},
+		}
+
+		err := SetOwner(&nfdCR, &cm, scheme)
+		Expect(err).To(BeNil())
+		Expect(metav1.IsControlledBy(&cm, &nfdCR)).To(BeTrue())
+		Expect(cm.Labels).To(BeEmpty())
+		Expect(IsOwnedBy(&cm, &nfdCR)).To(BeTrue())
+	})
+
+	It("object in another namespace gets the owner labels", func() {
+		operandCR := nfdCR.DeepCopy()
+		operandCR.Spec.Operand.Namespace = "nfd-operands"
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-worker",
+				Namespace: "nfd-operands",
+				Labels:    map[string]string{"app": "nfd"},
+			},
+		}
+
+		err := SetOwner(operandCR, &cm, scheme)
+		Expect(err).To(BeNil())
+		Expect(cm.OwnerReferences).To(BeEmpty())
+		Expect(cm.Labels).To(Equal(map[string]string{
+			"app":                               "nfd",
+			"nfd.kubernetes.io/owner-namespace": "test-namespace",
+			"nfd.kubernetes.io/owner-name":      "nfd-cr",
+		}))
+		Expect(IsOwnedByLabels(&cm)).To(BeTrue())
+		Expect(IsOwnedBy(&cm, operandCR)).To(BeTrue())
+	})
+})
+
+var _ = Describe("IsOwnedBy", func() {
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace", UID: "nfd-uid"},
+	}
+
+	DescribeTable("objects labelled with another CR are not owned", func(labels map[string]string, expected bool) {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "nfd-operands", Labels: labels},
+		}
+
+		Expect(IsOwnedBy(&cm, &nfdCR)).To(Equal(expected))
+	},
+		Entry("no owner labels", nil, false),
+		Entry("owner name only", map[string]string{OwnerNameLabel: "nfd-cr"}, false),
+		Entry("CR of another namespace", map[string]string{OwnerNamespaceLabel: "other-namespace", OwnerNameLabel: "nfd-cr"}, false),
+		Entry("another CR", map[string]string{OwnerNamespaceLabel: "test-namespace", OwnerNameLabel: "other-cr"}, false),
+		Entry("the CR", map[string]string{OwnerNamespaceLabel: "test-namespace", OwnerNameLabel: "nfd-cr"}, true),
+	)
+})
+
+var _ = Describe("EnqueueRequestForOwner", func() {
+	ctx := context.Background()
+
+	It("labelled object enqueues its owner", func() {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-worker",
+				Namespace: "nfd-operands",
+				Labels:    map[string]string{OwnerNamespaceLabel: "test-namespace", OwnerNameLabel: "nfd-cr"},
+			},
+		}
+
+		EnqueueRequestForOwner().Delete(ctx, event.DeleteEvent{Object: &cm}, queue)
+		Expect(queue.Len()).To(Equal(1))
+		item, _ := queue.Get()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "test-namespace", Name: "nfd-cr"}}))
+	})
+
+	It("object without owner labels is ignored", func() {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "nfd-operands"},
+		}
+
+		EnqueueRequestForOwner().Delete(ctx, event.DeleteEvent{Object: &cm}, queue)
+		Expect(queue.Len()).To(Equal(0))
+	})
+})
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pod.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pod.go -package=pod -destination=mock_pod.go PodAPI
+//
+// Package pod is a generated GoMock package.
+package pod
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MockPodAPI is a mock of PodAPI interface.
+type MockPodAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPodAPIMockRecorder
+}
+
+// MockPodAPIMockRecorder is the mock recorder for MockPodAPI.
+type MockPodAPIMockRecorder struct {
+	mock *MockPodAPI
+}
+
+// NewMockPodAPI creates a new mock instance.
+func NewMockPodAPI(ctrl *gomock.Controller) *MockPodAPI {
+	mock := &MockPodAPI{ctrl: ctrl}
+	mock.recorder = &MockPodAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPodAPI) EXPECT() *MockPodAPIMockRecorder {
+	return m.recorder
+}
+
+// ListPods mocks base method.
+func (m *MockPodAPI) ListPods(ctx context.Context, namespace string, selector *v10.LabelSelector) ([]v1.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPods", ctx, namespace, selector)
+	ret0, _ := ret[0].([]v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPods indicates an expected call of ListPods.
+func (mr *MockPodAPIMockRecorder) ListPods(ctx, namespace, selector any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPods", reflect.TypeOf((*MockPodAPI)(nil).ListPods), ctx, namespace, selector)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//go:generate mockgen -source=pod.go -package=pod -destination=mock_pod.go PodAPI
+
+type PodAPI interface {
+	ListPods(ctx context.Context, namespace string, selector *metav1.LabelSelector) ([]corev1.Pod, error)
+}
+
+type pod struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewPodAPI(client client.Client, scheme *runtime.Scheme) PodAPI {
+	return &pod{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// ListPods returns the pods matched by the selector of a Deployment or of a
+// DaemonSet. A nil or empty selector does not match any pod, instead of
+// matching all the pods of the namespace
+func (p *pod) ListPods(ctx context.Context, namespace string, selector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the pod selector: %w", err)
+	}
+	if labelSelector.Empty() {
+		return nil, nil
+	}
+	podList := corev1.PodList{}
+	err = p.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+	return podList.Items, nil
+}
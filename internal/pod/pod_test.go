@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+)
+
+var _ = Describe("ListPods", func() {
+	var (
+		ctrl   *gomock.Controller
+		clnt   *client.MockClient
+		podAPI PodAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		podAPI = NewPodAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	namespace := "test-namespace"
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nfd-worker"}}
+
+	It("nil or empty selector does not list any pod", func() {
+		pods, err := podAPI.ListPods(ctx, namespace, nil)
+		Expect(err).To(BeNil())
+		Expect(pods).To(BeEmpty())
+
+		pods, err = podAPI.ListPods(ctx, namespace, &metav1.LabelSelector{})
+		Expect(err).To(BeNil())
+		Expect(pods).To(BeEmpty())
+	})
+
+	It("invalid selector", func() {
+		invalidSelector := &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "bad"}},
+		}
+
+		_, err := podAPI.ListPods(ctx, namespace, invalidSelector)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("failure to list pods", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := podAPI.ListPods(ctx, namespace, selector)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("pods of the selector are returned", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, list *corev1.PodList, opts ...ctrlclient.ListOption) error {
+				listOpts := ctrlclient.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				Expect(listOpts.Namespace).To(Equal(namespace))
+				Expect(listOpts.LabelSelector.Matches(labels.Set{"app": "nfd-worker"})).To(BeTrue())
+				Expect(listOpts.LabelSelector.Matches(labels.Set{"app": "nfd-master"})).To(BeFalse())
+				list.Items = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker-abcde"}}}
+				return nil
+			})
+
+		pods, err := podAPI.ListPods(ctx, namespace, selector)
+		Expect(err).To(BeNil())
+		Expect(pods).To(HaveLen(1))
+		Expect(pods[0].Name).To(Equal("nfd-worker-abcde"))
+	})
+})
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: poddisruptionbudget.go
+//
+// Generated by this command:
+//
+//	mockgen -source=poddisruptionbudget.go -package=poddisruptionbudget -destination=mock_poddisruptionbudget.go PodDisruptionBudgetAPI
+//
+// Package poddisruptionbudget is a generated GoMock package.
+package poddisruptionbudget
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/policy/v1"
+	v10 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockPodDisruptionBudgetAPI is a mock of PodDisruptionBudgetAPI interface.
+type MockPodDisruptionBudgetAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPodDisruptionBudgetAPIMockRecorder
+}
+
+// MockPodDisruptionBudgetAPIMockRecorder is the mock recorder for MockPodDisruptionBudgetAPI.
+type MockPodDisruptionBudgetAPIMockRecorder struct {
+	mock *MockPodDisruptionBudgetAPI
+}
+
+// NewMockPodDisruptionBudgetAPI creates a new mock instance.
+func NewMockPodDisruptionBudgetAPI(ctrl *gomock.Controller) *MockPodDisruptionBudgetAPI {
+	mock := &MockPodDisruptionBudgetAPI{ctrl: ctrl}
+	mock.recorder = &MockPodDisruptionBudgetAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPodDisruptionBudgetAPI) EXPECT() *MockPodDisruptionBudgetAPIMockRecorder {
+	return m.recorder
+}
+
+// DeletePodDisruptionBudget mocks base method.
+func (m *MockPodDisruptionBudgetAPI) DeletePodDisruptionBudget(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePodDisruptionBudget", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePodDisruptionBudget indicates an expected call of DeletePodDisruptionBudget.
+func (mr *MockPodDisruptionBudgetAPIMockRecorder) DeletePodDisruptionBudget(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePodDisruptionBudget", reflect.TypeOf((*MockPodDisruptionBudgetAPI)(nil).DeletePodDisruptionBudget), ctx, namespace, name)
+}
+
+// SetMasterPodDisruptionBudgetAsDesired mocks base method.
+func (m *MockPodDisruptionBudgetAPI) SetMasterPodDisruptionBudgetAsDesired(nfdInstance *v10.NodeFeatureDiscovery, masterPDB *v1.PodDisruptionBudget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMasterPodDisruptionBudgetAsDesired", nfdInstance, masterPDB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMasterPodDisruptionBudgetAsDesired indicates an expected call of SetMasterPodDisruptionBudgetAsDesired.
+func (mr *MockPodDisruptionBudgetAPIMockRecorder) SetMasterPodDisruptionBudgetAsDesired(nfdInstance, masterPDB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterPodDisruptionBudgetAsDesired", reflect.TypeOf((*MockPodDisruptionBudgetAPI)(nil).SetMasterPodDisruptionBudgetAsDesired), nfdInstance, masterPDB)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poddisruptionbudget
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+)
+
+//go:generate mockgen -source=poddisruptionbudget.go -package=poddisruptionbudget -destination=mock_poddisruptionbudget.go PodDisruptionBudgetAPI
+
+type PodDisruptionBudgetAPI interface {
+	SetMasterPodDisruptionBudgetAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, masterPDB *policyv1.PodDisruptionBudget) error
+	DeletePodDisruptionBudget(ctx context.Context, namespace, name string) (bool, error)
+}
+
+type podDisruptionBudget struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewPodDisruptionBudgetAPI(client client.Client, scheme *runtime.Scheme) PodDisruptionBudgetAPI {
+	return &podDisruptionBudget{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// SetMasterPodDisruptionBudgetAsDesired lets node drains evict one
+// nfd-master pod at a time, so that the label updates are never stopped
+func (p *podDisruptionBudget) SetMasterPodDisruptionBudgetAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, masterPDB *policyv1.PodDisruptionBudget) error {
+	masterPDB.ObjectMeta.Labels = map[string]string{"app": "nfd"}
+	masterPDB.Spec = policyv1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": names.Master(nfdInstance)},
+		},
+	}
+	return ownership.SetOwner(nfdInstance, masterPDB, p.scheme)
+}
+
+// DeletePodDisruptionBudget deletes the PodDisruptionBudget, if it exists, and reports whether it existed
+func (p *podDisruptionBudget) DeletePodDisruptionBudget(ctx context.Context, namespace, name string) (bool, error) {
+	pdb := policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err := p.client.Delete(ctx, &pdb)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete poddisruptionbudget %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
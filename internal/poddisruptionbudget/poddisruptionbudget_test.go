@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poddisruptionbudget
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("SetMasterPodDisruptionBudgetAsDesired", func() {
+	var (
+		pdbAPI PodDisruptionBudgetAPI
+	)
+
+	BeforeEach(func() {
+		pdbAPI = NewPodDisruptionBudgetAPI(nil, scheme)
+	})
+
+	It("good flow, master pdb object populated with correct values", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		masterPDB := policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-master",
+				Namespace: "test-namespace",
+			},
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "PodDisruptionBudget",
+				APIVersion: "policy/v1",
+			},
+		}
+
+		err := pdbAPI.SetMasterPodDisruptionBudgetAsDesired(&nfdCR, &masterPDB)
+
+		Expect(err).To(BeNil())
+		expectedYAMLFile, err := os.ReadFile("testdata/test_master_pdb.yaml")
+		Expect(err).To(BeNil())
+		expectedJSON, err := yaml.YAMLToJSON(expectedYAMLFile)
+		Expect(err).To(BeNil())
+		testMasterPDB := policyv1.PodDisruptionBudget{}
+		err = yaml.Unmarshal(expectedJSON, &testMasterPDB)
+		Expect(err).To(BeNil())
+		Expect(masterPDB).To(BeComparableTo(testMasterPDB))
+	})
+
+	It("selector contains the instance name", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+			},
+		}
+		masterPDB := policyv1.PodDisruptionBudget{}
+
+		err := pdbAPI.SetMasterPodDisruptionBudgetAsDesired(&nfdCR, &masterPDB)
+
+		Expect(err).To(BeNil())
+		Expect(masterPDB.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "nfd-master-blue"}))
+	})
+})
+
+var _ = Describe("DeletePodDisruptionBudget", func() {
+	var (
+		ctrl   *gomock.Controller
+		clnt   *client.MockClient
+		pdbAPI PodDisruptionBudgetAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		pdbAPI = NewPodDisruptionBudgetAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	name := "pdb-name"
+	namespace := "pdb-namespace"
+	expectedPDB := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	It("failure to delete pdb from the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedPDB).Return(fmt.Errorf("some error"))
+
+		_, err := pdbAPI.DeletePodDisruptionBudget(ctx, namespace, name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("pdb is not present in the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedPDB).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		deleted, err := pdbAPI.DeletePodDisruptionBudget(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("pdb deleted successfully", func() {
+		clnt.EXPECT().Delete(ctx, expectedPDB).Return(nil)
+
+		deleted, err := pdbAPI.DeletePodDisruptionBudget(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: prometheusrule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=prometheusrule.go -package=prometheusrule -destination=mock_prometheusrule.go PrometheusRuleAPI
+//
+// Package prometheusrule is a generated GoMock package.
+package prometheusrule
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	v1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockPrometheusRuleAPI is a mock of PrometheusRuleAPI interface.
+type MockPrometheusRuleAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrometheusRuleAPIMockRecorder
+}
+
+// MockPrometheusRuleAPIMockRecorder is the mock recorder for MockPrometheusRuleAPI.
+type MockPrometheusRuleAPIMockRecorder struct {
+	mock *MockPrometheusRuleAPI
+}
+
+// NewMockPrometheusRuleAPI creates a new mock instance.
+func NewMockPrometheusRuleAPI(ctrl *gomock.Controller) *MockPrometheusRuleAPI {
+	mock := &MockPrometheusRuleAPI{ctrl: ctrl}
+	mock.recorder = &MockPrometheusRuleAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrometheusRuleAPI) EXPECT() *MockPrometheusRuleAPIMockRecorder {
+	return m.recorder
+}
+
+// DeletePrometheusRule mocks base method.
+func (m *MockPrometheusRuleAPI) DeletePrometheusRule(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrometheusRule", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrometheusRule indicates an expected call of DeletePrometheusRule.
+func (mr *MockPrometheusRuleAPIMockRecorder) DeletePrometheusRule(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrometheusRule", reflect.TypeOf((*MockPrometheusRuleAPI)(nil).DeletePrometheusRule), ctx, namespace, name)
+}
+
+// IsPrometheusRuleSupported mocks base method.
+func (m *MockPrometheusRuleAPI) IsPrometheusRuleSupported() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrometheusRuleSupported")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPrometheusRuleSupported indicates an expected call of IsPrometheusRuleSupported.
+func (mr *MockPrometheusRuleAPIMockRecorder) IsPrometheusRuleSupported() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrometheusRuleSupported", reflect.TypeOf((*MockPrometheusRuleAPI)(nil).IsPrometheusRuleSupported))
+}
+
+// SetPrometheusRuleAsDesired mocks base method.
+func (m *MockPrometheusRuleAPI) SetPrometheusRuleAsDesired(nfdInstance *v1.NodeFeatureDiscovery, rule *unstructured.Unstructured) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrometheusRuleAsDesired", nfdInstance, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrometheusRuleAsDesired indicates an expected call of SetPrometheusRuleAsDesired.
+func (mr *MockPrometheusRuleAPIMockRecorder) SetPrometheusRuleAsDesired(nfdInstance, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrometheusRuleAsDesired", reflect.TypeOf((*MockPrometheusRuleAPI)(nil).SetPrometheusRuleAsDesired), nfdInstance, rule)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrule
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+)
+
+// GroupVersionKind of the PrometheusRules of the Prometheus operator. They
+// are handled as unstructured objects, so that the operator does not depend
+// on the Prometheus operator being installed
+var GroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+//go:generate mockgen -source=prometheusrule.go -package=prometheusrule -destination=mock_prometheusrule.go PrometheusRuleAPI
+
+type PrometheusRuleAPI interface {
+	IsPrometheusRuleSupported() (bool, error)
+	SetPrometheusRuleAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, rule *unstructured.Unstructured) error
+	DeletePrometheusRule(ctx context.Context, namespace, name string) (bool, error)
+}
+
+type prometheusRule struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewPrometheusRuleAPI(client client.Client, scheme *runtime.Scheme) PrometheusRuleAPI {
+	return &prometheusRule{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// NewPrometheusRule returns an empty PrometheusRule with the given name
+func NewPrometheusRule(namespace, name string) *unstructured.Unstructured {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(GroupVersionKind)
+	rule.SetNamespace(namespace)
+	rule.SetName(name)
+	return rule
+}
+
+// IsSupported reports whether the PrometheusRule CRD is installed in the
+// cluster known to mapper
+func IsSupported(mapper meta.RESTMapper) (bool, error) {
+	_, err := mapper.RESTMapping(GroupVersionKind.GroupKind(), GroupVersionKind.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the REST mapping of %s: %w", GroupVersionKind, err)
+	}
+	return true, nil
+}
+
+// IsPrometheusRuleSupported reports whether the PrometheusRule CRD is
+// installed. It is checked on every call, so that the PrometheusRule is
+// created once the Prometheus operator is installed
+func (p *prometheusRule) IsPrometheusRuleSupported() (bool, error) {
+	return IsSupported(p.client.RESTMapper())
+}
+
+// alert is a rule of the PrometheusRule, tuned by an AlertSpec of the CR
+type alert struct {
+	name            string
+	spec            *nfdv1.AlertSpec
+	defaultFor      time.Duration
+	defaultSeverity nfdv1.AlertSeverity
+	expr            string
+	summary         string
+	description     string
+}
+
+// SetPrometheusRuleAsDesired sets the alerts on the health of the operands
+// deployed for the NFD CR
+func (p *prometheusRule) SetPrometheusRuleAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, rule *unstructured.Unstructured) error {
+	labels := map[string]string{}
+	if nfdInstance.Spec.Metrics != nil {
+		maps.Copy(labels, nfdInstance.Spec.Metrics.Labels)
+	}
+	labels["app"] = "nfd"
+	rule.SetLabels(labels)
+
+	rules := []interface{}{}
+	for _, a := range getAlerts(nfdInstance) {
+		if a.spec.Disabled {
+			continue
+		}
+		rules = append(rules, getRule(a))
+	}
+	rule.Object["spec"] = map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  names.PrometheusRule(nfdInstance),
+				"rules": rules,
+			},
+		},
+	}
+	return ownership.SetOwner(nfdInstance, rule, p.scheme)
+}
+
+func getRule(a alert) map[string]interface{} {
+	rule := map[string]interface{}{
+		"alert": a.name,
+		"expr":  a.expr,
+		"labels": map[string]interface{}{
+			"severity": string(a.spec.GetSeverity(a.defaultSeverity)),
+		},
+		"annotations": map[string]interface{}{
+			"summary":     a.summary,
+			"description": a.description,
+		},
+	}
+	if forDuration := a.spec.GetFor(a.defaultFor); forDuration > 0 {
+		// Prometheus durations have no fractional part
+		rule["for"] = fmt.Sprintf("%ds", int64(forDuration.Seconds()))
+	}
+	return rule
+}
+
+// getAlerts returns the alerts on the components enabled in the CR. The
+// alerts rely on the metrics of the operator, of the operands and of
+// kube-state-metrics, selected by the namespace and the names of the objects
+// of the CR, so that the alerts of several NFD instances do not overlap
+func getAlerts(nfdInstance *nfdv1.NodeFeatureDiscovery) []alert {
+	var spec nfdv1.PrometheusRuleSpec
+	if nfdInstance.Spec.Metrics != nil {
+		spec = nfdInstance.Spec.Metrics.PrometheusRule
+	}
+	namespace := nfdInstance.GetOperandNamespace()
+	cr := fmt.Sprintf("namespace=%q,name=%q", nfdInstance.Namespace, nfdInstance.Name)
+
+	alerts := []alert{
+		{
+			name:            "NFDWorkerUnavailable",
+			spec:            &spec.WorkerUnavailable,
+			defaultFor:      15 * time.Minute,
+			defaultSeverity: nfdv1.AlertSeverityWarning,
+			expr: fmt.Sprintf(`nfd_operator_component_ready_pods{%[1]s,component=~"worker(-.+)?"} < nfd_operator_component_desired_pods{%[1]s,component=~"worker(-.+)?"}`,
+				cr),
+			summary:     "nfd-worker is not running on all the eligible nodes",
+			description: "Component {{ $labels.component }} of NodeFeatureDiscovery {{ $labels.namespace }}/{{ $labels.name }} has fewer ready pods than desired, the features of some nodes are not labeled.",
+		},
+		{
+			name:            "NFDMasterUnavailable",
+			spec:            &spec.MasterUnavailable,
+			defaultFor:      5 * time.Minute,
+			defaultSeverity: nfdv1.AlertSeverityCritical,
+			expr:            fmt.Sprintf(`nfd_operator_component_ready_pods{%s,component="master"} == 0`, cr),
+			summary:         "nfd-master has no available replica",
+			description:     "NodeFeatureDiscovery {{ $labels.namespace }}/{{ $labels.name }} has no ready nfd-master pod, the node labels are not updated.",
+		},
+		{
+			name:            "NFDPruneJobFailed",
+			spec:            &spec.PruneJobFailed,
+			defaultSeverity: nfdv1.AlertSeverityWarning,
+			expr: fmt.Sprintf(`kube_job_failed{namespace=%q,job_name=~%q,condition="true"} == 1`,
+				namespace, names.Prune(nfdInstance)+"(-[0-9a-f]{10})?"),
+			summary:     "nfd-prune job failed",
+			description: "Job {{ $labels.namespace }}/{{ $labels.job_name }} failed to remove the NFD labels from the nodes.",
+		},
+	}
+	if nfdInstance.Spec.GC.IsEnabled() {
+		alerts = append(alerts, alert{
+			name:            "NFDGCCrashLooping",
+			spec:            &spec.GCCrashLooping,
+			defaultFor:      15 * time.Minute,
+			defaultSeverity: nfdv1.AlertSeverityWarning,
+			// the pods of a Deployment are named <deployment>-<hash>-<suffix>
+			expr: fmt.Sprintf(`kube_pod_container_status_waiting_reason{namespace=%q,pod=~%q,reason="CrashLoopBackOff"} == 1`,
+				namespace, regexp.QuoteMeta(names.GC(nfdInstance))+"-[a-z0-9]+-[a-z0-9]+"),
+			summary:     "nfd-gc is crash looping",
+			description: "Pod {{ $labels.namespace }}/{{ $labels.pod }} is in CrashLoopBackOff, the stale NodeFeature objects are not garbage collected.",
+		})
+	}
+	if nfdInstance.Spec.TopologyUpdater.Enabled {
+		alerts = append(alerts, alert{
+			name:            "NFDTopologyUpdaterStale",
+			spec:            &spec.TopologyUpdaterStale,
+			defaultFor:      15 * time.Minute,
+			defaultSeverity: nfdv1.AlertSeverityWarning,
+			expr: fmt.Sprintf(`rate(nfd_topology_updater_scan_errors_total{namespace=%q,service=%q}[5m]) > 0`,
+				namespace, names.Metrics(names.TopologyUpdater(nfdInstance))),
+			summary:     "nfd-topology-updater fails to scan the node resources",
+			description: "Pod {{ $labels.namespace }}/{{ $labels.pod }} fails to scan the resources of its node, the NodeResourceTopology object is stale.",
+		})
+	}
+	return alerts
+}
+
+// DeletePrometheusRule deletes the PrometheusRule, if it exists, and reports whether it existed
+func (p *prometheusRule) DeletePrometheusRule(ctx context.Context, namespace, name string) (bool, error) {
+	err := p.client.Delete(ctx, NewPrometheusRule(namespace, name))
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete prometheusrule %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("IsSupported", func() {
+	It("prometheusrule CRD is installed", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(GroupVersionKind, meta.RESTScopeNamespace)
+
+		supported, err := IsSupported(mapper)
+
+		Expect(err).To(BeNil())
+		Expect(supported).To(BeTrue())
+	})
+
+	It("prometheusrule CRD is not installed", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+
+		supported, err := IsSupported(mapper)
+
+		Expect(err).To(BeNil())
+		Expect(supported).To(BeFalse())
+	})
+})
+
+var _ = Describe("SetPrometheusRuleAsDesired", func() {
+	var (
+		ruleAPI PrometheusRuleAPI
+	)
+
+	BeforeEach(func() {
+		ruleAPI = NewPrometheusRuleAPI(nil, scheme)
+	})
+
+	It("good flow, prometheusrule object populated with correct values", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{
+					Enabled: true,
+				},
+				Metrics: &nfdv1.MetricsSpec{
+					Labels: map[string]string{"release": "prometheus"},
+					PrometheusRule: nfdv1.PrometheusRuleSpec{
+						Enabled: true,
+						WorkerUnavailable: nfdv1.AlertSpec{
+							For:      &metav1.Duration{Duration: 30 * time.Minute},
+							Severity: nfdv1.AlertSeverityCritical,
+						},
+						PruneJobFailed: nfdv1.AlertSpec{
+							Disabled: true,
+						},
+					},
+				},
+			},
+		}
+		rule := NewPrometheusRule("test-namespace", "nfd-alerts-blue")
+
+		err := ruleAPI.SetPrometheusRuleAsDesired(&nfdCR, rule)
+
+		Expect(err).To(BeNil())
+		expectedYAMLFile, err := os.ReadFile("testdata/test_prometheusrule.yaml")
+		Expect(err).To(BeNil())
+		expectedJSON, err := yaml.YAMLToJSON(expectedYAMLFile)
+		Expect(err).To(BeNil())
+		testRule := unstructured.Unstructured{}
+		err = testRule.UnmarshalJSON(expectedJSON)
+		Expect(err).To(BeNil())
+		Expect(rule.Object).To(Equal(testRule.Object))
+	})
+
+	It("alerts of the disabled components are not created", func() {
+		disabled := false
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				GC: nfdv1.GCSpec{
+					Enabled: &disabled,
+				},
+				Metrics: &nfdv1.MetricsSpec{
+					PrometheusRule: nfdv1.PrometheusRuleSpec{
+						Enabled: true,
+					},
+				},
+			},
+		}
+		rule := NewPrometheusRule("test-namespace", "nfd-alerts")
+
+		err := ruleAPI.SetPrometheusRuleAsDesired(&nfdCR, rule)
+
+		Expect(err).To(BeNil())
+		groups, found, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+		Expect(err).To(BeNil())
+		Expect(found).To(BeTrue())
+		rules, found, err := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+		Expect(err).To(BeNil())
+		Expect(found).To(BeTrue())
+		alertNames := []string{}
+		for _, r := range rules {
+			alertNames = append(alertNames, r.(map[string]interface{})["alert"].(string))
+		}
+		Expect(alertNames).To(Equal([]string{"NFDWorkerUnavailable", "NFDMasterUnavailable", "NFDPruneJobFailed"}))
+	})
+})
+
+var _ = Describe("DeletePrometheusRule", func() {
+	var (
+		ctrl    *gomock.Controller
+		clnt    *client.MockClient
+		ruleAPI PrometheusRuleAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		ruleAPI = NewPrometheusRuleAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	name := "prometheusrule-name"
+	namespace := "prometheusrule-namespace"
+	expectedRule := NewPrometheusRule(namespace, name)
+
+	It("failure to delete prometheusrule from the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedRule).Return(fmt.Errorf("some error"))
+
+		_, err := ruleAPI.DeletePrometheusRule(ctx, namespace, name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("prometheusrule is not present in the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedRule).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		deleted, err := ruleAPI.DeletePrometheusRule(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("prometheusrule deleted successfully", func() {
+		clnt.EXPECT().Delete(ctx, expectedRule).Return(nil)
+
+		deleted, err := ruleAPI.DeletePrometheusRule(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rbac.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rbac.go -package=rbac -destination=mock_rbac.go RBACAPI
+//
+// Package rbac is a generated GoMock package.
+package rbac
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/api/rbac/v1"
+	v11 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockRBACAPI is a mock of RBACAPI interface.
+type MockRBACAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRBACAPIMockRecorder
+}
+
+// MockRBACAPIMockRecorder is the mock recorder for MockRBACAPI.
+type MockRBACAPIMockRecorder struct {
+	mock *MockRBACAPI
+}
+
+// NewMockRBACAPI creates a new mock instance.
+func NewMockRBACAPI(ctrl *gomock.Controller) *MockRBACAPI {
+	mock := &MockRBACAPI{ctrl: ctrl}
+	mock.recorder = &MockRBACAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRBACAPI) EXPECT() *MockRBACAPIMockRecorder {
+	return m.recorder
+}
+
+// DeleteClusterRoleBinding mocks base method.
+func (m *MockRBACAPI) DeleteClusterRoleBinding(ctx context.Context, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClusterRoleBinding", ctx, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClusterRoleBinding indicates an expected call of DeleteClusterRoleBinding.
+func (mr *MockRBACAPIMockRecorder) DeleteClusterRoleBinding(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRoleBinding", reflect.TypeOf((*MockRBACAPI)(nil).DeleteClusterRoleBinding), ctx, name)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRBACAPI) DeleteRole(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRBACAPIMockRecorder) DeleteRole(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRBACAPI)(nil).DeleteRole), ctx, namespace, name)
+}
+
+// DeleteRoleBinding mocks base method.
+func (m *MockRBACAPI) DeleteRoleBinding(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoleBinding", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRoleBinding indicates an expected call of DeleteRoleBinding.
+func (mr *MockRBACAPIMockRecorder) DeleteRoleBinding(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockRBACAPI)(nil).DeleteRoleBinding), ctx, namespace, name)
+}
+
+// DeleteServiceAccount mocks base method.
+func (m *MockRBACAPI) DeleteServiceAccount(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAccount", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
+func (mr *MockRBACAPIMockRecorder) DeleteServiceAccount(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockRBACAPI)(nil).DeleteServiceAccount), ctx, namespace, name)
+}
+
+// SetClusterRoleBindingAsDesired mocks base method.
+func (m *MockRBACAPI) SetClusterRoleBindingAsDesired(nfdInstance *v11.NodeFeatureDiscovery, clusterRole string, clusterRoleBinding *v10.ClusterRoleBinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClusterRoleBindingAsDesired", nfdInstance, clusterRole, clusterRoleBinding)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClusterRoleBindingAsDesired indicates an expected call of SetClusterRoleBindingAsDesired.
+func (mr *MockRBACAPIMockRecorder) SetClusterRoleBindingAsDesired(nfdInstance, clusterRole, clusterRoleBinding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClusterRoleBindingAsDesired", reflect.TypeOf((*MockRBACAPI)(nil).SetClusterRoleBindingAsDesired), nfdInstance, clusterRole, clusterRoleBinding)
+}
+
+// SetServiceAccountAsDesired mocks base method.
+func (m *MockRBACAPI) SetServiceAccountAsDesired(nfdInstance *v11.NodeFeatureDiscovery, sa *v1.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetServiceAccountAsDesired", nfdInstance, sa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetServiceAccountAsDesired indicates an expected call of SetServiceAccountAsDesired.
+func (mr *MockRBACAPIMockRecorder) SetServiceAccountAsDesired(nfdInstance, sa any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServiceAccountAsDesired", reflect.TypeOf((*MockRBACAPI)(nil).SetServiceAccountAsDesired), nfdInstance, sa)
+}
+
+// SetWorkerRoleAsDesired mocks base method.
+func (m *MockRBACAPI) SetWorkerRoleAsDesired(nfdInstance *v11.NodeFeatureDiscovery, role *v10.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkerRoleAsDesired", nfdInstance, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkerRoleAsDesired indicates an expected call of SetWorkerRoleAsDesired.
+func (mr *MockRBACAPIMockRecorder) SetWorkerRoleAsDesired(nfdInstance, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerRoleAsDesired", reflect.TypeOf((*MockRBACAPI)(nil).SetWorkerRoleAsDesired), nfdInstance, role)
+}
+
+// SetWorkerRoleBindingAsDesired mocks base method.
+func (m *MockRBACAPI) SetWorkerRoleBindingAsDesired(nfdInstance *v11.NodeFeatureDiscovery, roleBinding *v10.RoleBinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkerRoleBindingAsDesired", nfdInstance, roleBinding)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkerRoleBindingAsDesired indicates an expected call of SetWorkerRoleBindingAsDesired.
+func (mr *MockRBACAPIMockRecorder) SetWorkerRoleBindingAsDesired(nfdInstance, roleBinding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerRoleBindingAsDesired", reflect.TypeOf((*MockRBACAPI)(nil).SetWorkerRoleBindingAsDesired), nfdInstance, roleBinding)
+}
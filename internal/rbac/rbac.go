@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+)
+
+const workerName = "nfd-worker"
+
+// OperandClusterRoles are the ClusterRoles installed with the operator for
+// the operands, each of them granted to the ServiceAccount of the same name
+var OperandClusterRoles = []string{"nfd-master", "nfd-gc", "nfd-topology-updater", "nfd-prune"}
+
+// OperandServiceAccounts are the ServiceAccounts the operand pods run as
+var OperandServiceAccounts = append([]string{workerName}, OperandClusterRoles...)
+
+//go:generate mockgen -source=rbac.go -package=rbac -destination=mock_rbac.go RBACAPI
+
+type RBACAPI interface {
+	SetServiceAccountAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, sa *corev1.ServiceAccount) error
+	SetWorkerRoleAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, role *rbacv1.Role) error
+	SetWorkerRoleBindingAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, roleBinding *rbacv1.RoleBinding) error
+	SetClusterRoleBindingAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, clusterRole string, clusterRoleBinding *rbacv1.ClusterRoleBinding) error
+	DeleteServiceAccount(ctx context.Context, namespace, name string) (bool, error)
+	DeleteRole(ctx context.Context, namespace, name string) (bool, error)
+	DeleteRoleBinding(ctx context.Context, namespace, name string) (bool, error)
+	DeleteClusterRoleBinding(ctx context.Context, name string) (bool, error)
+}
+
+type rbac struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewRBACAPI(client client.Client, scheme *runtime.Scheme) RBACAPI {
+	return &rbac{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+func (r *rbac) SetServiceAccountAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, sa *corev1.ServiceAccount) error {
+	return ownership.SetOwner(nfdInstance, sa, r.scheme)
+}
+
+// SetWorkerRoleAsDesired lets nfd-worker publish its features in the
+// NodeFeature objects of the operand namespace, as the nfd-worker Role
+// installed with the operator does in the namespace of the operator
+func (r *rbac) SetWorkerRoleAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, role *rbacv1.Role) error {
+	role.Rules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"nfd.k8s-sigs.io"},
+			Resources: []string{"nodefeatures"},
+			Verbs:     []string{"get", "create", "update"},
+		},
+	}
+	return ownership.SetOwner(nfdInstance, role, r.scheme)
+}
+
+func (r *rbac) SetWorkerRoleBindingAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, roleBinding *rbacv1.RoleBinding) error {
+	roleBinding.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     workerName,
+	}
+	roleBinding.Subjects = []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      workerName,
+			Namespace: roleBinding.Namespace,
+		},
+	}
+	return ownership.SetOwner(nfdInstance, roleBinding, r.scheme)
+}
+
+// SetClusterRoleBindingAsDesired grants an operand ClusterRole to the
+// ServiceAccount of the same name in the operand namespace. The binding is
+// cluster scoped, so it is always owned through the owner labels
+func (r *rbac) SetClusterRoleBindingAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, clusterRole string, clusterRoleBinding *rbacv1.ClusterRoleBinding) error {
+	clusterRoleBinding.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     clusterRole,
+	}
+	clusterRoleBinding.Subjects = []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      clusterRole,
+			Namespace: nfdInstance.GetOperandNamespace(),
+		},
+	}
+	labels := clusterRoleBinding.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 2)
+	}
+	labels[ownership.OwnerNamespaceLabel] = nfdInstance.Namespace
+	labels[ownership.OwnerNameLabel] = nfdInstance.Name
+	clusterRoleBinding.SetLabels(labels)
+	return nil
+}
+
+// DeleteServiceAccount deletes the ServiceAccount, if it exists, and reports whether it existed
+func (r *rbac) DeleteServiceAccount(ctx context.Context, namespace, name string) (bool, error) {
+	sa := corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err := r.client.Delete(ctx, &sa)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete serviceaccount %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
+
+// DeleteRole deletes the Role, if it exists, and reports whether it existed
+func (r *rbac) DeleteRole(ctx context.Context, namespace, name string) (bool, error) {
+	role := rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err := r.client.Delete(ctx, &role)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete role %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
+
+// DeleteRoleBinding deletes the RoleBinding, if it exists, and reports whether it existed
+func (r *rbac) DeleteRoleBinding(ctx context.Context, namespace, name string) (bool, error) {
+	roleBinding := rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err := r.client.Delete(ctx, &roleBinding)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete rolebinding %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
+
+// DeleteClusterRoleBinding deletes the ClusterRoleBinding, if it exists, and reports whether it existed
+func (r *rbac) DeleteClusterRoleBinding(ctx context.Context, name string) (bool, error) {
+	clusterRoleBinding := rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	err := r.client.Delete(ctx, &clusterRoleBinding)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete clusterrolebinding %s: %w", name, err)
+	}
+	return err == nil, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+)
+
+var _ = Describe("operand RBAC objects", func() {
+	var (
+		rbacAPI RBACAPI
+	)
+
+	BeforeEach(func() {
+		rbacAPI = NewRBACAPI(nil, scheme)
+	})
+
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+		},
+	}
+	ownerLabels := map[string]string{
+		ownership.OwnerNamespaceLabel: "test-namespace",
+		ownership.OwnerNameLabel:      "nfd-cr",
+	}
+
+	It("serviceaccount is labelled with the CR", func() {
+		sa := corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "nfd-operands"},
+		}
+
+		err := rbacAPI.SetServiceAccountAsDesired(&nfdCR, &sa)
+		Expect(err).To(BeNil())
+		Expect(sa.Labels).To(Equal(ownerLabels))
+	})
+
+	It("worker role lets nfd-worker publish its NodeFeature objects", func() {
+		role := rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "nfd-operands"},
+		}
+
+		err := rbacAPI.SetWorkerRoleAsDesired(&nfdCR, &role)
+		Expect(err).To(BeNil())
+		Expect(role.Rules).To(Equal([]rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"nfd.k8s-sigs.io"},
+				Resources: []string{"nodefeatures"},
+				Verbs:     []string{"get", "create", "update"},
+			},
+		}))
+		Expect(role.Labels).To(Equal(ownerLabels))
+	})
+
+	It("worker rolebinding binds the worker role to the worker serviceaccount", func() {
+		roleBinding := rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "nfd-operands"},
+		}
+
+		err := rbacAPI.SetWorkerRoleBindingAsDesired(&nfdCR, &roleBinding)
+		Expect(err).To(BeNil())
+		Expect(roleBinding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "nfd-worker"}))
+		Expect(roleBinding.Subjects).To(Equal([]rbacv1.Subject{{Kind: "ServiceAccount", Name: "nfd-worker", Namespace: "nfd-operands"}}))
+		Expect(roleBinding.Labels).To(Equal(ownerLabels))
+	})
+
+	It("clusterrolebinding grants the clusterrole to the serviceaccount of the operand namespace", func() {
+		crb := rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-master-nfd-operands"},
+		}
+
+		err := rbacAPI.SetClusterRoleBindingAsDesired(&nfdCR, "nfd-master", &crb)
+		Expect(err).To(BeNil())
+		Expect(crb.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "nfd-master"}))
+		Expect(crb.Subjects).To(Equal([]rbacv1.Subject{{Kind: "ServiceAccount", Name: "nfd-master", Namespace: "nfd-operands"}}))
+		Expect(crb.Labels).To(Equal(ownerLabels))
+	})
+})
+
+var _ = Describe("DeleteClusterRoleBinding", func() {
+	var (
+		ctrl    *gomock.Controller
+		clnt    *client.MockClient
+		rbacAPI RBACAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		rbacAPI = NewRBACAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	expectedCRB := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "nfd-master-nfd-operands"},
+	}
+
+	It("failure to delete clusterrolebinding from the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedCRB).Return(fmt.Errorf("some error"))
+
+		_, err := rbacAPI.DeleteClusterRoleBinding(ctx, "nfd-master-nfd-operands")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("clusterrolebinding is not present in the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedCRB).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		deleted, err := rbacAPI.DeleteClusterRoleBinding(ctx, "nfd-master-nfd-operands")
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("clusterrolebinding deleted successfully", func() {
+		clnt.EXPECT().Delete(ctx, expectedCRB).Return(nil)
+
+		deleted, err := rbacAPI.DeleteClusterRoleBinding(ctx, "nfd-master-nfd-operands")
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -package=service -destination=mock_service.go ServiceAPI
+//
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	v10 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockServiceAPI is a mock of ServiceAPI interface.
+type MockServiceAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceAPIMockRecorder
+}
+
+// MockServiceAPIMockRecorder is the mock recorder for MockServiceAPI.
+type MockServiceAPIMockRecorder struct {
+	mock *MockServiceAPI
+}
+
+// NewMockServiceAPI creates a new mock instance.
+func NewMockServiceAPI(ctrl *gomock.Controller) *MockServiceAPI {
+	mock := &MockServiceAPI{ctrl: ctrl}
+	mock.recorder = &MockServiceAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceAPI) EXPECT() *MockServiceAPIMockRecorder {
+	return m.recorder
+}
+
+// DeleteService mocks base method.
+func (m *MockServiceAPI) DeleteService(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteService", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteService indicates an expected call of DeleteService.
+func (mr *MockServiceAPIMockRecorder) DeleteService(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceAPI)(nil).DeleteService), ctx, namespace, name)
+}
+
+// ListMetricsServices mocks base method.
+func (m *MockServiceAPI) ListMetricsServices(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) ([]v1.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricsServices", ctx, nfdInstance)
+	ret0, _ := ret[0].([]v1.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricsServices indicates an expected call of ListMetricsServices.
+func (mr *MockServiceAPIMockRecorder) ListMetricsServices(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsServices", reflect.TypeOf((*MockServiceAPI)(nil).ListMetricsServices), ctx, nfdInstance)
+}
+
+// SetMetricsServiceAsDesired mocks base method.
+func (m *MockServiceAPI) SetMetricsServiceAsDesired(nfdInstance *v10.NodeFeatureDiscovery, operandName string, svc *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetricsServiceAsDesired", nfdInstance, operandName, svc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMetricsServiceAsDesired indicates an expected call of SetMetricsServiceAsDesired.
+func (mr *MockServiceAPIMockRecorder) SetMetricsServiceAsDesired(nfdInstance, operandName, svc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetricsServiceAsDesired", reflect.TypeOf((*MockServiceAPI)(nil).SetMetricsServiceAsDesired), nfdInstance, operandName, svc)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+)
+
+const (
+	// MetricsPortName is the name of the container port the operands serve
+	// their metrics on, and of the port of the metrics Services
+	MetricsPortName = "http"
+
+	metricsPort = 8080
+)
+
+//go:generate mockgen -source=service.go -package=service -destination=mock_service.go ServiceAPI
+
+type ServiceAPI interface {
+	SetMetricsServiceAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, operandName string, svc *corev1.Service) error
+	ListMetricsServices(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]corev1.Service, error)
+	DeleteService(ctx context.Context, namespace, name string) (bool, error)
+}
+
+type service struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewServiceAPI(client client.Client, scheme *runtime.Scheme) ServiceAPI {
+	return &service{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// SetMetricsServiceAsDesired exposes the metrics port of the pods of an
+// operand Deployment or DaemonSet. The fields allocated by the API server,
+// like the cluster IP, are left untouched
+func (s *service) SetMetricsServiceAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, operandName string, svc *corev1.Service) error {
+	svc.ObjectMeta.Labels = GetMetricsLabels(nfdInstance, operandName)
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = map[string]string{"app": operandName}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       MetricsPortName,
+			Port:       metricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString(MetricsPortName),
+		},
+	}
+	return ownership.SetOwner(nfdInstance, svc, s.scheme)
+}
+
+// GetMetricsLabels returns the labels of the metrics objects of an operand:
+// the labels of spec.metrics, which can not override the ones used by the
+// operator to select the objects
+func GetMetricsLabels(nfdInstance *nfdv1.NodeFeatureDiscovery, operandName string) map[string]string {
+	labels := map[string]string{}
+	if nfdInstance.Spec.Metrics != nil {
+		maps.Copy(labels, nfdInstance.Spec.Metrics.Labels)
+	}
+	labels["app"] = "nfd"
+	labels[names.MetricsLabel] = operandName
+	return labels
+}
+
+func (s *service) ListMetricsServices(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]corev1.Service, error) {
+	svcList := corev1.ServiceList{}
+	err := s.client.List(ctx, &svcList, client.InNamespace(nfdInstance.GetOperandNamespace()), client.HasLabels{names.MetricsLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics services in namespace %s: %w", nfdInstance.GetOperandNamespace(), err)
+	}
+	// several NFD instances can share the namespace
+	owned := make([]corev1.Service, 0, len(svcList.Items))
+	for _, svc := range svcList.Items {
+		if ownership.IsOwnedBy(&svc, nfdInstance) {
+			owned = append(owned, svc)
+		}
+	}
+	return owned, nil
+}
+
+// DeleteService deletes the Service, if it exists, and reports whether it existed
+func (s *service) DeleteService(ctx context.Context, namespace, name string) (bool, error) {
+	svc := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err := s.client.Delete(ctx, &svc)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete service %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("SetMetricsServiceAsDesired", func() {
+	var (
+		serviceAPI ServiceAPI
+	)
+
+	BeforeEach(func() {
+		serviceAPI = NewServiceAPI(nil, scheme)
+	})
+
+	It("good flow, metrics service object populated with correct values", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		svc := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nfd-master-metrics",
+				Namespace: "test-namespace",
+			},
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+		}
+
+		err := serviceAPI.SetMetricsServiceAsDesired(&nfdCR, "nfd-master", &svc)
+
+		Expect(err).To(BeNil())
+		expectedYAMLFile, err := os.ReadFile("testdata/test_metrics_service.yaml")
+		Expect(err).To(BeNil())
+		expectedJSON, err := yaml.YAMLToJSON(expectedYAMLFile)
+		Expect(err).To(BeNil())
+		testSvc := corev1.Service{}
+		err = yaml.Unmarshal(expectedJSON, &testSvc)
+		Expect(err).To(BeNil())
+		Expect(svc).To(BeComparableTo(testSvc))
+	})
+
+	It("cluster IP allocated by the API server is kept", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		svc := corev1.Service{
+			Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+		}
+
+		err := serviceAPI.SetMetricsServiceAsDesired(&nfdCR, "nfd-master", &svc)
+
+		Expect(err).To(BeNil())
+		Expect(svc.Spec.ClusterIP).To(Equal("10.0.0.1"))
+	})
+
+	It("labels of spec.metrics are added, without overriding the operator ones", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{
+					Labels: map[string]string{"release": "prometheus", "app": "other"},
+				},
+			},
+		}
+		svc := corev1.Service{}
+
+		err := serviceAPI.SetMetricsServiceAsDesired(&nfdCR, "nfd-worker", &svc)
+
+		Expect(err).To(BeNil())
+		Expect(svc.Labels).To(Equal(map[string]string{
+			"app":                       "nfd",
+			"nfd.kubernetes.io/metrics": "nfd-worker",
+			"release":                   "prometheus",
+		}))
+	})
+})
+
+var _ = Describe("ListMetricsServices", func() {
+	var (
+		ctrl       *gomock.Controller
+		clnt       *client.MockClient
+		serviceAPI ServiceAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		serviceAPI = NewServiceAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+
+	It("only the services owned by the NFD CR are returned", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance", UID: "nfd-uid"},
+		}
+		owned := corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-master-metrics"}}
+		Expect(controllerutil.SetControllerReference(&nfdCR, &owned, scheme)).To(Succeed())
+		other := corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-master-blue-metrics"}}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *corev1.ServiceList, _ ...ctrlclient.ListOption) error {
+				list.Items = []corev1.Service{owned, other}
+				return nil
+			},
+		)
+
+		svcs, err := serviceAPI.ListMetricsServices(ctx, &nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(svcs).To(Equal([]corev1.Service{owned}))
+	})
+
+	It("failure to list the services", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := serviceAPI.ListMetricsServices(ctx, &nfdCR)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("DeleteService", func() {
+	var (
+		ctrl       *gomock.Controller
+		clnt       *client.MockClient
+		serviceAPI ServiceAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		serviceAPI = NewServiceAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	name := "service-name"
+	namespace := "service-namespace"
+	expectedSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	It("failure to delete service from the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedSvc).Return(fmt.Errorf("some error"))
+
+		_, err := serviceAPI.DeleteService(ctx, namespace, name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("service is not present in the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedSvc).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		deleted, err := serviceAPI.DeleteService(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("service deleted successfully", func() {
+		clnt.EXPECT().Delete(ctx, expectedSvc).Return(nil)
+
+		deleted, err := serviceAPI.DeleteService(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
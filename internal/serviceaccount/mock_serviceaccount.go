@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: serviceaccount.go
+//
+// Generated by this command:
+//
+//	mockgen -source=serviceaccount.go -package=serviceaccount -destination=mock_serviceaccount.go ServiceAccountAPI
+//
+// Package serviceaccount is a generated GoMock package.
+package serviceaccount
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MockServiceAccountAPI is a mock of ServiceAccountAPI interface.
+type MockServiceAccountAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceAccountAPIMockRecorder
+}
+
+// MockServiceAccountAPIMockRecorder is the mock recorder for MockServiceAccountAPI.
+type MockServiceAccountAPIMockRecorder struct {
+	mock *MockServiceAccountAPI
+}
+
+// NewMockServiceAccountAPI creates a new mock instance.
+func NewMockServiceAccountAPI(ctrl *gomock.Controller) *MockServiceAccountAPI {
+	mock := &MockServiceAccountAPI{ctrl: ctrl}
+	mock.recorder = &MockServiceAccountAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceAccountAPI) EXPECT() *MockServiceAccountAPIMockRecorder {
+	return m.recorder
+}
+
+// GetServiceAccount mocks base method.
+func (m *MockServiceAccountAPI) GetServiceAccount(ctx context.Context, namespace, name string) (*v1.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccount", ctx, namespace, name)
+	ret0, _ := ret[0].(*v1.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccount indicates an expected call of GetServiceAccount.
+func (mr *MockServiceAccountAPIMockRecorder) GetServiceAccount(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockServiceAccountAPI)(nil).GetServiceAccount), ctx, namespace, name)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//go:generate mockgen -source=serviceaccount.go -package=serviceaccount -destination=mock_serviceaccount.go ServiceAccountAPI
+
+type ServiceAccountAPI interface {
+	GetServiceAccount(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error)
+}
+
+type serviceAccount struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewServiceAccountAPI(client client.Client, scheme *runtime.Scheme) ServiceAccountAPI {
+	return &serviceAccount{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+func (s *serviceAccount) GetServiceAccount(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error) {
+	sa := &corev1.ServiceAccount{}
+	err := s.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, sa)
+	return sa, err
+}
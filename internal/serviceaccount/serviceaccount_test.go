@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+)
+
+var _ = Describe("GetServiceAccount", func() {
+	var (
+		ctrl  *gomock.Controller
+		clnt  *client.MockClient
+		saAPI ServiceAccountAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		saAPI = NewServiceAccountAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	key := ctrlclient.ObjectKey{Namespace: "test-namespace", Name: "nfd-worker"}
+
+	It("service account is not present in the cluster", func() {
+		clnt.EXPECT().Get(ctx, key, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, key.Name))
+
+		_, err := saAPI.GetServiceAccount(ctx, key.Namespace, key.Name)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("service account is returned", func() {
+		clnt.EXPECT().Get(ctx, key, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ ctrlclient.ObjectKey, sa *corev1.ServiceAccount, _ ...ctrlclient.GetOption) error {
+				sa.Name = key.Name
+				return nil
+			})
+
+		sa, err := saAPI.GetServiceAccount(ctx, key.Namespace, key.Name)
+		Expect(err).To(BeNil())
+		Expect(sa.Name).To(Equal(key.Name))
+	})
+})
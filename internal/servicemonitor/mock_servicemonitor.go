@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: servicemonitor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=servicemonitor.go -package=servicemonitor -destination=mock_servicemonitor.go ServiceMonitorAPI
+//
+// Package servicemonitor is a generated GoMock package.
+package servicemonitor
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	v1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockServiceMonitorAPI is a mock of ServiceMonitorAPI interface.
+type MockServiceMonitorAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMonitorAPIMockRecorder
+}
+
+// MockServiceMonitorAPIMockRecorder is the mock recorder for MockServiceMonitorAPI.
+type MockServiceMonitorAPIMockRecorder struct {
+	mock *MockServiceMonitorAPI
+}
+
+// NewMockServiceMonitorAPI creates a new mock instance.
+func NewMockServiceMonitorAPI(ctrl *gomock.Controller) *MockServiceMonitorAPI {
+	mock := &MockServiceMonitorAPI{ctrl: ctrl}
+	mock.recorder = &MockServiceMonitorAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceMonitorAPI) EXPECT() *MockServiceMonitorAPIMockRecorder {
+	return m.recorder
+}
+
+// DeleteServiceMonitor mocks base method.
+func (m *MockServiceMonitorAPI) DeleteServiceMonitor(ctx context.Context, namespace, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceMonitor", ctx, namespace, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteServiceMonitor indicates an expected call of DeleteServiceMonitor.
+func (mr *MockServiceMonitorAPIMockRecorder) DeleteServiceMonitor(ctx, namespace, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceMonitor", reflect.TypeOf((*MockServiceMonitorAPI)(nil).DeleteServiceMonitor), ctx, namespace, name)
+}
+
+// IsServiceMonitorSupported mocks base method.
+func (m *MockServiceMonitorAPI) IsServiceMonitorSupported() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsServiceMonitorSupported")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsServiceMonitorSupported indicates an expected call of IsServiceMonitorSupported.
+func (mr *MockServiceMonitorAPIMockRecorder) IsServiceMonitorSupported() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsServiceMonitorSupported", reflect.TypeOf((*MockServiceMonitorAPI)(nil).IsServiceMonitorSupported))
+}
+
+// ListMetricsServiceMonitors mocks base method.
+func (m *MockServiceMonitorAPI) ListMetricsServiceMonitors(ctx context.Context, nfdInstance *v1.NodeFeatureDiscovery) ([]unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricsServiceMonitors", ctx, nfdInstance)
+	ret0, _ := ret[0].([]unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricsServiceMonitors indicates an expected call of ListMetricsServiceMonitors.
+func (mr *MockServiceMonitorAPIMockRecorder) ListMetricsServiceMonitors(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsServiceMonitors", reflect.TypeOf((*MockServiceMonitorAPI)(nil).ListMetricsServiceMonitors), ctx, nfdInstance)
+}
+
+// SetMetricsServiceMonitorAsDesired mocks base method.
+func (m *MockServiceMonitorAPI) SetMetricsServiceMonitorAsDesired(nfdInstance *v1.NodeFeatureDiscovery, operandName string, sm *unstructured.Unstructured) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetricsServiceMonitorAsDesired", nfdInstance, operandName, sm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMetricsServiceMonitorAsDesired indicates an expected call of SetMetricsServiceMonitorAsDesired.
+func (mr *MockServiceMonitorAPIMockRecorder) SetMetricsServiceMonitorAsDesired(nfdInstance, operandName, sm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetricsServiceMonitorAsDesired", reflect.TypeOf((*MockServiceMonitorAPI)(nil).SetMetricsServiceMonitorAsDesired), nfdInstance, operandName, sm)
+}
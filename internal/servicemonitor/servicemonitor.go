@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicemonitor
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/ownership"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/service"
+)
+
+// GroupVersionKind of the ServiceMonitors of the Prometheus operator. They
+// are handled as unstructured objects, so that the operator does not depend
+// on the Prometheus operator being installed
+var GroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+//go:generate mockgen -source=servicemonitor.go -package=servicemonitor -destination=mock_servicemonitor.go ServiceMonitorAPI
+
+type ServiceMonitorAPI interface {
+	IsServiceMonitorSupported() (bool, error)
+	SetMetricsServiceMonitorAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, operandName string, sm *unstructured.Unstructured) error
+	ListMetricsServiceMonitors(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]unstructured.Unstructured, error)
+	DeleteServiceMonitor(ctx context.Context, namespace, name string) (bool, error)
+}
+
+type serviceMonitor struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewServiceMonitorAPI(client client.Client, scheme *runtime.Scheme) ServiceMonitorAPI {
+	return &serviceMonitor{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// NewServiceMonitor returns an empty ServiceMonitor with the given name
+func NewServiceMonitor(namespace, name string) *unstructured.Unstructured {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(GroupVersionKind)
+	sm.SetNamespace(namespace)
+	sm.SetName(name)
+	return sm
+}
+
+// IsSupported reports whether the ServiceMonitor CRD is installed in the
+// cluster known to mapper
+func IsSupported(mapper meta.RESTMapper) (bool, error) {
+	_, err := mapper.RESTMapping(GroupVersionKind.GroupKind(), GroupVersionKind.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the REST mapping of %s: %w", GroupVersionKind, err)
+	}
+	return true, nil
+}
+
+// IsServiceMonitorSupported reports whether the ServiceMonitor CRD is
+// installed. It is checked on every call, so that the ServiceMonitors are
+// created once the Prometheus operator is installed
+func (s *serviceMonitor) IsServiceMonitorSupported() (bool, error) {
+	return IsSupported(s.client.RESTMapper())
+}
+
+// SetMetricsServiceMonitorAsDesired scrapes the metrics Service of an
+// operand Deployment or DaemonSet
+func (s *serviceMonitor) SetMetricsServiceMonitorAsDesired(nfdInstance *nfdv1.NodeFeatureDiscovery, operandName string, sm *unstructured.Unstructured) error {
+	sm.SetLabels(service.GetMetricsLabels(nfdInstance, operandName))
+	sm.Object["spec"] = map[string]interface{}{
+		"endpoints": []interface{}{getEndpoint(nfdInstance)},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{nfdInstance.GetOperandNamespace()},
+		},
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				"app":              "nfd",
+				names.MetricsLabel: operandName,
+			},
+		},
+	}
+	return ownership.SetOwner(nfdInstance, sm, s.scheme)
+}
+
+func getEndpoint(nfdInstance *nfdv1.NodeFeatureDiscovery) map[string]interface{} {
+	endpoint := map[string]interface{}{
+		"port": service.MetricsPortName,
+		"path": "/metrics",
+	}
+	if nfdInstance.Spec.Metrics == nil {
+		return endpoint
+	}
+	smSpec := &nfdInstance.Spec.Metrics.ServiceMonitor
+	if smSpec.Interval != nil {
+		// Prometheus durations have no fractional part
+		endpoint["interval"] = fmt.Sprintf("%ds", int64(smSpec.Interval.Seconds()))
+	}
+	if smSpec.TLSConfig != nil {
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = getTLSConfig(smSpec.TLSConfig)
+	}
+	return endpoint
+}
+
+func getTLSConfig(tlsConfig *nfdv1.MetricsTLSConfig) map[string]interface{} {
+	config := map[string]interface{}{}
+	if tlsConfig.CASecret != nil {
+		config["ca"] = map[string]interface{}{"secret": getSecretKeySelector(tlsConfig.CASecret)}
+	}
+	if tlsConfig.CertSecret != nil {
+		config["cert"] = map[string]interface{}{"secret": getSecretKeySelector(tlsConfig.CertSecret)}
+	}
+	if tlsConfig.KeySecret != nil {
+		config["keySecret"] = getSecretKeySelector(tlsConfig.KeySecret)
+	}
+	if tlsConfig.ServerName != "" {
+		config["serverName"] = tlsConfig.ServerName
+	}
+	if tlsConfig.InsecureSkipVerify {
+		config["insecureSkipVerify"] = true
+	}
+	return config
+}
+
+func getSecretKeySelector(selector *corev1.SecretKeySelector) map[string]interface{} {
+	return map[string]interface{}{
+		"name": selector.Name,
+		"key":  selector.Key,
+	}
+}
+
+func (s *serviceMonitor) ListMetricsServiceMonitors(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]unstructured.Unstructured, error) {
+	smList := unstructured.UnstructuredList{}
+	smList.SetGroupVersionKind(GroupVersionKind.GroupVersion().WithKind(GroupVersionKind.Kind + "List"))
+	err := s.client.List(ctx, &smList, client.InNamespace(nfdInstance.GetOperandNamespace()), client.HasLabels{names.MetricsLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics servicemonitors in namespace %s: %w", nfdInstance.GetOperandNamespace(), err)
+	}
+	// several NFD instances can share the namespace
+	owned := make([]unstructured.Unstructured, 0, len(smList.Items))
+	for _, sm := range smList.Items {
+		if ownership.IsOwnedBy(&sm, nfdInstance) {
+			owned = append(owned, sm)
+		}
+	}
+	return owned, nil
+}
+
+// DeleteServiceMonitor deletes the ServiceMonitor, if it exists, and reports whether it existed
+func (s *serviceMonitor) DeleteServiceMonitor(ctx context.Context, namespace, name string) (bool, error) {
+	err := s.client.Delete(ctx, NewServiceMonitor(namespace, name))
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete servicemonitor %s/%s: %w", namespace, name, err)
+	}
+	return err == nil, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicemonitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("IsSupported", func() {
+	It("servicemonitor CRD is installed", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(GroupVersionKind, meta.RESTScopeNamespace)
+
+		supported, err := IsSupported(mapper)
+
+		Expect(err).To(BeNil())
+		Expect(supported).To(BeTrue())
+	})
+
+	It("servicemonitor CRD is not installed", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+
+		supported, err := IsSupported(mapper)
+
+		Expect(err).To(BeNil())
+		Expect(supported).To(BeFalse())
+	})
+})
+
+var _ = Describe("SetMetricsServiceMonitorAsDesired", func() {
+	var (
+		smAPI ServiceMonitorAPI
+	)
+
+	BeforeEach(func() {
+		smAPI = NewServiceMonitorAPI(nil, scheme)
+	})
+
+	It("good flow, metrics servicemonitor object populated with correct values", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{
+					Labels: map[string]string{"release": "prometheus"},
+					ServiceMonitor: nfdv1.ServiceMonitorSpec{
+						Interval: &metav1.Duration{Duration: 30 * time.Second},
+						TLSConfig: &nfdv1.MetricsTLSConfig{
+							CASecret: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "nfd-metrics-tls"},
+								Key:                  "ca.crt",
+							},
+							ServerName: "nfd-master-metrics.test-namespace.svc",
+						},
+					},
+				},
+			},
+		}
+		sm := NewServiceMonitor("test-namespace", "nfd-master-metrics")
+
+		err := smAPI.SetMetricsServiceMonitorAsDesired(&nfdCR, "nfd-master", sm)
+
+		Expect(err).To(BeNil())
+		expectedYAMLFile, err := os.ReadFile("testdata/test_metrics_servicemonitor.yaml")
+		Expect(err).To(BeNil())
+		expectedJSON, err := yaml.YAMLToJSON(expectedYAMLFile)
+		Expect(err).To(BeNil())
+		testSM := unstructured.Unstructured{}
+		err = testSM.UnmarshalJSON(expectedJSON)
+		Expect(err).To(BeNil())
+		Expect(sm.Object).To(Equal(testSM.Object))
+	})
+
+	It("metrics are scraped over plain HTTP without TLS configuration", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Metrics: &nfdv1.MetricsSpec{},
+			},
+		}
+		sm := NewServiceMonitor("test-namespace", "nfd-worker-metrics")
+
+		err := smAPI.SetMetricsServiceMonitorAsDesired(&nfdCR, "nfd-worker", sm)
+
+		Expect(err).To(BeNil())
+		endpoints, found, err := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+		Expect(err).To(BeNil())
+		Expect(found).To(BeTrue())
+		Expect(endpoints).To(Equal([]interface{}{
+			map[string]interface{}{"port": "http", "path": "/metrics"},
+		}))
+	})
+})
+
+var _ = Describe("ListMetricsServiceMonitors", func() {
+	var (
+		ctrl  *gomock.Controller
+		clnt  *client.MockClient
+		smAPI ServiceMonitorAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		smAPI = NewServiceMonitorAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+
+	It("only the servicemonitors owned by the NFD CR are returned", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance", UID: "nfd-uid"},
+		}
+		owned := NewServiceMonitor("test-namespace", "nfd-master-metrics")
+		Expect(controllerutil.SetControllerReference(&nfdCR, owned, scheme)).To(Succeed())
+		other := NewServiceMonitor("test-namespace", "nfd-master-blue-metrics")
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *unstructured.UnstructuredList, _ ...ctrlclient.ListOption) error {
+				Expect(list.GetKind()).To(Equal("ServiceMonitorList"))
+				list.Items = []unstructured.Unstructured{*owned, *other}
+				return nil
+			},
+		)
+
+		sms, err := smAPI.ListMetricsServiceMonitors(ctx, &nfdCR)
+
+		Expect(err).To(BeNil())
+		Expect(sms).To(Equal([]unstructured.Unstructured{*owned}))
+	})
+
+	It("failure to list the servicemonitors", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := smAPI.ListMetricsServiceMonitors(ctx, &nfdCR)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("DeleteServiceMonitor", func() {
+	var (
+		ctrl  *gomock.Controller
+		clnt  *client.MockClient
+		smAPI ServiceMonitorAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		smAPI = NewServiceMonitorAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	name := "servicemonitor-name"
+	namespace := "servicemonitor-namespace"
+	expectedSM := NewServiceMonitor(namespace, name)
+
+	It("failure to delete servicemonitor from the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedSM).Return(fmt.Errorf("some error"))
+
+		_, err := smAPI.DeleteServiceMonitor(ctx, namespace, name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("servicemonitor is not present in the cluster", func() {
+		clnt.EXPECT().Delete(ctx, expectedSM).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever"))
+
+		deleted, err := smAPI.DeleteServiceMonitor(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("servicemonitor deleted successfully", func() {
+		clnt.EXPECT().Delete(ctx, expectedSM).Return(nil)
+
+		deleted, err := smAPI.DeleteServiceMonitor(ctx, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+	})
+})
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snapshot.go -package=snapshot -destination=mock_snapshot.go SnapshotAPI
+//
+// Package snapshot is a generated GoMock package.
+package snapshot
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	v10 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// MockSnapshotAPI is a mock of SnapshotAPI interface.
+type MockSnapshotAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotAPIMockRecorder
+}
+
+// MockSnapshotAPIMockRecorder is the mock recorder for MockSnapshotAPI.
+type MockSnapshotAPIMockRecorder struct {
+	mock *MockSnapshotAPI
+}
+
+// NewMockSnapshotAPI creates a new mock instance.
+func NewMockSnapshotAPI(ctrl *gomock.Controller) *MockSnapshotAPI {
+	mock := &MockSnapshotAPI{ctrl: ctrl}
+	mock.recorder = &MockSnapshotAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotAPI) EXPECT() *MockSnapshotAPIMockRecorder {
+	return m.recorder
+}
+
+// ListSnapshots mocks base method.
+func (m *MockSnapshotAPI) ListSnapshots(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) ([]v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSnapshots", ctx, nfdInstance)
+	ret0, _ := ret[0].([]v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSnapshots indicates an expected call of ListSnapshots.
+func (mr *MockSnapshotAPIMockRecorder) ListSnapshots(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSnapshots", reflect.TypeOf((*MockSnapshotAPI)(nil).ListSnapshots), ctx, nfdInstance)
+}
+
+// RestoreSnapshot mocks base method.
+func (m *MockSnapshotAPI) RestoreSnapshot(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSnapshot", ctx, nfdInstance, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSnapshot indicates an expected call of RestoreSnapshot.
+func (mr *MockSnapshotAPIMockRecorder) RestoreSnapshot(ctx, nfdInstance, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSnapshot", reflect.TypeOf((*MockSnapshotAPI)(nil).RestoreSnapshot), ctx, nfdInstance, name)
+}
+
+// SetSnapshotConfigMapAsDesired mocks base method.
+func (m *MockSnapshotAPI) SetSnapshotConfigMapAsDesired(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery, pruneID string, cm *v1.ConfigMap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSnapshotConfigMapAsDesired", ctx, nfdInstance, pruneID, cm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSnapshotConfigMapAsDesired indicates an expected call of SetSnapshotConfigMapAsDesired.
+func (mr *MockSnapshotAPIMockRecorder) SetSnapshotConfigMapAsDesired(ctx, nfdInstance, pruneID, cm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapshotConfigMapAsDesired", reflect.TypeOf((*MockSnapshotAPI)(nil).SetSnapshotConfigMapAsDesired), ctx, nfdInstance, pruneID, cm)
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+const (
+	// SnapshotKey is the key of the ConfigMap holding the gzipped JSON
+	// snapshot of the nodes
+	SnapshotKey = "nodes.json.gz"
+
+	// PruneIDAnnotation is set on the snapshot ConfigMap, its value
+	// identifies the prune the snapshot was taken for
+	PruneIDAnnotation = "nfd.kubernetes.io/snapshot-of"
+
+	// OwnerLabel is set on the snapshot ConfigMaps, its value is the name of
+	// the NFD CR the snapshot was taken for
+	OwnerLabel = "nfd.kubernetes.io/snapshot-owner"
+
+	// HistoryLimit is the number of snapshots kept for an NFD CR, the oldest
+	// ones are deleted once a new snapshot is saved
+	HistoryLimit = 3
+
+	// namespaces and annotations used by nfd-master to track the labels,
+	// annotations, extended resources and taints it manages on a node
+	featureNs                    = "feature.node.kubernetes.io"
+	profileNs                    = "profile.node.kubernetes.io"
+	annotationNs                 = "nfd.node.kubernetes.io"
+	featureLabelsAnnotation      = "feature-labels"
+	featureAnnotationsAnnotation = "feature-annotations"
+	extendedResourcesAnnotation  = "extended-resources"
+	taintsAnnotation             = "taints"
+)
+
+//go:generate mockgen -source=snapshot.go -package=snapshot -destination=mock_snapshot.go SnapshotAPI
+
+type SnapshotAPI interface {
+	SetSnapshotConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pruneID string, cm *corev1.ConfigMap) error
+	ListSnapshots(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]corev1.ConfigMap, error)
+	RestoreSnapshot(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, name string) (int, error)
+}
+
+// NodeSnapshot holds what nfd-master manages on a node, and what the prune
+// job removes from it
+type NodeSnapshot struct {
+	Labels            map[string]string            `json:"labels,omitempty"`
+	Annotations       map[string]string            `json:"annotations,omitempty"`
+	ExtendedResources map[string]resource.Quantity `json:"extendedResources,omitempty"`
+	Taints            []corev1.Taint               `json:"taints,omitempty"`
+}
+
+func (n *NodeSnapshot) isEmpty() bool {
+	return len(n.Labels) == 0 && len(n.Annotations) == 0 && len(n.ExtendedResources) == 0 && len(n.Taints) == 0
+}
+
+type snapshot struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewSnapshotAPI(client client.Client, scheme *runtime.Scheme) SnapshotAPI {
+	return &snapshot{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// SetSnapshotConfigMapAsDesired writes the snapshot of the nodes of the NFD
+// instance to the ConfigMap. The ConfigMap has no owner reference, so that it
+// survives the deletion of the CR. A snapshot already taken for the same
+// prune is kept as is: once the prune job started, the nodes no longer hold
+// the NFD labels and a new snapshot would overwrite the one to restore
+func (s *snapshot) SetSnapshotConfigMapAsDesired(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, pruneID string,
+	cm *corev1.ConfigMap) error {
+	if cm.Annotations[PruneIDAnnotation] == pruneID {
+		return nil
+	}
+
+	nodeList := corev1.NodeList{}
+	err := s.client.List(ctx, &nodeList)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodes := make(map[string]NodeSnapshot, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodeSnapshot := getNodeSnapshot(&nodeList.Items[i], nfdInstance.Spec.Instance)
+		if !nodeSnapshot.isEmpty() {
+			nodes[nodeList.Items[i].Name] = nodeSnapshot
+		}
+	}
+	data, err := encode(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to encode the snapshot of the nodes: %w", err)
+	}
+
+	cm.Labels = map[string]string{"app": "nfd", OwnerLabel: nfdInstance.Name}
+	cm.Annotations = map[string]string{PruneIDAnnotation: pruneID}
+	cm.Data = nil
+	cm.BinaryData = map[string][]byte{SnapshotKey: data}
+	return nil
+}
+
+// ListSnapshots returns the snapshot ConfigMaps of the NFD CR, from the
+// newest to the oldest
+func (s *snapshot) ListSnapshots(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) ([]corev1.ConfigMap, error) {
+	cmList := corev1.ConfigMapList{}
+	err := s.client.List(ctx, &cmList, client.InNamespace(nfdInstance.Namespace), client.MatchingLabels{OwnerLabel: nfdInstance.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshot configmaps in namespace %s: %w", nfdInstance.Namespace, err)
+	}
+	slices.SortFunc(cmList.Items, func(a, b corev1.ConfigMap) int {
+		if c := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return cmList.Items, nil
+}
+
+// RestoreSnapshot re-applies a snapshot of the NFD CR to the nodes, and
+// returns the number of nodes it was applied to. Only a ConfigMap saved by
+// SetSnapshotConfigMapAsDesired for the CR is restored, and only what the
+// nfd-master of the instance managed on a node, in the namespaces it is
+// allowed to write to, is taken from it. The snapshot is merged into the
+// nodes: labels, annotations and extended resources are set to their
+// recorded value, and the missing taints are added. Nodes that no longer
+// exist are skipped
+func (s *snapshot) RestoreSnapshot(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, name string) (int, error) {
+	namespace := nfdInstance.Namespace
+	cm := corev1.ConfigMap{}
+	err := s.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cm)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get snapshot configmap %s/%s: %w", namespace, name, err)
+	}
+	if cm.Labels[OwnerLabel] != nfdInstance.Name || cm.Annotations[PruneIDAnnotation] == "" {
+		return 0, fmt.Errorf("configmap %s/%s is not a snapshot of %s", namespace, name, nfdInstance.Name)
+	}
+	data, ok := cm.BinaryData[SnapshotKey]
+	if !ok {
+		return 0, fmt.Errorf("configmap %s/%s does not hold a %s snapshot", namespace, name, SnapshotKey)
+	}
+	nodes, err := decode(data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode snapshot configmap %s/%s: %w", namespace, name, err)
+	}
+
+	restored := 0
+	nodeNames := make([]string, 0, len(nodes))
+	for nodeName := range nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	slices.Sort(nodeNames)
+	for _, nodeName := range nodeNames {
+		node := corev1.Node{}
+		err = s.client.Get(ctx, client.ObjectKey{Name: nodeName}, &node)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return restored, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+		}
+		err = s.restoreNode(ctx, &node, filterNodeSnapshot(nodes[nodeName], nfdInstance))
+		if err != nil {
+			return restored, err
+		}
+		restored++
+	}
+	return restored, nil
+}
+
+func (s *snapshot) restoreNode(ctx context.Context, node *corev1.Node, nodeSnapshot NodeSnapshot) error {
+	unmodified := node.DeepCopy()
+	if len(nodeSnapshot.Labels) > 0 && node.Labels == nil {
+		node.Labels = make(map[string]string, len(nodeSnapshot.Labels))
+	}
+	maps.Copy(node.Labels, nodeSnapshot.Labels)
+	if len(nodeSnapshot.Annotations) > 0 && node.Annotations == nil {
+		node.Annotations = make(map[string]string, len(nodeSnapshot.Annotations))
+	}
+	maps.Copy(node.Annotations, nodeSnapshot.Annotations)
+	for i := range nodeSnapshot.Taints {
+		taint := &nodeSnapshot.Taints[i]
+		if !slices.ContainsFunc(node.Spec.Taints, func(t corev1.Taint) bool { return t.MatchTaint(taint) }) {
+			node.Spec.Taints = append(node.Spec.Taints, *taint)
+		}
+	}
+	err := s.client.Patch(ctx, node, client.MergeFrom(unmodified))
+	if err != nil {
+		return fmt.Errorf("failed to patch node %s: %w", node.Name, err)
+	}
+
+	if len(nodeSnapshot.ExtendedResources) == 0 {
+		return nil
+	}
+	unmodified = node.DeepCopy()
+	if node.Status.Capacity == nil {
+		node.Status.Capacity = make(corev1.ResourceList, len(nodeSnapshot.ExtendedResources))
+	}
+	for name, quantity := range nodeSnapshot.ExtendedResources {
+		node.Status.Capacity[corev1.ResourceName(name)] = quantity
+	}
+	err = s.client.Status().Patch(ctx, node, client.MergeFrom(unmodified))
+	if err != nil {
+		return fmt.Errorf("failed to patch the status of node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// getNodeSnapshot returns what the nfd-master of the instance manages on the
+// node. nfd-master lists the labels, annotations, extended resources and
+// taints it set in annotations of its own namespace, which also hold the
+// versions of the operands
+func getNodeSnapshot(node *corev1.Node, instance string) NodeSnapshot {
+	ns := getAnnotationNs(instance)
+
+	nodeSnapshot := NodeSnapshot{}
+	for key, value := range node.Annotations {
+		if strings.HasPrefix(key, ns+"/") {
+			setValue(&nodeSnapshot.Annotations, key, value)
+		}
+	}
+	for _, name := range getTrackedNames(node, ns+"/"+featureLabelsAnnotation) {
+		if value, ok := node.Labels[name]; ok {
+			setValue(&nodeSnapshot.Labels, name, value)
+		}
+	}
+	for _, name := range getTrackedNames(node, ns+"/"+featureAnnotationsAnnotation) {
+		if value, ok := node.Annotations[name]; ok {
+			setValue(&nodeSnapshot.Annotations, name, value)
+		}
+	}
+	for _, name := range getTrackedNames(node, ns+"/"+extendedResourcesAnnotation) {
+		if quantity, ok := node.Status.Capacity[corev1.ResourceName(name)]; ok {
+			if nodeSnapshot.ExtendedResources == nil {
+				nodeSnapshot.ExtendedResources = make(map[string]resource.Quantity)
+			}
+			nodeSnapshot.ExtendedResources[name] = quantity
+		}
+	}
+	if value := node.Annotations[ns+"/"+taintsAnnotation]; value != "" {
+		tracked := strings.Split(value, ",")
+		for _, taint := range node.Spec.Taints {
+			if slices.Contains(tracked, taint.ToString()) {
+				nodeSnapshot.Taints = append(nodeSnapshot.Taints, taint)
+			}
+		}
+	}
+	return nodeSnapshot
+}
+
+// filterNodeSnapshot returns the part of a recorded node snapshot that the
+// nfd-master of the instance may have set: what the NFD annotations of the
+// snapshot list, in the feature namespaces or the extra label namespaces of
+// the CR
+func filterNodeSnapshot(nodeSnapshot NodeSnapshot, nfdInstance *nfdv1.NodeFeatureDiscovery) NodeSnapshot {
+	recorded := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Labels: nodeSnapshot.Labels, Annotations: nodeSnapshot.Annotations},
+		Spec:       corev1.NodeSpec{Taints: nodeSnapshot.Taints},
+		Status:     corev1.NodeStatus{Capacity: make(corev1.ResourceList, len(nodeSnapshot.ExtendedResources))},
+	}
+	for name, quantity := range nodeSnapshot.ExtendedResources {
+		recorded.Status.Capacity[corev1.ResourceName(name)] = quantity
+	}
+	filtered := getNodeSnapshot(&recorded, nfdInstance.Spec.Instance)
+
+	isAllowed := func(name string) bool { return isAllowedNs(name, nfdInstance.Spec.ExtraLabelNs) }
+	maps.DeleteFunc(filtered.Labels, func(name, _ string) bool { return !isAllowed(name) })
+	maps.DeleteFunc(filtered.Annotations, func(name, _ string) bool {
+		return !isAllowed(name) && !strings.HasPrefix(name, getAnnotationNs(nfdInstance.Spec.Instance)+"/")
+	})
+	maps.DeleteFunc(filtered.ExtendedResources, func(name string, _ resource.Quantity) bool { return !isAllowed(name) })
+	filtered.Taints = slices.DeleteFunc(filtered.Taints, func(taint corev1.Taint) bool { return !isAllowed(taint.Key) })
+	return filtered
+}
+
+// isAllowedNs reports whether nfd-master may set a label, annotation,
+// extended resource or taint of that name: its namespace is a feature or
+// profile namespace, or an extra label namespace outside of the Kubernetes
+// ones
+func isAllowedNs(name string, extraLabelNs []string) bool {
+	ns, _, found := strings.Cut(name, "/")
+	if !found {
+		return false
+	}
+	for _, allowed := range []string{featureNs, profileNs} {
+		if ns == allowed || strings.HasSuffix(ns, "."+allowed) {
+			return true
+		}
+	}
+	for _, denied := range []string{"kubernetes.io", "k8s.io"} {
+		if ns == denied || strings.HasSuffix(ns, "."+denied) {
+			return false
+		}
+	}
+	return slices.Contains(extraLabelNs, ns)
+}
+
+func getAnnotationNs(instance string) string {
+	if instance == "" {
+		return annotationNs
+	}
+	return instance + "." + annotationNs
+}
+
+// getTrackedNames returns the names listed in a tracking annotation of
+// nfd-master. Names of the default feature namespace are listed without it
+func getTrackedNames(node *corev1.Node, annotation string) []string {
+	value := node.Annotations[annotation]
+	if value == "" {
+		return nil
+	}
+	names := strings.Split(value, ",")
+	for i, name := range names {
+		if !strings.Contains(name, "/") {
+			names[i] = featureNs + "/" + name
+		}
+	}
+	return names
+}
+
+func setValue(m *map[string]string, key, value string) {
+	if *m == nil {
+		*m = make(map[string]string)
+	}
+	(*m)[key] = value
+}
+
+// encode returns the gzipped JSON of the snapshot, so that the NFD labels of
+// large clusters fit in a single ConfigMap
+func encode(nodes map[string]NodeSnapshot) ([]byte, error) {
+	buf := bytes.Buffer{}
+	w := gzip.NewWriter(&buf)
+	err := json.NewEncoder(w).Encode(nodes)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte) (map[string]NodeSnapshot, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	nodes := map[string]NodeSnapshot{}
+	err = json.Unmarshal(raw, &nodes)
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
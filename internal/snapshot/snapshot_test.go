@@ -0,0 +1,408 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/client"
+)
+
+// newNode returns a node labelled by the default NFD instance and by the
+// blue one
+func newNode(name string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"kubernetes.io/hostname":                     name,
+				"feature.node.kubernetes.io/cpu-model.id":    "85",
+				"feature.node.kubernetes.io/kernel-version":  "6.1",
+				"vendor.example.com/gpu":                     "true",
+				"feature.node.kubernetes.io/blue-only-label": "true",
+			},
+			Annotations: map[string]string{
+				"nfd.node.kubernetes.io/feature-labels":           "cpu-model.id,kernel-version,vendor.example.com/gpu",
+				"nfd.node.kubernetes.io/feature-annotations":      "cpu-cores",
+				"nfd.node.kubernetes.io/extended-resources":       "cpu-sgx",
+				"nfd.node.kubernetes.io/taints":                   "feature.node.kubernetes.io/gpu=true:NoSchedule",
+				"nfd.node.kubernetes.io/master.version":           "v0.14.6",
+				"feature.node.kubernetes.io/cpu-cores":            "8",
+				"blue.nfd.node.kubernetes.io/feature-labels":      "blue-only-label",
+				"node.alpha.kubernetes.io/ttl":                    "0",
+				"blue.nfd.node.kubernetes.io/feature-annotations": "",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{
+				{Key: "feature.node.kubernetes.io/gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:                   resource.MustParse("8"),
+				"feature.node.kubernetes.io/cpu-sgx": resource.MustParse("10"),
+			},
+		},
+	}
+}
+
+var expectedNodeSnapshot = NodeSnapshot{
+	Labels: map[string]string{
+		"feature.node.kubernetes.io/cpu-model.id":   "85",
+		"feature.node.kubernetes.io/kernel-version": "6.1",
+		"vendor.example.com/gpu":                    "true",
+	},
+	Annotations: map[string]string{
+		"nfd.node.kubernetes.io/feature-labels":      "cpu-model.id,kernel-version,vendor.example.com/gpu",
+		"nfd.node.kubernetes.io/feature-annotations": "cpu-cores",
+		"nfd.node.kubernetes.io/extended-resources":  "cpu-sgx",
+		"nfd.node.kubernetes.io/taints":              "feature.node.kubernetes.io/gpu=true:NoSchedule",
+		"nfd.node.kubernetes.io/master.version":      "v0.14.6",
+		"feature.node.kubernetes.io/cpu-cores":       "8",
+	},
+	ExtendedResources: map[string]resource.Quantity{
+		"feature.node.kubernetes.io/cpu-sgx": resource.MustParse("10"),
+	},
+	Taints: []corev1.Taint{
+		{Key: "feature.node.kubernetes.io/gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+	},
+}
+
+var _ = Describe("getNodeSnapshot", func() {
+	It("only what the default instance manages is part of the snapshot", func() {
+		node := newNode("node-1")
+
+		Expect(getNodeSnapshot(&node, "")).To(Equal(expectedNodeSnapshot))
+	})
+
+	It("only what the blue instance manages is part of the snapshot", func() {
+		node := newNode("node-1")
+
+		Expect(getNodeSnapshot(&node, "blue")).To(Equal(NodeSnapshot{
+			Labels: map[string]string{
+				"feature.node.kubernetes.io/blue-only-label": "true",
+			},
+			Annotations: map[string]string{
+				"blue.nfd.node.kubernetes.io/feature-labels":      "blue-only-label",
+				"blue.nfd.node.kubernetes.io/feature-annotations": "",
+			},
+		}))
+	})
+
+	It("node not labelled by NFD", func() {
+		node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+
+		nodeSnapshot := getNodeSnapshot(&node, "")
+		Expect(nodeSnapshot.isEmpty()).To(BeTrue())
+	})
+})
+
+var _ = Describe("SetSnapshotConfigMapAsDesired", func() {
+	var (
+		ctrl        *gomock.Controller
+		clnt        *client.MockClient
+		snapshotAPI SnapshotAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		snapshotAPI = NewSnapshotAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+	}
+
+	It("snapshot already taken for the prune", func() {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PruneIDAnnotation: "1234"}},
+			BinaryData: map[string][]byte{SnapshotKey: []byte("previous")},
+		}
+
+		err := snapshotAPI.SetSnapshotConfigMapAsDesired(ctx, &nfdCR, "1234", &cm)
+		Expect(err).To(BeNil())
+		Expect(cm.BinaryData[SnapshotKey]).To(Equal([]byte("previous")))
+	})
+
+	It("failure to list nodes", func() {
+		clnt.EXPECT().List(ctx, gomock.Any()).Return(fmt.Errorf("some error"))
+
+		err := snapshotAPI.SetSnapshotConfigMapAsDesired(ctx, &nfdCR, "1234", &corev1.ConfigMap{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("snapshot of a previous prune is replaced", func() {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PruneIDAnnotation: "0000"}},
+			BinaryData: map[string][]byte{SnapshotKey: []byte("previous")},
+		}
+		clnt.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, list *corev1.NodeList, _ ...ctrlclient.ListOption) error {
+				list.Items = []corev1.Node{newNode("node-1"), {ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}}
+				return nil
+			})
+
+		err := snapshotAPI.SetSnapshotConfigMapAsDesired(ctx, &nfdCR, "1234", &cm)
+		Expect(err).To(BeNil())
+		Expect(cm.OwnerReferences).To(BeEmpty())
+		Expect(cm.Annotations).To(Equal(map[string]string{PruneIDAnnotation: "1234"}))
+		Expect(cm.Labels).To(HaveKeyWithValue(OwnerLabel, "nfd-instance"))
+		nodes, err := decode(cm.BinaryData[SnapshotKey])
+		Expect(err).To(BeNil())
+		Expect(nodes).To(HaveLen(1))
+		Expect(nodes["node-1"].Labels).To(Equal(expectedNodeSnapshot.Labels))
+		Expect(nodes["node-1"].Annotations).To(Equal(expectedNodeSnapshot.Annotations))
+		Expect(nodes["node-1"].Taints).To(Equal(expectedNodeSnapshot.Taints))
+		Expect(nodes["node-1"].ExtendedResources).To(HaveKey("feature.node.kubernetes.io/cpu-sgx"))
+	})
+})
+
+var _ = Describe("ListSnapshots", func() {
+	var (
+		ctrl        *gomock.Controller
+		clnt        *client.MockClient
+		snapshotAPI SnapshotAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		snapshotAPI = NewSnapshotAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-instance"},
+	}
+	newSnapshot := func(name string, age time.Duration) corev1.ConfigMap {
+		return corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+		}
+	}
+
+	It("snapshots are returned from the newest to the oldest", func() {
+		oldest := newSnapshot("nfd-snapshot-a", time.Hour)
+		newest := newSnapshot("nfd-snapshot-b", time.Minute)
+		middle := newSnapshot("nfd-snapshot-c", 10*time.Minute)
+		clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.InNamespace("test-namespace"), ctrlclient.MatchingLabels{OwnerLabel: "nfd-instance"}).DoAndReturn(
+			func(_ context.Context, list *corev1.ConfigMapList, _ ...ctrlclient.ListOption) error {
+				list.Items = []corev1.ConfigMap{oldest, newest, middle}
+				return nil
+			})
+
+		res, err := snapshotAPI.ListSnapshots(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(res).To(Equal([]corev1.ConfigMap{newest, middle, oldest}))
+	})
+
+	It("error flow", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := snapshotAPI.ListSnapshots(ctx, &nfdCR)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("RestoreSnapshot", func() {
+	var (
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		statusWriter *client.MockStatusWriter
+		snapshotAPI  SnapshotAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
+		snapshotAPI = NewSnapshotAPI(clnt, scheme)
+	})
+
+	ctx := context.Background()
+	namespace := "test-namespace"
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nfd-instance"},
+		Spec:       nfdv1.NodeFeatureDiscoverySpec{ExtraLabelNs: []string{"vendor.example.com"}},
+	}
+	newSnapshotCM := func(nodes map[string]NodeSnapshot) corev1.ConfigMap {
+		data, err := encode(nodes)
+		Expect(err).To(BeNil())
+		return corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"app": "nfd", OwnerLabel: "nfd-instance"},
+				Annotations: map[string]string{PruneIDAnnotation: "1234"},
+			},
+			BinaryData: map[string][]byte{SnapshotKey: data},
+		}
+	}
+	snapshotCM := func() corev1.ConfigMap {
+		return newSnapshotCM(map[string]NodeSnapshot{"node-1": expectedNodeSnapshot, "node-2": expectedNodeSnapshot})
+	}
+
+	It("failure to get the configmap", func() {
+		clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-snapshot"}, gomock.Any()).Return(fmt.Errorf("some error"))
+
+		_, err := snapshotAPI.RestoreSnapshot(ctx, &nfdCR, "nfd-snapshot")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("configmap is not a snapshot", func() {
+		clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-snapshot"}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ ctrlclient.ObjectKey, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				*cm = snapshotCM()
+				cm.BinaryData = nil
+				return nil
+			})
+
+		_, err := snapshotAPI.RestoreSnapshot(ctx, &nfdCR, "nfd-snapshot")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("configmap not saved for the CR is rejected", func(mutate func(cm *corev1.ConfigMap)) {
+		clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-snapshot"}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ ctrlclient.ObjectKey, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				*cm = snapshotCM()
+				mutate(cm)
+				return nil
+			})
+
+		restored, err := snapshotAPI.RestoreSnapshot(ctx, &nfdCR, "nfd-snapshot")
+		Expect(err).To(MatchError("configmap test-namespace/nfd-snapshot is not a snapshot of nfd-instance"))
+		Expect(restored).To(Equal(0))
+	},
+		Entry("owner label missing", func(cm *corev1.ConfigMap) { delete(cm.Labels, OwnerLabel) }),
+		Entry("snapshot of another CR", func(cm *corev1.ConfigMap) { cm.Labels[OwnerLabel] = "other-instance" }),
+		Entry("prune ID missing", func(cm *corev1.ConfigMap) { cm.Annotations = nil }),
+	)
+
+	It("keys not managed by nfd-master are not restored", func() {
+		nodeSnapshot := NodeSnapshot{
+			Labels: map[string]string{
+				"feature.node.kubernetes.io/cpu-model.id": "85",
+				"feature.node.kubernetes.io/not-tracked":  "true",
+				"node-role.kubernetes.io/control-plane":   "",
+				"other.example.com/not-in-extra-label-ns": "true",
+			},
+			Annotations: map[string]string{
+				"nfd.node.kubernetes.io/feature-labels":     "cpu-model.id,not-listed,node-role.kubernetes.io/control-plane,other.example.com/not-in-extra-label-ns",
+				"nfd.node.kubernetes.io/extended-resources": "pods",
+				"nfd.node.kubernetes.io/taints":             "node.kubernetes.io/unschedulable:NoSchedule",
+				"node.alpha.kubernetes.io/ttl":              "0",
+			},
+			ExtendedResources: map[string]resource.Quantity{"pods": resource.MustParse("1000")},
+			Taints:            []corev1.Taint{{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule}},
+		}
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-snapshot"}, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ ctrlclient.ObjectKey, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					*cm = newSnapshotCM(map[string]NodeSnapshot{"node-1": nodeSnapshot})
+					return nil
+				}),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Name: "node-1"}, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ ctrlclient.ObjectKey, node *corev1.Node, _ ...ctrlclient.GetOption) error {
+					node.Name = "node-1"
+					return nil
+				}),
+			clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, node *corev1.Node, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+					Expect(node.Labels).To(Equal(map[string]string{"feature.node.kubernetes.io/cpu-model.id": "85"}))
+					Expect(node.Annotations).To(Equal(map[string]string{
+						"nfd.node.kubernetes.io/feature-labels":     nodeSnapshot.Annotations["nfd.node.kubernetes.io/feature-labels"],
+						"nfd.node.kubernetes.io/extended-resources": "pods",
+						"nfd.node.kubernetes.io/taints":             "node.kubernetes.io/unschedulable:NoSchedule",
+					}))
+					Expect(node.Spec.Taints).To(BeEmpty())
+					return nil
+				}),
+		)
+
+		restored, err := snapshotAPI.RestoreSnapshot(ctx, &nfdCR, "nfd-snapshot")
+		Expect(err).To(BeNil())
+		Expect(restored).To(Equal(1))
+	})
+
+	It("pruned node is restored, deleted node is skipped", func() {
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-snapshot"}, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ ctrlclient.ObjectKey, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					*cm = snapshotCM()
+					return nil
+				}),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Name: "node-1"}, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ ctrlclient.ObjectKey, node *corev1.Node, _ ...ctrlclient.GetOption) error {
+					node.Name = "node-1"
+					node.Labels = map[string]string{"kubernetes.io/hostname": "node-1"}
+					node.Spec.Taints = []corev1.Taint{{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule}}
+					return nil
+				}),
+			clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, node *corev1.Node, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+					Expect(node.Labels).To(HaveKeyWithValue("kubernetes.io/hostname", "node-1"))
+					for key, value := range expectedNodeSnapshot.Labels {
+						Expect(node.Labels).To(HaveKeyWithValue(key, value))
+					}
+					Expect(node.Annotations).To(Equal(expectedNodeSnapshot.Annotations))
+					Expect(node.Spec.Taints).To(HaveLen(2))
+					return nil
+				}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, node *corev1.Node, _ ctrlclient.Patch, _ ...ctrlclient.SubResourcePatchOption) error {
+					Expect(node.Status.Capacity).To(HaveKey(corev1.ResourceName("feature.node.kubernetes.io/cpu-sgx")))
+					return nil
+				}),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Name: "node-2"}, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "node-2")),
+		)
+
+		restored, err := snapshotAPI.RestoreSnapshot(ctx, &nfdCR, "nfd-snapshot")
+		Expect(err).To(BeNil())
+		Expect(restored).To(Equal(1))
+	})
+
+	It("failure to patch a node", func() {
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: "nfd-snapshot"}, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ ctrlclient.ObjectKey, cm *corev1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					*cm = snapshotCM()
+					return nil
+				}),
+			clnt.EXPECT().Get(ctx, ctrlclient.ObjectKey{Name: "node-1"}, gomock.Any()).Return(nil),
+			clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error")),
+		)
+
+		restored, err := snapshotAPI.RestoreSnapshot(ctx, &nfdCR, "nfd-snapshot")
+		Expect(err).To(HaveOccurred())
+		Expect(restored).To(Equal(0))
+	})
+})
@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reasons of the failures found on the pods of a component
+const (
+	conditionPodImagePullBackOff             = "ImagePullBackOff"
+	conditionPodCrashLoopBackOff             = "CrashLoopBackOff"
+	conditionPodUnschedulable                = "Unschedulable"
+	conditionServiceAccountNotFound          = "ServiceAccountNotFound"
+	conditionSecurityContextConstraintDenied = "SecurityContextConstraintDenied"
+	conditionFailedCreatePods                = "FailedCreatePods"
+
+	// maxReportedNodes bounds the number of nodes listed in a message, so
+	// that a failure on a large cluster does not overflow the condition
+	maxReportedNodes = 5
+)
+
+// podFailure explains why the pods of a component are not running
+type podFailure struct {
+	reason  string
+	message string
+	// degraded is false for the failures that go away without any action,
+	// e.g. a pod waiting for a node with enough free resources
+	degraded bool
+}
+
+// getState turns the failure into the state of the component, a failure
+// that is not degraded keeps the status computed from the workload
+func (f *podFailure) getState(workloadStatus string) *componentState {
+	if f.degraded {
+		return degradedState(f.reason, f.message)
+	}
+	return &componentState{status: workloadStatus, reason: f.reason, message: f.message}
+}
+
+// diagnosePodFailure looks at the pods of a Deployment or of a DaemonSet that
+// is not available. When there are no pods at all, it checks why they could
+// not be created. It returns nil when nothing more specific than the state of
+// the workload can be told, including when the pods can not be listed
+func (sh *statusHelper) diagnosePodFailure(ctx context.Context, namespace string, selector *metav1.LabelSelector,
+	podSpec *corev1.PodSpec, replicaFailure string) *podFailure {
+
+	pods, err := sh.podAPI.ListPods(ctx, namespace, selector)
+	if err != nil {
+		return nil
+	}
+	if len(pods) > 0 {
+		return diagnosePods(pods)
+	}
+	return sh.diagnosePodCreation(ctx, namespace, podSpec, replicaFailure)
+}
+
+// diagnosePodCreation explains why a component does not have any pod: the
+// service account of the pods does not exist, or the admission of the pods
+// failed, e.g. because no SecurityContextConstraints of OpenShift allows them
+func (sh *statusHelper) diagnosePodCreation(ctx context.Context, namespace string, podSpec *corev1.PodSpec, replicaFailure string) *podFailure {
+	if saName := podSpec.ServiceAccountName; saName != "" {
+		_, err := sh.serviceAccountAPI.GetServiceAccount(ctx, namespace, saName)
+		if apierrors.IsNotFound(err) {
+			return &podFailure{
+				reason:   conditionServiceAccountNotFound,
+				message:  fmt.Sprintf("service account %s/%s of the pods not found", namespace, saName),
+				degraded: true,
+			}
+		}
+	}
+	if replicaFailure == "" {
+		return nil
+	}
+	reason := conditionFailedCreatePods
+	if strings.Contains(replicaFailure, "security context constraint") {
+		reason = conditionSecurityContextConstraintDenied
+	}
+	return &podFailure{reason: reason, message: replicaFailure, degraded: true}
+}
+
+// getReplicaFailure returns the message of the ReplicaFailure condition of a
+// deployment, which reports the pods rejected at admission
+func getReplicaFailure(dep *appsv1.Deployment) string {
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsv1.DeploymentReplicaFailure && cond.Status == corev1.ConditionTrue {
+			return cond.Message
+		}
+	}
+	return ""
+}
+
+// failedPods groups the pods that fail for the same reason
+type failedPods struct {
+	// detail is the explanation given for the first pod
+	detail string
+	nodes  []string
+	pods   []string
+}
+
+// diagnosePods classifies the pods that are not running and returns the
+// most severe failure, naming the nodes of the affected pods
+func diagnosePods(pods []corev1.Pod) *podFailure {
+	failures := map[string]*failedPods{}
+	for i := range pods {
+		reason, detail := getPodFailure(&pods[i])
+		if reason == "" {
+			continue
+		}
+		failure, ok := failures[reason]
+		if !ok {
+			failure = &failedPods{detail: detail}
+			failures[reason] = failure
+		}
+		if node := getPodNode(&pods[i]); node != "" {
+			failure.nodes = append(failure.nodes, node)
+		}
+		failure.pods = append(failure.pods, pods[i].Name)
+	}
+	for _, reason := range []string{conditionPodImagePullBackOff, conditionPodCrashLoopBackOff, conditionPodUnschedulable} {
+		failure, ok := failures[reason]
+		if !ok {
+			continue
+		}
+		return &podFailure{
+			reason:   reason,
+			message:  fmt.Sprintf("%s: %s", failure.describe(), failure.detail),
+			degraded: reason != conditionPodUnschedulable,
+		}
+	}
+	return nil
+}
+
+// describe names the nodes of the failed pods or, for the pods not bound
+// to a node, the pods themselves
+func (f *failedPods) describe() string {
+	if len(f.nodes) > 0 {
+		nodes := slices.Clone(f.nodes)
+		slices.Sort(nodes)
+		return "nodes " + joinNames(slices.Compact(nodes))
+	}
+	return "pods " + joinNames(f.pods)
+}
+
+func joinNames(names []string) string {
+	if len(names) <= maxReportedNodes {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxReportedNodes], ", "), len(names)-maxReportedNodes)
+}
+
+// getPodFailure returns the reason why a pod is not running along with the
+// details given by the kubelet or by the scheduler, or an empty reason
+func getPodFailure(pod *corev1.Pod) (string, string) {
+	if pod.DeletionTimestamp != nil {
+		return "", ""
+	}
+	statuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting == nil {
+			continue
+		}
+		switch cs.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+			return conditionPodImagePullBackOff, fmt.Sprintf("container %s: %s", cs.Name, cs.State.Waiting.Message)
+		case "CrashLoopBackOff":
+			return conditionPodCrashLoopBackOff, getCrashLoopDetail(&cs)
+		}
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+			return conditionPodUnschedulable, cond.Message
+		}
+	}
+	return "", ""
+}
+
+// getCrashLoopDetail reports how the last run of a crash looping container
+// ended, along with the termination message it left
+func getCrashLoopDetail(cs *corev1.ContainerStatus) string {
+	terminated := cs.LastTerminationState.Terminated
+	if terminated == nil {
+		return fmt.Sprintf("container %s is restarting", cs.Name)
+	}
+	detail := fmt.Sprintf("container %s exited with code %d", cs.Name, terminated.ExitCode)
+	if message := strings.TrimSpace(terminated.Message); message != "" {
+		return fmt.Sprintf("%s: %s", detail, message)
+	}
+	if terminated.Reason != "" {
+		return fmt.Sprintf("%s (%s)", detail, terminated.Reason)
+	}
+	return detail
+}
+
+// getPodNode returns the node of a pod. A DaemonSet pod that is not
+// scheduled yet targets its node through a node affinity on metadata.name
+func getPodNode(pod *corev1.Pod) string {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName
+	}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil ||
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, field := range term.MatchFields {
+			if field.Key == metav1.ObjectNameField && field.Operator == corev1.NodeSelectorOpIn && len(field.Values) == 1 {
+				return field.Values[0]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/daemonset"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/deployment"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/pod"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/serviceaccount"
+)
+
+func imagePullPod(name, node string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "nfd-worker",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: "Back-off pulling image \"nfd:missing\"",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func crashLoopPod(name, node string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "nfd-worker",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Reason:   "Error",
+							Message:  "failed to read config\n",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// unschedulablePod is a DaemonSet pod pinned to its node by node affinity
+func unschedulablePod(name, node string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PodSpec{
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchFields: []corev1.NodeSelectorRequirement{
+									{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{node}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient memory.",
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("diagnosePods", func() {
+	It("running pods are not reported", func() {
+		pods := []corev1.Pod{{Spec: corev1.PodSpec{NodeName: "node-1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}}
+		Expect(diagnosePods(pods)).To(BeNil())
+	})
+
+	It("image pull failure names the nodes", func() {
+		pods := []corev1.Pod{imagePullPod("nfd-worker-b", "node-2"), imagePullPod("nfd-worker-a", "node-1")}
+		Expect(diagnosePods(pods)).To(Equal(&podFailure{
+			reason:   conditionPodImagePullBackOff,
+			message:  "nodes node-1, node-2: container nfd-worker: Back-off pulling image \"nfd:missing\"",
+			degraded: true,
+		}))
+	})
+
+	It("crash loop reports the last termination message", func() {
+		pods := []corev1.Pod{crashLoopPod("nfd-worker-a", "node-1")}
+		Expect(diagnosePods(pods)).To(Equal(&podFailure{
+			reason:   conditionPodCrashLoopBackOff,
+			message:  "nodes node-1: container nfd-worker exited with code 1: failed to read config",
+			degraded: true,
+		}))
+	})
+
+	It("crash loop without termination message reports the termination reason", func() {
+		p := crashLoopPod("nfd-worker-a", "node-1")
+		p.Status.ContainerStatuses[0].LastTerminationState.Terminated.Message = ""
+		Expect(diagnosePods([]corev1.Pod{p}).message).To(Equal("nodes node-1: container nfd-worker exited with code 1 (Error)"))
+	})
+
+	It("unschedulable daemonset pods name the nodes they target and are not degraded", func() {
+		pods := []corev1.Pod{unschedulablePod("nfd-worker-a", "node-1")}
+		Expect(diagnosePods(pods)).To(Equal(&podFailure{
+			reason:  conditionPodUnschedulable,
+			message: "nodes node-1: 0/3 nodes are available: 3 Insufficient memory.",
+		}))
+	})
+
+	It("unschedulable pods without target node are named", func() {
+		p := unschedulablePod("nfd-master-a", "")
+		p.Spec.Affinity = nil
+		Expect(diagnosePods([]corev1.Pod{p}).message).To(Equal("pods nfd-master-a: 0/3 nodes are available: 3 Insufficient memory."))
+	})
+
+	It("image pull failure is reported before the other failures", func() {
+		pods := []corev1.Pod{
+			unschedulablePod("nfd-worker-a", "node-1"),
+			crashLoopPod("nfd-worker-b", "node-2"),
+			imagePullPod("nfd-worker-c", "node-3"),
+		}
+		Expect(diagnosePods(pods).reason).To(Equal(conditionPodImagePullBackOff))
+	})
+
+	It("pods being deleted are ignored", func() {
+		p := imagePullPod("nfd-worker-a", "node-1")
+		p.DeletionTimestamp = &metav1.Time{}
+		Expect(diagnosePods([]corev1.Pod{p})).To(BeNil())
+	})
+
+	It("the number of nodes in the message is bounded", func() {
+		pods := make([]corev1.Pod, 0, 7)
+		for i := 1; i <= 7; i++ {
+			pods = append(pods, imagePullPod(fmt.Sprintf("nfd-worker-%d", i), fmt.Sprintf("node-%d", i)))
+		}
+		Expect(diagnosePods(pods).message).To(HavePrefix("nodes node-1, node-2, node-3, node-4, node-5 and 2 more: "))
+	})
+})
+
+var _ = Describe("getDaemonSetNotAvailableState and getDeploymentNotAvailableState with pod diagnosis", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockDeployment *deployment.MockDeploymentAPI
+		mockDS         *daemonset.MockDaemonsetAPI
+		mockPod        *pod.MockPodAPI
+		mockSA         *serviceaccount.MockServiceAccountAPI
+		h              statusHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		mockPod = pod.NewMockPodAPI(ctrl)
+		mockSA = serviceaccount.NewMockServiceAccountAPI(ctrl)
+		h = newStatusHelperAPI(mockDeployment, mockDS, nil, mockPod, mockSA, nil, "", "", nil)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+		},
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nfd-worker"}}
+	progressingDS := &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Selector: selector,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{ServiceAccountName: "nfd-worker"},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 2,
+			CurrentNumberScheduled: 2,
+			NumberReady:            1,
+		},
+	}
+	unavailableDeployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Selector: selector,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{ServiceAccountName: "nfd-master"},
+			},
+		},
+	}
+
+	It("crash looping worker pods degrade the worker", func() {
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(progressingDS, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return([]corev1.Pod{crashLoopPod("nfd-worker-a", "node-1")}, nil)
+
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(degradedState(conditionPodCrashLoopBackOff,
+			"nodes node-1: container nfd-worker exited with code 1: failed to read config")))
+	})
+
+	It("unschedulable worker pods keep the worker progressing", func() {
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(progressingDS, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return([]corev1.Pod{unschedulablePod("nfd-worker-a", "node-1")}, nil)
+
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(progressingState(conditionPodUnschedulable,
+			"nodes node-1: 0/3 nodes are available: 3 Insufficient memory.")))
+	})
+
+	It("failure to list the pods falls back to the daemonset state", func() {
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(progressingDS, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return(nil, fmt.Errorf("some error"))
+
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(progressingState(conditionNFDWorkerDaemonSetProgressing, "ds is progressing")))
+	})
+
+	It("missing service account of the worker pods", func() {
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(progressingDS, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return(nil, nil)
+		mockSA.EXPECT().GetServiceAccount(ctx, nfdCR.Namespace, "nfd-worker").
+			Return(nil, apierrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, "nfd-worker"))
+
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(degradedState(conditionServiceAccountNotFound,
+			"service account test-namespace/nfd-worker of the pods not found")))
+	})
+
+	It("image pull failure of a worker pool", func() {
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker--gpu").Return(progressingDS, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return([]corev1.Pod{imagePullPod("nfd-worker--gpu-a", "node-1")}, nil)
+
+		resState := h.getWorkerPoolNotAvailableState(ctx, &nfdCR, "gpu")
+		Expect(resState).To(Equal(degradedState(conditionPodImagePullBackOff,
+			"worker pool gpu: nodes node-1: container nfd-worker: Back-off pulling image \"nfd:missing\"")))
+	})
+
+	It("master pods denied by the security context constraints", func() {
+		dep := unavailableDeployment.DeepCopy()
+		dep.Status.Conditions = []appsv1.DeploymentCondition{
+			{
+				Type:    appsv1.DeploymentReplicaFailure,
+				Status:  corev1.ConditionTrue,
+				Reason:  "FailedCreate",
+				Message: "pods \"nfd-master-1234-\" is forbidden: unable to validate against any security context constraint",
+			},
+		}
+		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(dep, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return(nil, nil)
+		mockSA.EXPECT().GetServiceAccount(ctx, nfdCR.Namespace, "nfd-master").Return(&corev1.ServiceAccount{}, nil)
+
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(degradedState(conditionSecurityContextConstraintDenied,
+			"pods \"nfd-master-1234-\" is forbidden: unable to validate against any security context constraint")))
+	})
+
+	It("master without pods and without known cause", func() {
+		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(unavailableDeployment, nil)
+		mockPod.EXPECT().ListPods(ctx, nfdCR.Namespace, selector).Return(nil, nil)
+		mockSA.EXPECT().GetServiceAccount(ctx, nfdCR.Namespace, "nfd-master").Return(&corev1.ServiceAccount{}, nil)
+
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(degradedState(conditionNFDMasterDeploymentDegraded, "number of available pods is 0")))
+	})
+})
@@ -11,10 +11,12 @@ package status
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v10 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	v1 "k8s.io/api/batch/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v11 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
 )
 
 // MockStatusAPI is a mock of StatusAPI interface.
@@ -40,25 +42,39 @@ func (m *MockStatusAPI) EXPECT() *MockStatusAPIMockRecorder {
 	return m.recorder
 }
 
-// AreConditionsEqual mocks base method.
-func (m *MockStatusAPI) AreConditionsEqual(prevConditions, newConditions []v1.Condition) bool {
+// GetComponentsCondition mocks base method.
+func (m *MockStatusAPI) GetComponentsCondition(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) v10.Condition {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AreConditionsEqual", prevConditions, newConditions)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetComponentsCondition", ctx, nfdInstance)
+	ret0, _ := ret[0].(v10.Condition)
 	return ret0
 }
 
-// AreConditionsEqual indicates an expected call of AreConditionsEqual.
-func (mr *MockStatusAPIMockRecorder) AreConditionsEqual(prevConditions, newConditions any) *gomock.Call {
+// GetComponentsCondition indicates an expected call of GetComponentsCondition.
+func (mr *MockStatusAPIMockRecorder) GetComponentsCondition(ctx, nfdInstance any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreConditionsEqual", reflect.TypeOf((*MockStatusAPI)(nil).AreConditionsEqual), prevConditions, newConditions)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentsCondition", reflect.TypeOf((*MockStatusAPI)(nil).GetComponentsCondition), ctx, nfdInstance)
+}
+
+// GetComponentsStatus mocks base method.
+func (m *MockStatusAPI) GetComponentsStatus(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) []v11.ComponentStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentsStatus", ctx, nfdInstance)
+	ret0, _ := ret[0].([]v11.ComponentStatus)
+	return ret0
+}
+
+// GetComponentsStatus indicates an expected call of GetComponentsStatus.
+func (mr *MockStatusAPIMockRecorder) GetComponentsStatus(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentsStatus", reflect.TypeOf((*MockStatusAPI)(nil).GetComponentsStatus), ctx, nfdInstance)
 }
 
 // GetConditions mocks base method.
-func (m *MockStatusAPI) GetConditions(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) []v1.Condition {
+func (m *MockStatusAPI) GetConditions(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) []v10.Condition {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetConditions", ctx, nfdInstance)
-	ret0, _ := ret[0].([]v1.Condition)
+	ret0, _ := ret[0].([]v10.Condition)
 	return ret0
 }
 
@@ -68,6 +84,76 @@ func (mr *MockStatusAPIMockRecorder) GetConditions(ctx, nfdInstance any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConditions", reflect.TypeOf((*MockStatusAPI)(nil).GetConditions), ctx, nfdInstance)
 }
 
+// GetOperandNamespaceConflictConditions mocks base method.
+func (m *MockStatusAPI) GetOperandNamespaceConflictConditions(nfdInstance, owner *v11.NodeFeatureDiscovery) []v10.Condition {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperandNamespaceConflictConditions", nfdInstance, owner)
+	ret0, _ := ret[0].([]v10.Condition)
+	return ret0
+}
+
+// GetOperandNamespaceConflictConditions indicates an expected call of GetOperandNamespaceConflictConditions.
+func (mr *MockStatusAPIMockRecorder) GetOperandNamespaceConflictConditions(nfdInstance, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperandNamespaceConflictConditions", reflect.TypeOf((*MockStatusAPI)(nil).GetOperandNamespaceConflictConditions), nfdInstance, owner)
+}
+
+// GetPruneCondition mocks base method.
+func (m *MockStatusAPI) GetPruneCondition(nfdInstance *v11.NodeFeatureDiscovery, pruneJob *v1.Job) v10.Condition {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPruneCondition", nfdInstance, pruneJob)
+	ret0, _ := ret[0].(v10.Condition)
+	return ret0
+}
+
+// GetPruneCondition indicates an expected call of GetPruneCondition.
+func (mr *MockStatusAPIMockRecorder) GetPruneCondition(nfdInstance, pruneJob any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPruneCondition", reflect.TypeOf((*MockStatusAPI)(nil).GetPruneCondition), nfdInstance, pruneJob)
+}
+
+// GetPruneDelayedCondition mocks base method.
+func (m *MockStatusAPI) GetPruneDelayedCondition(nfdInstance *v11.NodeFeatureDiscovery, pruneAt time.Time) v10.Condition {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPruneDelayedCondition", nfdInstance, pruneAt)
+	ret0, _ := ret[0].(v10.Condition)
+	return ret0
+}
+
+// GetPruneDelayedCondition indicates an expected call of GetPruneDelayedCondition.
+func (mr *MockStatusAPIMockRecorder) GetPruneDelayedCondition(nfdInstance, pruneAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPruneDelayedCondition", reflect.TypeOf((*MockStatusAPI)(nil).GetPruneDelayedCondition), nfdInstance, pruneAt)
+}
+
+// GetWorkerPoolsStatus mocks base method.
+func (m *MockStatusAPI) GetWorkerPoolsStatus(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) []v11.WorkerPoolStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkerPoolsStatus", ctx, nfdInstance)
+	ret0, _ := ret[0].([]v11.WorkerPoolStatus)
+	return ret0
+}
+
+// GetWorkerPoolsStatus indicates an expected call of GetWorkerPoolsStatus.
+func (mr *MockStatusAPIMockRecorder) GetWorkerPoolsStatus(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkerPoolsStatus", reflect.TypeOf((*MockStatusAPI)(nil).GetWorkerPoolsStatus), ctx, nfdInstance)
+}
+
+// MergeConditions mocks base method.
+func (m *MockStatusAPI) MergeConditions(prevConditions, newConditions []v10.Condition, generation int64) []v10.Condition {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeConditions", prevConditions, newConditions, generation)
+	ret0, _ := ret[0].([]v10.Condition)
+	return ret0
+}
+
+// MergeConditions indicates an expected call of MergeConditions.
+func (mr *MockStatusAPIMockRecorder) MergeConditions(prevConditions, newConditions, generation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeConditions", reflect.TypeOf((*MockStatusAPI)(nil).MergeConditions), prevConditions, newConditions, generation)
+}
+
 // MockstatusHelperAPI is a mock of statusHelperAPI interface.
 type MockstatusHelperAPI struct {
 	ctrl     *gomock.Controller
@@ -91,58 +177,157 @@ func (m *MockstatusHelperAPI) EXPECT() *MockstatusHelperAPIMockRecorder {
 	return m.recorder
 }
 
-// getGCNotAvailableConditions mocks base method.
-func (m *MockstatusHelperAPI) getGCNotAvailableConditions(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) []v1.Condition {
+// getDaemonSetComponentStatus mocks base method.
+func (m *MockstatusHelperAPI) getDaemonSetComponentStatus(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery, component, name string) v11.ComponentStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getDaemonSetComponentStatus", ctx, nfdInstance, component, name)
+	ret0, _ := ret[0].(v11.ComponentStatus)
+	return ret0
+}
+
+// getDaemonSetComponentStatus indicates an expected call of getDaemonSetComponentStatus.
+func (mr *MockstatusHelperAPIMockRecorder) getDaemonSetComponentStatus(ctx, nfdInstance, component, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getDaemonSetComponentStatus", reflect.TypeOf((*MockstatusHelperAPI)(nil).getDaemonSetComponentStatus), ctx, nfdInstance, component, name)
+}
+
+// getDeploymentComponentStatus mocks base method.
+func (m *MockstatusHelperAPI) getDeploymentComponentStatus(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery, component, name string) v11.ComponentStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getDeploymentComponentStatus", ctx, nfdInstance, component, name)
+	ret0, _ := ret[0].(v11.ComponentStatus)
+	return ret0
+}
+
+// getDeploymentComponentStatus indicates an expected call of getDeploymentComponentStatus.
+func (mr *MockstatusHelperAPIMockRecorder) getDeploymentComponentStatus(ctx, nfdInstance, component, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getDeploymentComponentStatus", reflect.TypeOf((*MockstatusHelperAPI)(nil).getDeploymentComponentStatus), ctx, nfdInstance, component, name)
+}
+
+// getGCNotAvailableState mocks base method.
+func (m *MockstatusHelperAPI) getGCNotAvailableState(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) *componentState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getGCNotAvailableState", ctx, nfdInstance)
+	ret0, _ := ret[0].(*componentState)
+	return ret0
+}
+
+// getGCNotAvailableState indicates an expected call of getGCNotAvailableState.
+func (mr *MockstatusHelperAPIMockRecorder) getGCNotAvailableState(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getGCNotAvailableState", reflect.TypeOf((*MockstatusHelperAPI)(nil).getGCNotAvailableState), ctx, nfdInstance)
+}
+
+// getMasterNotAvailableState mocks base method.
+func (m *MockstatusHelperAPI) getMasterNotAvailableState(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) *componentState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getMasterNotAvailableState", ctx, nfdInstance)
+	ret0, _ := ret[0].(*componentState)
+	return ret0
+}
+
+// getMasterNotAvailableState indicates an expected call of getMasterNotAvailableState.
+func (mr *MockstatusHelperAPIMockRecorder) getMasterNotAvailableState(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getMasterNotAvailableState", reflect.TypeOf((*MockstatusHelperAPI)(nil).getMasterNotAvailableState), ctx, nfdInstance)
+}
+
+// getNotUpgradeableReason mocks base method.
+func (m *MockstatusHelperAPI) getNotUpgradeableReason(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery, components []v11.ComponentStatus) (string, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getNotUpgradeableReason", ctx, nfdInstance, components)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// getNotUpgradeableReason indicates an expected call of getNotUpgradeableReason.
+func (mr *MockstatusHelperAPIMockRecorder) getNotUpgradeableReason(ctx, nfdInstance, components any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getNotUpgradeableReason", reflect.TypeOf((*MockstatusHelperAPI)(nil).getNotUpgradeableReason), ctx, nfdInstance, components)
+}
+
+// getPresentComponents mocks base method.
+func (m *MockstatusHelperAPI) getPresentComponents(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPresentComponents", ctx, nfdInstance)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// getPresentComponents indicates an expected call of getPresentComponents.
+func (mr *MockstatusHelperAPIMockRecorder) getPresentComponents(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPresentComponents", reflect.TypeOf((*MockstatusHelperAPI)(nil).getPresentComponents), ctx, nfdInstance)
+}
+
+// getTopologyNotAvailableState mocks base method.
+func (m *MockstatusHelperAPI) getTopologyNotAvailableState(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) *componentState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getTopologyNotAvailableState", ctx, nfdInstance)
+	ret0, _ := ret[0].(*componentState)
+	return ret0
+}
+
+// getTopologyNotAvailableState indicates an expected call of getTopologyNotAvailableState.
+func (mr *MockstatusHelperAPIMockRecorder) getTopologyNotAvailableState(ctx, nfdInstance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTopologyNotAvailableState", reflect.TypeOf((*MockstatusHelperAPI)(nil).getTopologyNotAvailableState), ctx, nfdInstance)
+}
+
+// getWorkerConfigNotAvailableState mocks base method.
+func (m *MockstatusHelperAPI) getWorkerConfigNotAvailableState(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) *componentState {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getGCNotAvailableConditions", ctx, nfdInstance)
-	ret0, _ := ret[0].([]v1.Condition)
+	ret := m.ctrl.Call(m, "getWorkerConfigNotAvailableState", ctx, nfdInstance)
+	ret0, _ := ret[0].(*componentState)
 	return ret0
 }
 
-// getGCNotAvailableConditions indicates an expected call of getGCNotAvailableConditions.
-func (mr *MockstatusHelperAPIMockRecorder) getGCNotAvailableConditions(ctx, nfdInstance any) *gomock.Call {
+// getWorkerConfigNotAvailableState indicates an expected call of getWorkerConfigNotAvailableState.
+func (mr *MockstatusHelperAPIMockRecorder) getWorkerConfigNotAvailableState(ctx, nfdInstance any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getGCNotAvailableConditions", reflect.TypeOf((*MockstatusHelperAPI)(nil).getGCNotAvailableConditions), ctx, nfdInstance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWorkerConfigNotAvailableState", reflect.TypeOf((*MockstatusHelperAPI)(nil).getWorkerConfigNotAvailableState), ctx, nfdInstance)
 }
 
-// getMasterNotAvailableConditions mocks base method.
-func (m *MockstatusHelperAPI) getMasterNotAvailableConditions(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) []v1.Condition {
+// getWorkerNotAvailableState mocks base method.
+func (m *MockstatusHelperAPI) getWorkerNotAvailableState(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery) *componentState {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getMasterNotAvailableConditions", ctx, nfdInstance)
-	ret0, _ := ret[0].([]v1.Condition)
+	ret := m.ctrl.Call(m, "getWorkerNotAvailableState", ctx, nfdInstance)
+	ret0, _ := ret[0].(*componentState)
 	return ret0
 }
 
-// getMasterNotAvailableConditions indicates an expected call of getMasterNotAvailableConditions.
-func (mr *MockstatusHelperAPIMockRecorder) getMasterNotAvailableConditions(ctx, nfdInstance any) *gomock.Call {
+// getWorkerNotAvailableState indicates an expected call of getWorkerNotAvailableState.
+func (mr *MockstatusHelperAPIMockRecorder) getWorkerNotAvailableState(ctx, nfdInstance any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getMasterNotAvailableConditions", reflect.TypeOf((*MockstatusHelperAPI)(nil).getMasterNotAvailableConditions), ctx, nfdInstance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWorkerNotAvailableState", reflect.TypeOf((*MockstatusHelperAPI)(nil).getWorkerNotAvailableState), ctx, nfdInstance)
 }
 
-// getTopologyNotAvailableConditions mocks base method.
-func (m *MockstatusHelperAPI) getTopologyNotAvailableConditions(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) []v1.Condition {
+// getWorkerPoolNotAvailableState mocks base method.
+func (m *MockstatusHelperAPI) getWorkerPoolNotAvailableState(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery, poolName string) *componentState {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getTopologyNotAvailableConditions", ctx, nfdInstance)
-	ret0, _ := ret[0].([]v1.Condition)
+	ret := m.ctrl.Call(m, "getWorkerPoolNotAvailableState", ctx, nfdInstance, poolName)
+	ret0, _ := ret[0].(*componentState)
 	return ret0
 }
 
-// getTopologyNotAvailableConditions indicates an expected call of getTopologyNotAvailableConditions.
-func (mr *MockstatusHelperAPIMockRecorder) getTopologyNotAvailableConditions(ctx, nfdInstance any) *gomock.Call {
+// getWorkerPoolNotAvailableState indicates an expected call of getWorkerPoolNotAvailableState.
+func (mr *MockstatusHelperAPIMockRecorder) getWorkerPoolNotAvailableState(ctx, nfdInstance, poolName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTopologyNotAvailableConditions", reflect.TypeOf((*MockstatusHelperAPI)(nil).getTopologyNotAvailableConditions), ctx, nfdInstance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWorkerPoolNotAvailableState", reflect.TypeOf((*MockstatusHelperAPI)(nil).getWorkerPoolNotAvailableState), ctx, nfdInstance, poolName)
 }
 
-// getWorkerNotAvailableConditions mocks base method.
-func (m *MockstatusHelperAPI) getWorkerNotAvailableConditions(ctx context.Context, nfdInstance *v10.NodeFeatureDiscovery) []v1.Condition {
+// getWorkerPoolStatus mocks base method.
+func (m *MockstatusHelperAPI) getWorkerPoolStatus(ctx context.Context, nfdInstance *v11.NodeFeatureDiscovery, poolName string) v11.WorkerPoolStatus {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getWorkerNotAvailableConditions", ctx, nfdInstance)
-	ret0, _ := ret[0].([]v1.Condition)
+	ret := m.ctrl.Call(m, "getWorkerPoolStatus", ctx, nfdInstance, poolName)
+	ret0, _ := ret[0].(v11.WorkerPoolStatus)
 	return ret0
 }
 
-// getWorkerNotAvailableConditions indicates an expected call of getWorkerNotAvailableConditions.
-func (mr *MockstatusHelperAPIMockRecorder) getWorkerNotAvailableConditions(ctx, nfdInstance any) *gomock.Call {
+// getWorkerPoolStatus indicates an expected call of getWorkerPoolStatus.
+func (mr *MockstatusHelperAPIMockRecorder) getWorkerPoolStatus(ctx, nfdInstance, poolName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWorkerNotAvailableConditions", reflect.TypeOf((*MockstatusHelperAPI)(nil).getWorkerNotAvailableConditions), ctx, nfdInstance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWorkerPoolStatus", reflect.TypeOf((*MockstatusHelperAPI)(nil).getWorkerPoolStatus), ctx, nfdInstance, poolName)
 }
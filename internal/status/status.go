@@ -18,15 +18,27 @@ package status
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
 
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/configmap"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/daemonset"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/deployment"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/names"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/pod"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/serviceaccount"
 )
 
 const (
@@ -34,10 +46,16 @@ const (
 	conditionStatusDegraded    = "degrading"
 	conditionStatusAvailable   = "available"
 
+	conditionNFDWorkerConfigMapNotFound    = "NFDWorkerConfigMapNotFound"
+	conditionNFDWorkerConfigMapKeyNotFound = "NFDWorkerConfigMapKeyNotFound"
+
 	conditionFailedGettingNFDWorkerDaemonSet = "FailedGettingNFDWorkerDaemonSet"
 	conditionNFDWorkerDaemonSetDegraded      = "NFDWorkerDaemonSetDegraded"
 	conditionNFDWorkerDaemonSetProgressing   = "NFDWorkerDaemonSetProgressing"
 
+	conditionFailedGettingNFDWorkerPoolDaemonSet = "FailedGettingNFDWorkerPoolDaemonSet"
+	conditionNFDWorkerPoolDaemonSetProgressing   = "NFDWorkerPoolDaemonSetProgressing"
+
 	conditionFailedGettingNFDTopologyDaemonSet = "FailedGettingNFDTopologyDaemonSet"
 	conditionNFDTopologyDaemonSetDegraded      = "NFDTopologyDaemonSetDegraded"
 	conditionNFDTopologyDaemonSetProgressing   = "NFDTopologyDaemonSetProgressing"
@@ -52,6 +70,34 @@ const (
 
 	conditionIsFalseReason = "ConditionNotBeingMetCurrently"
 
+	conditionComponentAvailableReason = "ComponentAvailable"
+
+	conditionComponentsDeployedReason  = "EnabledComponentsDeployed"
+	conditionComponentsOutOfSyncReason = "ComponentsOutOfSync"
+
+	conditionPruneDelayedReason      = "PruneDelayed"
+	conditionPruneJobRunningReason   = "PruneJobRunning"
+	conditionPruneJobSucceededReason = "PruneJobSucceeded"
+	conditionPruneJobFailedReason    = "PruneJobFailed"
+	conditionPruneAbandonedReason    = "PruneAbandoned"
+
+	conditionOperandNamespaceConflictReason = "OperandNamespaceConflict"
+
+	// ConditionPruned reports the progress and the outcome of the prune
+	// job run on deletion of the NFD CR
+	conditionPruned string = "Pruned"
+
+	// ConditionComponentsDeployed indicates whether exactly the components
+	// enabled in the NFD CR are deployed, its message lists the enabled and
+	// the present components
+	conditionComponentsDeployed string = "ComponentsDeployed"
+
+	// per component conditions, True when the component is available
+	conditionMasterAvailable          string = "MasterAvailable"
+	conditionWorkerAvailable          string = "WorkerAvailable"
+	conditionTopologyUpdaterAvailable string = "TopologyUpdaterAvailable"
+	conditionGCAvailable              string = "GCAvailable"
+
 	// ConditionAvailable indicates that the resources maintained by the operator,
 	// is functional and available in the cluster.
 	conditionAvailable string = "Available"
@@ -72,163 +118,656 @@ const (
 	conditionUpgradeable string = "Upgradeable"
 )
 
+// names of the components reported in the ComponentsDeployed condition
+const (
+	componentMaster          = "master"
+	componentWorker          = "worker"
+	componentTopologyUpdater = "topology-updater"
+	componentGC              = "gc"
+
+	kindDeployment = "Deployment"
+	kindDaemonSet  = "DaemonSet"
+)
+
+// componentState describes why a component is not available
+type componentState struct {
+	// status is either conditionStatusDegraded or conditionStatusProgressing
+	status  string
+	reason  string
+	message string
+}
+
+func degradedState(reason, message string) *componentState {
+	return &componentState{status: conditionStatusDegraded, reason: reason, message: message}
+}
+
+func progressingState(reason, message string) *componentState {
+	return &componentState{status: conditionStatusProgressing, reason: reason, message: message}
+}
+
+// namedComponentState is the state of a component along with the type of
+// its own condition. A nil state means that the component is available
+type namedComponentState struct {
+	component     string
+	conditionType string
+	state         *componentState
+}
+
 //go:generate mockgen -source=status.go -package=status -destination=mock_status.go StatusAPI
 
 type StatusAPI interface {
 	GetConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition
-	AreConditionsEqual(prevConditions, newConditions []metav1.Condition) bool
+	GetComponentsCondition(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) metav1.Condition
+	GetComponentsStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []nfdv1.ComponentStatus
+	MergeConditions(prevConditions, newConditions []metav1.Condition, generation int64) []metav1.Condition
+	GetWorkerPoolsStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []nfdv1.WorkerPoolStatus
+	GetPruneCondition(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneJob *batchv1.Job) metav1.Condition
+	GetPruneDelayedCondition(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneAt time.Time) metav1.Condition
+	GetOperandNamespaceConflictConditions(nfdInstance, owner *nfdv1.NodeFeatureDiscovery) []metav1.Condition
 }
 
 type status struct {
 	helper statusHelperAPI
 }
 
-func NewStatusAPI(deploymentAPI deployment.DeploymentAPI, daemonsetAPI daemonset.DaemonsetAPI) StatusAPI {
-	helper := newStatusHelperAPI(deploymentAPI, daemonsetAPI)
+func NewStatusAPI(deploymentAPI deployment.DeploymentAPI,
+	daemonsetAPI daemonset.DaemonsetAPI,
+	configmapAPI configmap.ConfigMapAPI,
+	podAPI pod.PodAPI,
+	serviceAccountAPI serviceaccount.ServiceAccountAPI,
+	jobAPI job.JobAPI,
+	operandImage string,
+	kubernetesVersion string,
+	maxKubernetesVersion *version.Version) StatusAPI {
+	helper := newStatusHelperAPI(deploymentAPI, daemonsetAPI, configmapAPI, podAPI, serviceAccountAPI, jobAPI, operandImage,
+		kubernetesVersion, maxKubernetesVersion)
 	return &status{
 		helper: helper,
 	}
 }
 
+// GetConditions returns the aggregated Available, Progressing and Degraded
+// conditions, computed from all the enabled components, the Upgradeable
+// condition, and then the condition of each component
 func (s *status) GetConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition {
-	// get worker daemonset conditions
-	nonAvailableConditions := s.helper.getWorkerNotAvailableConditions(ctx, nfdInstance)
-	if nonAvailableConditions != nil {
-		return nonAvailableConditions
-	}
-	// get master deployment conditions
-	nonAvailableConditions = s.helper.getMasterNotAvailableConditions(ctx, nfdInstance)
-	if nonAvailableConditions != nil {
-		return nonAvailableConditions
-	}
-	// get GC deployment conditions
-	nonAvailableConditions = s.helper.getGCNotAvailableConditions(ctx, nfdInstance)
-	if nonAvailableConditions != nil {
-		return nonAvailableConditions
-	}
-	// get topology, if needed
-	if nfdInstance.Spec.TopologyUpdater {
-		nonAvailableConditions := s.helper.getTopologyNotAvailableConditions(ctx, nfdInstance)
-		if nonAvailableConditions != nil {
-			return nonAvailableConditions
+	states := s.getComponentStates(ctx, nfdInstance)
+	conditions := append(getAggregatedConditions(states), s.getUpgradeableCondition(ctx, nfdInstance))
+	for _, cs := range states {
+		conditions = append(conditions, getComponentCondition(cs))
+	}
+	return conditions
+}
+
+// getComponentStates returns the state of every enabled component, in the
+// order in which they are reconciled
+func (s *status) getComponentStates(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []namedComponentState {
+	states := []namedComponentState{
+		{
+			component:     componentMaster,
+			conditionType: conditionMasterAvailable,
+			state:         s.helper.getMasterNotAvailableState(ctx, nfdInstance),
+		},
+		{
+			component:     componentWorker,
+			conditionType: conditionWorkerAvailable,
+			state:         s.getWorkersNotAvailableState(ctx, nfdInstance),
+		},
+	}
+	if nfdInstance.Spec.TopologyUpdater.Enabled {
+		states = append(states, namedComponentState{
+			component:     componentTopologyUpdater,
+			conditionType: conditionTopologyUpdaterAvailable,
+			state:         s.helper.getTopologyNotAvailableState(ctx, nfdInstance),
+		})
+	}
+	if nfdInstance.Spec.GC.IsEnabled() {
+		states = append(states, namedComponentState{
+			component:     componentGC,
+			conditionType: conditionGCAvailable,
+			state:         s.helper.getGCNotAvailableState(ctx, nfdInstance),
+		})
+	}
+	return states
+}
+
+// getWorkersNotAvailableState checks the worker configuration, then the
+// default worker or, when they are defined, the worker pools
+func (s *status) getWorkersNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState {
+	if state := s.helper.getWorkerConfigNotAvailableState(ctx, nfdInstance); state != nil {
+		return state
+	}
+	if len(nfdInstance.Spec.WorkerPools) == 0 {
+		return s.helper.getWorkerNotAvailableState(ctx, nfdInstance)
+	}
+	for _, pool := range nfdInstance.Spec.WorkerPools {
+		if state := s.helper.getWorkerPoolNotAvailableState(ctx, nfdInstance, pool.Name); state != nil {
+			return state
 		}
 	}
+	return nil
+}
 
+// getAggregatedConditions reports the CR as degraded if any component is
+// degraded, else as progressing if any component is progressing, else as
+// available. The reason is the one of the first affected component and the
+// message lists all the affected components
+func getAggregatedConditions(states []namedComponentState) []metav1.Condition {
+	for _, status := range []string{conditionStatusDegraded, conditionStatusProgressing} {
+		reason := ""
+		messages := make([]string, 0, len(states))
+		for _, cs := range states {
+			if cs.state == nil || cs.state.status != status {
+				continue
+			}
+			if reason == "" {
+				reason = cs.state.reason
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s", cs.component, cs.state.message))
+		}
+		if len(messages) == 0 {
+			continue
+		}
+		if status == conditionStatusDegraded {
+			return getDegradedConditions(reason, strings.Join(messages, "; "))
+		}
+		return getProgressingConditions(reason, strings.Join(messages, "; "))
+	}
 	return getAvailableConditions()
 }
 
-func (s *status) AreConditionsEqual(prevConditions, newConditions []metav1.Condition) bool {
-	for _, newCondition := range newConditions {
-		oldCondition := meta.FindStatusCondition(prevConditions, newCondition.Type)
-		if oldCondition == nil {
-			return false
+func getComponentCondition(cs namedComponentState) metav1.Condition {
+	if cs.state == nil {
+		return metav1.Condition{
+			Type:   cs.conditionType,
+			Status: metav1.ConditionTrue,
+			Reason: conditionComponentAvailableReason,
 		}
-		// Ignore timestamps
-		if oldCondition.Status != newCondition.Status ||
-			oldCondition.Reason != newCondition.Reason ||
-			oldCondition.Message != newCondition.Message {
-			return false
+	}
+	return metav1.Condition{
+		Type:    cs.conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  cs.state.reason,
+		Message: cs.state.message,
+	}
+}
+
+// GetComponentsStatus returns the observed state of every enabled
+// component, in the order in which they are reconciled
+func (s *status) GetComponentsStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []nfdv1.ComponentStatus {
+	components := []nfdv1.ComponentStatus{
+		s.helper.getDeploymentComponentStatus(ctx, nfdInstance, componentMaster, names.Master(nfdInstance)),
+	}
+	if len(nfdInstance.Spec.WorkerPools) == 0 {
+		components = append(components,
+			s.helper.getDaemonSetComponentStatus(ctx, nfdInstance, componentWorker, names.Worker(nfdInstance)))
+	}
+	for _, pool := range nfdInstance.Spec.WorkerPools {
+		components = append(components,
+			s.helper.getDaemonSetComponentStatus(ctx, nfdInstance, componentWorker+"-"+pool.Name, names.WorkerPool(nfdInstance, pool.Name)))
+	}
+	if nfdInstance.Spec.TopologyUpdater.Enabled {
+		components = append(components,
+			s.helper.getDaemonSetComponentStatus(ctx, nfdInstance, componentTopologyUpdater, names.TopologyUpdater(nfdInstance)))
+	}
+	if nfdInstance.Spec.GC.IsEnabled() {
+		components = append(components,
+			s.helper.getDeploymentComponentStatus(ctx, nfdInstance, componentGC, names.GC(nfdInstance)))
+	}
+	return components
+}
+
+// GetComponentsCondition compares the components enabled in the NFD CR with
+// the ones present in the cluster, so that a component left behind after
+// being disabled, or not created yet, shows up in the CR status
+func (s *status) GetComponentsCondition(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) metav1.Condition {
+	enabled := getEnabledComponents(nfdInstance)
+	present := s.helper.getPresentComponents(ctx, nfdInstance)
+	condition := metav1.Condition{
+		Type:    conditionComponentsDeployed,
+		Status:  metav1.ConditionTrue,
+		Reason:  conditionComponentsDeployedReason,
+		Message: fmt.Sprintf("enabled: %s; present: %s", strings.Join(enabled, ", "), strings.Join(present, ", ")),
+	}
+	if !slices.Equal(enabled, present) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditionComponentsOutOfSyncReason
+	}
+	return condition
+}
+
+// getEnabledComponents returns the components enabled in the NFD CR, in the
+// order in which they are reconciled
+func getEnabledComponents(nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
+	components := []string{componentMaster, componentWorker}
+	if nfdInstance.Spec.TopologyUpdater.Enabled {
+		components = append(components, componentTopologyUpdater)
+	}
+	if nfdInstance.Spec.GC.IsEnabled() {
+		components = append(components, componentGC)
+	}
+	return components
+}
+
+// MergeConditions applies the new conditions on top of the previous ones
+// with meta.SetStatusCondition semantics, so that the LastTransitionTime of
+// a condition only moves when its status flips. Previous conditions that
+// are not reported anymore are removed, and every condition records the
+// generation of the NFD CR it was computed from. The Pruned condition is set
+// by the prune jobs rather than computed, so it is kept as is
+func (s *status) MergeConditions(prevConditions, newConditions []metav1.Condition, generation int64) []metav1.Condition {
+	conditions := make([]metav1.Condition, 0, len(newConditions))
+	for _, condition := range prevConditions {
+		if condition.Type == conditionPruned || meta.FindStatusCondition(newConditions, condition.Type) != nil {
+			conditions = append(conditions, condition)
 		}
 	}
-	return true
+	for _, condition := range newConditions {
+		condition.ObservedGeneration = generation
+		meta.SetStatusCondition(&conditions, condition)
+	}
+	return conditions
+}
+
+// GetPruneCondition reports the state of the prune job of a CR being
+// deleted, or of the prune requested by the annotation of a CR. A nil
+// pruneJob is a job that was just created
+func (s *status) GetPruneCondition(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneJob *batchv1.Job) metav1.Condition {
+	jobName := nfdInstance.GetOperandNamespace() + "/" + getPruneJobName(nfdInstance)
+	condition := metav1.Condition{
+		Type:    conditionPruned,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditionPruneJobRunningReason,
+		Message: fmt.Sprintf("prune job %s is running", jobName),
+	}
+	if pruneJob == nil {
+		return condition
+	}
+	if job.IsSucceeded(pruneJob) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = conditionPruneJobSucceededReason
+		condition.Message = fmt.Sprintf("prune job %s removed the NFD labels from the nodes", jobName)
+		return condition
+	}
+	failed := job.GetFailedCondition(pruneJob)
+	if failed == nil {
+		if pruneJob.Status.Failed > 0 {
+			condition.Message = fmt.Sprintf("prune job %s is running, %d of %d retries used",
+				jobName, pruneJob.Status.Failed, nfdInstance.Spec.PrunePolicy.GetRetries())
+		}
+		return condition
+	}
+	if isPruneRequested(nfdInstance) {
+		condition.Reason = conditionPruneJobFailedReason
+		condition.Message = fmt.Sprintf("prune job %s failed: %s: %s; the workers are restored, request a new prune to run it again",
+			jobName, failed.Reason, failed.Message)
+		return condition
+	}
+	if nfdInstance.Spec.PrunePolicy.GetOnFailure() == nfdv1.PruneFailureActionRemoveFinalizer {
+		condition.Reason = conditionPruneAbandonedReason
+		condition.Message = fmt.Sprintf("prune job %s failed: %s: %s; the NFD labels are left on the nodes",
+			jobName, failed.Reason, failed.Message)
+		return condition
+	}
+	condition.Reason = conditionPruneJobFailedReason
+	condition.Message = fmt.Sprintf("prune job %s failed: %s: %s; the deletion is blocked until a new job succeeds, "+
+		"delete the job to run it again or set prunePolicy.onFailure to RemoveFinalizer to give up",
+		jobName, failed.Reason, failed.Message)
+	return condition
+}
+
+// isPruneRequested reports whether the prune job of the CR is the one of the
+// prune annotation, rather than the one run on deletion
+func isPruneRequested(nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
+	_, ok := nfdInstance.Annotations[nfdv1.PruneRequestedAnnotation]
+	return ok && nfdInstance.DeletionTimestamp == nil
+}
+
+// getPruneJobName returns the name of the prune job of the CR
+func getPruneJobName(nfdInstance *nfdv1.NodeFeatureDiscovery) string {
+	if isPruneRequested(nfdInstance) {
+		return names.PruneRequest(nfdInstance, nfdInstance.Annotations[nfdv1.PruneRequestedAnnotation])
+	}
+	return names.Prune(nfdInstance)
+}
+
+// GetPruneDelayedCondition reports a prune job which waits for the delay of
+// the PruneAfterDelay deletion policy, and how to cancel it
+func (s *status) GetPruneDelayedCondition(nfdInstance *nfdv1.NodeFeatureDiscovery, pruneAt time.Time) metav1.Condition {
+	return metav1.Condition{
+		Type:   conditionPruned,
+		Status: metav1.ConditionFalse,
+		Reason: conditionPruneDelayedReason,
+		Message: fmt.Sprintf("prune job %s/%s starts at %s; set deletionPolicy to Orphan to keep the NFD labels, "+
+			"or create a NodeFeatureDiscovery with instance %q in namespace %s to take them over",
+			nfdInstance.GetOperandNamespace(), names.Prune(nfdInstance), pruneAt.UTC().Format(time.RFC3339),
+			nfdInstance.Spec.Instance, nfdInstance.Namespace),
+	}
+}
+
+// IsPruneJobSucceededSince reports whether the Pruned condition recorded the
+// success of a prune job after t, the job itself may have been removed since
+// once its TTL expired
+func IsPruneJobSucceededSince(conditions []metav1.Condition, t time.Time) bool {
+	condition := meta.FindStatusCondition(conditions, conditionPruned)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.Reason == conditionPruneJobSucceededReason &&
+		!condition.LastTransitionTime.Time.Before(t)
+}
+
+// GetOperandNamespaceConflictConditions reports a CR whose operand namespace
+// is already used by another CR, none of its operands are deployed
+func (s *status) GetOperandNamespaceConflictConditions(nfdInstance, owner *nfdv1.NodeFeatureDiscovery) []metav1.Condition {
+	return getDegradedConditions(conditionOperandNamespaceConflictReason,
+		fmt.Sprintf("operand namespace %s is used by NodeFeatureDiscovery %s/%s, set another operand namespace",
+			nfdInstance.GetOperandNamespace(), owner.Namespace, owner.Name))
+}
+
+func (s *status) GetWorkerPoolsStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []nfdv1.WorkerPoolStatus {
+	if len(nfdInstance.Spec.WorkerPools) == 0 {
+		return nil
+	}
+	poolsStatus := make([]nfdv1.WorkerPoolStatus, 0, len(nfdInstance.Spec.WorkerPools))
+	for _, pool := range nfdInstance.Spec.WorkerPools {
+		poolsStatus = append(poolsStatus, s.helper.getWorkerPoolStatus(ctx, nfdInstance, pool.Name))
+	}
+	return poolsStatus
 }
 
 //go:generate mockgen -source=status.go -package=status -destination=mock_status.go statusHelperAPI
 
 type statusHelperAPI interface {
-	getWorkerNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition
-	getTopologyNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition
-	getMasterNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition
-	getGCNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition
+	getWorkerConfigNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState
+	getWorkerNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState
+	getWorkerPoolNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, poolName string) *componentState
+	getWorkerPoolStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, poolName string) nfdv1.WorkerPoolStatus
+	getTopologyNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState
+	getMasterNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState
+	getGCNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState
+	getPresentComponents(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []string
+	getDeploymentComponentStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, component, name string) nfdv1.ComponentStatus
+	getDaemonSetComponentStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, component, name string) nfdv1.ComponentStatus
+	getNotUpgradeableReason(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, components []nfdv1.ComponentStatus) (string, string)
 }
 
 type statusHelper struct {
-	deploymentAPI deployment.DeploymentAPI
-	daemonsetAPI  daemonset.DaemonsetAPI
+	deploymentAPI     deployment.DeploymentAPI
+	daemonsetAPI      daemonset.DaemonsetAPI
+	configmapAPI      configmap.ConfigMapAPI
+	podAPI            pod.PodAPI
+	serviceAccountAPI serviceaccount.ServiceAccountAPI
+	jobAPI            job.JobAPI
+	// operandImage is the image of the operands of the CRs that do not set
+	// one
+	operandImage string
+	// kubernetesVersion is the version of the API server, as reported by
+	// its /version endpoint when the operator started
+	kubernetesVersion string
+	// maxKubernetesVersion is the newest Kubernetes version the operand is
+	// supported on, without upper bound when nil
+	maxKubernetesVersion *version.Version
 }
 
-func newStatusHelperAPI(deploymentAPI deployment.DeploymentAPI, daemonsetAPI daemonset.DaemonsetAPI) statusHelperAPI {
+func newStatusHelperAPI(deploymentAPI deployment.DeploymentAPI,
+	daemonsetAPI daemonset.DaemonsetAPI,
+	configmapAPI configmap.ConfigMapAPI,
+	podAPI pod.PodAPI,
+	serviceAccountAPI serviceaccount.ServiceAccountAPI,
+	jobAPI job.JobAPI,
+	operandImage string,
+	kubernetesVersion string,
+	maxKubernetesVersion *version.Version) statusHelperAPI {
 	return &statusHelper{
-		deploymentAPI: deploymentAPI,
-		daemonsetAPI:  daemonsetAPI,
+		deploymentAPI:        deploymentAPI,
+		daemonsetAPI:         daemonsetAPI,
+		configmapAPI:         configmapAPI,
+		podAPI:               podAPI,
+		serviceAccountAPI:    serviceAccountAPI,
+		jobAPI:               jobAPI,
+		operandImage:         operandImage,
+		kubernetesVersion:    kubernetesVersion,
+		maxKubernetesVersion: maxKubernetesVersion,
 	}
 }
 
-func (sh *statusHelper) getWorkerNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition {
-	return sh.getDaemonSetNotAvailableConditions(ctx,
-		nfdInstance.Namespace,
-		"nfd-worker",
+func (sh *statusHelper) getWorkerConfigNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState {
+	refs := []*nfdv1.WorkerConfigMapRef{nfdInstance.Spec.WorkerConfig.ConfigMapRef}
+	if len(nfdInstance.Spec.WorkerPools) > 0 {
+		refs = make([]*nfdv1.WorkerConfigMapRef, 0, len(nfdInstance.Spec.WorkerPools))
+		for _, pool := range nfdInstance.Spec.WorkerPools {
+			refs = append(refs, pool.Config.ConfigMapRef)
+		}
+	}
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		cm, err := sh.configmapAPI.GetConfigMap(ctx, nfdInstance.GetOperandNamespace(), ref.Name)
+		if err != nil {
+			return degradedState(conditionNFDWorkerConfigMapNotFound,
+				fmt.Sprintf("failed to get worker configmap %s/%s: %v", nfdInstance.GetOperandNamespace(), ref.Name, err))
+		}
+		if _, ok := cm.Data[ref.GetKey()]; !ok {
+			return degradedState(conditionNFDWorkerConfigMapKeyNotFound,
+				fmt.Sprintf("key %s not found in worker configmap %s/%s", ref.GetKey(), nfdInstance.GetOperandNamespace(), ref.Name))
+		}
+	}
+	return nil
+}
+
+func (sh *statusHelper) getWorkerPoolNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, poolName string) *componentState {
+	ds, err := sh.daemonsetAPI.GetDaemonSet(ctx, nfdInstance.GetOperandNamespace(), names.WorkerPool(nfdInstance, poolName))
+	if err != nil {
+		return degradedState(conditionFailedGettingNFDWorkerPoolDaemonSet,
+			fmt.Sprintf("worker pool %s: %v", poolName, err))
+	}
+	status, message := getWorkerPoolDaemonSetConditions(ds)
+	if status == conditionStatusAvailable {
+		return nil
+	}
+	if failure := sh.diagnosePodFailure(ctx, nfdInstance.GetOperandNamespace(), ds.Spec.Selector, &ds.Spec.Template.Spec, ""); failure != nil {
+		state := failure.getState(status)
+		state.message = fmt.Sprintf("worker pool %s: %s", poolName, state.message)
+		return state
+	}
+	return progressingState(conditionNFDWorkerPoolDaemonSetProgressing, fmt.Sprintf("worker pool %s: %s", poolName, message))
+}
+
+func (sh *statusHelper) getWorkerPoolStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery, poolName string) nfdv1.WorkerPoolStatus {
+	poolStatus := nfdv1.WorkerPoolStatus{Name: poolName}
+	ds, err := sh.daemonsetAPI.GetDaemonSet(ctx, nfdInstance.GetOperandNamespace(), names.WorkerPool(nfdInstance, poolName))
+	if err != nil {
+		// the DaemonSet has not been created yet, the failure is reported
+		// in the conditions
+		return poolStatus
+	}
+	poolStatus.DesiredNumberScheduled = ds.Status.DesiredNumberScheduled
+	poolStatus.NumberReady = ds.Status.NumberReady
+	poolStatus.UpdatedNumberScheduled = ds.Status.UpdatedNumberScheduled
+	return poolStatus
+}
+
+func (sh *statusHelper) getWorkerNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState {
+	return sh.getDaemonSetNotAvailableState(ctx,
+		nfdInstance.GetOperandNamespace(),
+		names.Worker(nfdInstance),
 		conditionFailedGettingNFDWorkerDaemonSet,
 		conditionNFDWorkerDaemonSetDegraded,
 		conditionNFDWorkerDaemonSetProgressing)
 }
 
-func (sh *statusHelper) getTopologyNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition {
-	return sh.getDaemonSetNotAvailableConditions(ctx,
-		nfdInstance.Namespace,
-		"nfd-topology-updater",
+func (sh *statusHelper) getTopologyNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState {
+	return sh.getDaemonSetNotAvailableState(ctx,
+		nfdInstance.GetOperandNamespace(),
+		names.TopologyUpdater(nfdInstance),
 		conditionFailedGettingNFDTopologyDaemonSet,
 		conditionNFDTopologyDaemonSetDegraded,
 		conditionNFDTopologyDaemonSetProgressing)
 }
 
-func (sh *statusHelper) getDaemonSetNotAvailableConditions(ctx context.Context,
+func (sh *statusHelper) getDaemonSetNotAvailableState(ctx context.Context,
 	dsNamespace,
 	dsName,
 	failedToGetDSReason,
 	dsDegradedReason,
-	dsProgressingReason string) []metav1.Condition {
+	dsProgressingReason string) *componentState {
 
 	ds, err := sh.daemonsetAPI.GetDaemonSet(ctx, dsNamespace, dsName)
 	if err != nil {
-		return getDegradedConditions(failedToGetDSReason, err.Error())
+		return degradedState(failedToGetDSReason, err.Error())
 	}
 	conditionsStatus, message := getDaemonSetConditions(ds)
+	if conditionsStatus == conditionStatusAvailable {
+		return nil
+	}
+	if failure := sh.diagnosePodFailure(ctx, dsNamespace, ds.Spec.Selector, &ds.Spec.Template.Spec, ""); failure != nil {
+		return failure.getState(conditionsStatus)
+	}
 	if conditionsStatus == conditionStatusDegraded {
-		return getDegradedConditions(dsDegradedReason, message)
-	} else if conditionsStatus == conditionStatusProgressing {
-		return getProgressingConditions(dsProgressingReason, message)
+		return degradedState(dsDegradedReason, message)
 	}
-	return nil
+	return progressingState(dsProgressingReason, message)
 }
 
-func (sh *statusHelper) getMasterNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition {
-	return sh.getDeploymentNotAvailableConditions(ctx,
-		nfdInstance.Namespace,
-		"nfd-master",
+func (sh *statusHelper) getMasterNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState {
+	return sh.getDeploymentNotAvailableState(ctx,
+		nfdInstance.GetOperandNamespace(),
+		names.Master(nfdInstance),
 		conditionFailedGettingNFDMasterDeployment,
 		conditionNFDMasterDeploymentDegraded,
 		conditionNFDMasterDeploymentProgressing)
 }
 
-func (sh *statusHelper) getGCNotAvailableConditions(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []metav1.Condition {
-	return sh.getDeploymentNotAvailableConditions(ctx,
-		nfdInstance.Namespace,
-		"nfd-gc",
+func (sh *statusHelper) getGCNotAvailableState(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) *componentState {
+	return sh.getDeploymentNotAvailableState(ctx,
+		nfdInstance.GetOperandNamespace(),
+		names.GC(nfdInstance),
 		conditionFailedGettingNFDGCDeployment,
 		conditionNFDGCDeploymentDegraded,
 		conditionNFDGCDeploymentProgressing)
 
 }
 
-func (sh *statusHelper) getDeploymentNotAvailableConditions(ctx context.Context,
+// getPresentComponents returns the components whose objects exist in the
+// cluster, in the order in which they are reconciled. A component that can
+// not be fetched is considered absent
+func (sh *statusHelper) getPresentComponents(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) []string {
+	components := make([]string, 0, 4)
+	if _, err := sh.deploymentAPI.GetDeployment(ctx, nfdInstance.GetOperandNamespace(), names.Master(nfdInstance)); err == nil {
+		components = append(components, componentMaster)
+	}
+	if sh.isWorkerPresent(ctx, nfdInstance) {
+		components = append(components, componentWorker)
+	}
+	if _, err := sh.daemonsetAPI.GetDaemonSet(ctx, nfdInstance.GetOperandNamespace(), names.TopologyUpdater(nfdInstance)); err == nil {
+		components = append(components, componentTopologyUpdater)
+	}
+	if _, err := sh.deploymentAPI.GetDeployment(ctx, nfdInstance.GetOperandNamespace(), names.GC(nfdInstance)); err == nil {
+		components = append(components, componentGC)
+	}
+	return components
+}
+
+// isWorkerPresent checks the default worker DaemonSet, or the DaemonSets of
+// all the worker pools when pools are defined
+func (sh *statusHelper) isWorkerPresent(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
+	if len(nfdInstance.Spec.WorkerPools) == 0 {
+		_, err := sh.daemonsetAPI.GetDaemonSet(ctx, nfdInstance.GetOperandNamespace(), names.Worker(nfdInstance))
+		return err == nil
+	}
+	for _, pool := range nfdInstance.Spec.WorkerPools {
+		if _, err := sh.daemonsetAPI.GetDaemonSet(ctx, nfdInstance.GetOperandNamespace(), names.WorkerPool(nfdInstance, pool.Name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (sh *statusHelper) getDeploymentComponentStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery,
+	component, name string) nfdv1.ComponentStatus {
+
+	componentStatus := nfdv1.ComponentStatus{Name: component, Kind: kindDeployment}
+	dep, err := sh.deploymentAPI.GetDeployment(ctx, nfdInstance.GetOperandNamespace(), name)
+	if err != nil {
+		componentStatus.LastError = err.Error()
+		return componentStatus
+	}
+	componentStatus.Desired = ptr.Deref(dep.Spec.Replicas, 1)
+	componentStatus.Ready = dep.Status.ReadyReplicas
+	componentStatus.Updated = dep.Status.UpdatedReplicas
+	componentStatus.Image = getImage(&dep.Spec.Template.Spec)
+	if status, message := getDeploymentConditions(dep); status != conditionStatusAvailable {
+		componentStatus.LastError = message
+	}
+	return componentStatus
+}
+
+// getDaemonSetComponentStatus reports the state of the DaemonSet of a
+// component. The worker pools are checked like in
+// getWorkerPoolNotAvailableState
+func (sh *statusHelper) getDaemonSetComponentStatus(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery,
+	component, name string) nfdv1.ComponentStatus {
+
+	componentStatus := nfdv1.ComponentStatus{Name: component, Kind: kindDaemonSet}
+	ds, err := sh.daemonsetAPI.GetDaemonSet(ctx, nfdInstance.GetOperandNamespace(), name)
+	if err != nil {
+		componentStatus.LastError = err.Error()
+		return componentStatus
+	}
+	componentStatus.Desired = ds.Status.DesiredNumberScheduled
+	componentStatus.Ready = ds.Status.NumberReady
+	componentStatus.Updated = ds.Status.UpdatedNumberScheduled
+	componentStatus.Image = getImage(&ds.Spec.Template.Spec)
+	getConditions := getDaemonSetConditions
+	if _, isPool := ds.Labels[names.WorkerPoolLabel]; isPool {
+		getConditions = getWorkerPoolDaemonSetConditions
+	}
+	if status, message := getConditions(ds); status != conditionStatusAvailable {
+		componentStatus.LastError = message
+	}
+	return componentStatus
+}
+
+// getImage returns the image of the first container of the pod, which is
+// the one running the component
+func getImage(podSpec *corev1.PodSpec) string {
+	if len(podSpec.Containers) == 0 {
+		return ""
+	}
+	return podSpec.Containers[0].Image
+}
+
+func (sh *statusHelper) getDeploymentNotAvailableState(ctx context.Context,
 	deploymentNamespace,
 	deploymentName,
 	failedToGetDeploymentReason,
 	deploymentDegradedReason,
-	deploymentProgressingReason string) []metav1.Condition {
+	deploymentProgressingReason string) *componentState {
 
 	dep, err := sh.deploymentAPI.GetDeployment(ctx, deploymentNamespace, deploymentName)
 	if err != nil {
-		return getDegradedConditions(failedToGetDeploymentReason, err.Error())
+		return degradedState(failedToGetDeploymentReason, err.Error())
 	}
 	conditionsStatus, message := getDeploymentConditions(dep)
+	if conditionsStatus == conditionStatusAvailable {
+		return nil
+	}
+	if failure := sh.diagnosePodFailure(ctx, deploymentNamespace, dep.Spec.Selector, &dep.Spec.Template.Spec, getReplicaFailure(dep)); failure != nil {
+		return failure.getState(conditionsStatus)
+	}
 	if conditionsStatus == conditionStatusDegraded {
-		return getDegradedConditions(deploymentDegradedReason, message)
-	} else if conditionsStatus == conditionStatusProgressing {
-		return getProgressingConditions(deploymentProgressingReason, message)
+		return degradedState(deploymentDegradedReason, message)
 	}
-	return nil
+	return progressingState(deploymentProgressingReason, message)
+}
+
+// getWorkerPoolDaemonSetConditions reports a worker pool DaemonSet with
+// less ready pods than desired as progressing. Unlike the default worker, a
+// pool whose node selector does not match any node (yet) is not considered
+// degraded
+func getWorkerPoolDaemonSetConditions(ds *appsv1.DaemonSet) (string, string) {
+	if ds.Status.NumberReady < ds.Status.DesiredNumberScheduled {
+		return conditionStatusProgressing, fmt.Sprintf("%d of %d pods are ready", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+	}
+	return conditionStatusAvailable, ""
 }
 
 func getDaemonSetConditions(ds *appsv1.DaemonSet) (string, string) {
@@ -244,10 +783,25 @@ func getDaemonSetConditions(ds *appsv1.DaemonSet) (string, string) {
 	return conditionStatusProgressing, "ds is progressing"
 }
 
+// getDeploymentConditions reports a deployment without any available pod,
+// or with a rollout that exceeded its progress deadline, as degraded, and
+// one with less available pods than desired as progressing
 func getDeploymentConditions(dep *appsv1.Deployment) (string, string) {
 	if dep.Status.AvailableReplicas == 0 {
 		return conditionStatusDegraded, "number of available pods is 0"
 	}
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse {
+			return conditionStatusDegraded, cond.Message
+		}
+	}
+	desired := int32(1)
+	if dep.Spec.Replicas != nil {
+		desired = *dep.Spec.Replicas
+	}
+	if dep.Status.AvailableReplicas < desired {
+		return conditionStatusProgressing, fmt.Sprintf("%d of %d pods are available", dep.Status.AvailableReplicas, desired)
+	}
 	return conditionStatusAvailable, ""
 }
 
@@ -255,31 +809,21 @@ func getDeploymentConditions(dep *appsv1.Deployment) (string, string) {
 // every condition as FALSE except for ConditionAvailable so that the
 // reconciler can determine that the resource is available.
 func getAvailableConditions() []metav1.Condition {
-	now := time.Now()
 	return []metav1.Condition{
 		{
-			Type:               conditionAvailable,
-			Status:             metav1.ConditionTrue,
-			Reason:             "AllInstanceComponentsAreDeployedSuccessfuly",
-			LastTransitionTime: metav1.Time{Time: now},
-		},
-		{
-			Type:               conditionUpgradeable,
-			Status:             metav1.ConditionTrue,
-			Reason:             "CanBeUpgraded",
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionAvailable,
+			Status: metav1.ConditionTrue,
+			Reason: "AllInstanceComponentsAreDeployedSuccessfuly",
 		},
 		{
-			Type:               conditionProgressing,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionProgressing,
+			Status: metav1.ConditionFalse,
+			Reason: conditionIsFalseReason,
 		},
 		{
-			Type:               conditionDegraded,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionDegraded,
+			Status: metav1.ConditionFalse,
+			Reason: conditionIsFalseReason,
 		},
 	}
 }
@@ -288,32 +832,22 @@ func getAvailableConditions() []metav1.Condition {
 // every condition as FALSE except for conditions.ConditionDegraded so that the
 // reconciler can determine that the resource is degraded.
 func getDegradedConditions(reason string, message string) []metav1.Condition {
-	now := time.Now()
 	return []metav1.Condition{
 		{
-			Type:               conditionAvailable,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionAvailable,
+			Status: metav1.ConditionFalse,
+			Reason: conditionIsFalseReason,
 		},
 		{
-			Type:               conditionUpgradeable,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionProgressing,
+			Status: metav1.ConditionFalse,
+			Reason: conditionIsFalseReason,
 		},
 		{
-			Type:               conditionProgressing,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
-		},
-		{
-			Type:               conditionDegraded,
-			Status:             metav1.ConditionTrue,
-			LastTransitionTime: metav1.Time{Time: now},
-			Reason:             reason,
-			Message:            message,
+			Type:    conditionDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
 		},
 	}
 }
@@ -322,32 +856,22 @@ func getDegradedConditions(reason string, message string) []metav1.Condition {
 // every condition as FALSE except for ConditionProgressing so that the
 // reconciler can determine that the resource is progressing.
 func getProgressingConditions(reason string, message string) []metav1.Condition {
-	now := time.Now()
 	return []metav1.Condition{
 		{
-			Type:               conditionAvailable,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
-		},
-		{
-			Type:               conditionUpgradeable,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionAvailable,
+			Status: metav1.ConditionFalse,
+			Reason: conditionIsFalseReason,
 		},
 		{
-			Type:               conditionProgressing,
-			Status:             metav1.ConditionTrue,
-			LastTransitionTime: metav1.Time{Time: now},
-			Reason:             reason,
-			Message:            message,
+			Type:    conditionProgressing,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
 		},
 		{
-			Type:               conditionDegraded,
-			Status:             metav1.ConditionFalse,
-			Reason:             conditionIsFalseReason,
-			LastTransitionTime: metav1.Time{Time: now},
+			Type:   conditionDegraded,
+			Status: metav1.ConditionFalse,
+			Reason: conditionIsFalseReason,
 		},
 	}
 }
@@ -25,11 +25,17 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/configmap"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/daemonset"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/deployment"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/pod"
 )
 
 var _ = Describe("GetConditions", func() {
@@ -45,122 +51,493 @@ var _ = Describe("GetConditions", func() {
 		st = &status{
 			helper: mockHelper,
 		}
+		// the Upgradeable condition is checked in upgradeable_test.go
+		mockHelper.EXPECT().getDeploymentComponentStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		mockHelper.EXPECT().getDaemonSetComponentStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		mockHelper.EXPECT().getNotUpgradeableReason(gomock.Any(), gomock.Any(), gomock.Any()).Return("", "").AnyTimes()
 	})
 
 	ctx := context.Background()
 	nfdCR := nfdv1.NodeFeatureDiscovery{
 		Spec: nfdv1.NodeFeatureDiscoverySpec{
-			TopologyUpdater: true,
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
 		},
 	}
-	progConds := getProgressingConditions("progressing reason", "progressing message")
-	degConds := getDegradedConditions("degraded reason", "degraded message")
-	availConds := getAvailableConditions()
-
-	DescribeTable("checking all the flows", func(workerAvailable, masterAvailable, gcAvailable, topologyAvailable bool) {
-		expectConds := availConds
-		if !workerAvailable {
-			mockHelper.EXPECT().getWorkerNotAvailableConditions(ctx, &nfdCR).Return(degConds)
-			expectConds = degConds
-			goto executeTestFunction
-		}
-		mockHelper.EXPECT().getWorkerNotAvailableConditions(ctx, &nfdCR).Return(nil)
-		if !masterAvailable {
-			mockHelper.EXPECT().getMasterNotAvailableConditions(ctx, &nfdCR).Return(progConds)
-			expectConds = progConds
-			goto executeTestFunction
-		}
-		mockHelper.EXPECT().getMasterNotAvailableConditions(ctx, &nfdCR).Return(nil)
-		if !gcAvailable {
-			mockHelper.EXPECT().getGCNotAvailableConditions(ctx, &nfdCR).Return(degConds)
-			expectConds = degConds
-			goto executeTestFunction
-		}
-		mockHelper.EXPECT().getGCNotAvailableConditions(ctx, &nfdCR).Return(nil)
-		if !topologyAvailable {
-			mockHelper.EXPECT().getTopologyNotAvailableConditions(ctx, &nfdCR).Return(progConds)
-			expectConds = progConds
-		} else {
-			mockHelper.EXPECT().getTopologyNotAvailableConditions(ctx, &nfdCR).Return(nil)
-		}
-
-	executeTestFunction:
+	upgradeableCondition := metav1.Condition{
+		Type:   conditionUpgradeable,
+		Status: metav1.ConditionTrue,
+		Reason: conditionCanBeUpgradedReason,
+	}
+	progState := progressingState("progressing reason", "progressing message")
+	degState := degradedState("degraded reason", "degraded message")
+
+	componentCondition := func(conditionType string, state *componentState) metav1.Condition {
+		return getComponentCondition(namedComponentState{conditionType: conditionType, state: state})
+	}
+
+	DescribeTable("all the components are checked", func(masterState, workerState, topologyState, gcState *componentState,
+		expectedAggregated []metav1.Condition) {
+		gomock.InOrder(
+			mockHelper.EXPECT().getMasterNotAvailableState(ctx, &nfdCR).Return(masterState),
+			mockHelper.EXPECT().getWorkerConfigNotAvailableState(ctx, &nfdCR).Return(nil),
+			mockHelper.EXPECT().getWorkerNotAvailableState(ctx, &nfdCR).Return(workerState),
+			mockHelper.EXPECT().getTopologyNotAvailableState(ctx, &nfdCR).Return(topologyState),
+			mockHelper.EXPECT().getGCNotAvailableState(ctx, &nfdCR).Return(gcState),
+		)
+
 		conds := st.GetConditions(ctx, &nfdCR)
-		compareConditions(conds, expectConds)
+		compareConditions(conds, append(expectedAggregated,
+			upgradeableCondition,
+			componentCondition(conditionMasterAvailable, masterState),
+			componentCondition(conditionWorkerAvailable, workerState),
+			componentCondition(conditionTopologyUpdaterAvailable, topologyState),
+			componentCondition(conditionGCAvailable, gcState),
+		))
 	},
-		Entry("worker is not available yet", false, false, false, false),
-		Entry("worker available, master is not yet", true, false, false, false),
-		Entry("worker and master available, gc is not yet", true, true, false, false),
-		Entry("worker,master and gc available, topology is not yet", true, true, true, false),
-		Entry("all components are available", true, true, true, true),
+		Entry("all components are available", nil, nil, nil, nil, getAvailableConditions()),
+		Entry("worker is progressing", nil, progState, nil, nil,
+			getProgressingConditions("progressing reason", "worker: progressing message")),
+		Entry("a degraded master is not hidden by a progressing worker", degState, progState, nil, nil,
+			getDegradedConditions("degraded reason", "master: degraded message")),
+		Entry("several components are degraded", nil, degState, nil, degradedState("gc reason", "gc message"),
+			getDegradedConditions("degraded reason", "worker: degraded message; gc: gc message")),
+		Entry("topology and gc are progressing", nil, nil, progState, progState,
+			getProgressingConditions("progressing reason", "topology-updater: progressing message; gc: progressing message")),
 	)
+
+	It("disabled components are not checked", func() {
+		minimalCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				GC: nfdv1.GCSpec{Enabled: ptr.To(false)},
+			},
+		}
+		gomock.InOrder(
+			mockHelper.EXPECT().getMasterNotAvailableState(ctx, &minimalCR).Return(nil),
+			mockHelper.EXPECT().getWorkerConfigNotAvailableState(ctx, &minimalCR).Return(nil),
+			mockHelper.EXPECT().getWorkerNotAvailableState(ctx, &minimalCR).Return(nil),
+		)
+
+		conds := st.GetConditions(ctx, &minimalCR)
+		compareConditions(conds, append(getAvailableConditions(),
+			upgradeableCondition,
+			componentCondition(conditionMasterAvailable, nil),
+			componentCondition(conditionWorkerAvailable, nil),
+		))
+	})
+
+	It("referenced worker configmap is missing", func() {
+		minimalCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				GC: nfdv1.GCSpec{Enabled: ptr.To(false)},
+			},
+		}
+		gomock.InOrder(
+			mockHelper.EXPECT().getMasterNotAvailableState(ctx, &minimalCR).Return(nil),
+			mockHelper.EXPECT().getWorkerConfigNotAvailableState(ctx, &minimalCR).Return(degState),
+		)
+
+		conds := st.GetConditions(ctx, &minimalCR)
+		compareConditions(conds, append(getDegradedConditions("degraded reason", "worker: degraded message"),
+			upgradeableCondition,
+			componentCondition(conditionMasterAvailable, nil),
+			componentCondition(conditionWorkerAvailable, degState),
+		))
+	})
+
+	It("worker pools replace the default worker", func() {
+		poolsCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{{Name: "gpu"}, {Name: "smartnic"}},
+				GC:          nfdv1.GCSpec{Enabled: ptr.To(false)},
+			},
+		}
+		gomock.InOrder(
+			mockHelper.EXPECT().getMasterNotAvailableState(ctx, &poolsCR).Return(nil),
+			mockHelper.EXPECT().getWorkerConfigNotAvailableState(ctx, &poolsCR).Return(nil),
+			mockHelper.EXPECT().getWorkerPoolNotAvailableState(ctx, &poolsCR, "gpu").Return(nil),
+			mockHelper.EXPECT().getWorkerPoolNotAvailableState(ctx, &poolsCR, "smartnic").Return(progState),
+		)
+
+		conds := st.GetConditions(ctx, &poolsCR)
+		compareConditions(conds, append(getProgressingConditions("progressing reason", "worker: progressing message"),
+			upgradeableCondition,
+			componentCondition(conditionMasterAvailable, nil),
+			componentCondition(conditionWorkerAvailable, progState),
+		))
+	})
 })
 
-var _ = Describe("AreConditionsEqual", func() {
-	It("testing various use-cases", func() {
-		st := &status{}
+var _ = Describe("GetComponentsStatus", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockHelper *MockstatusHelperAPI
+		st         *status
+	)
 
-		By("progressing conditions, reason not equal")
-		firstCond := getProgressingConditions("reason1", "message1")
-		secondCond := getProgressingConditions("reason2", "message1")
-		res := st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeFalse())
-
-		By("progressing conditions, message not equal")
-		firstCond = getProgressingConditions("reason1", "message1")
-		secondCond = getProgressingConditions("reason1", "message2")
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeFalse())
-
-		By("progressing conditions equal")
-		firstCond = getProgressingConditions("reason1", "message1")
-		secondCond = getProgressingConditions("reason1", "message1")
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeTrue())
-
-		By("degraded conditions, reason not equal")
-		firstCond = getDegradedConditions("reason1", "message1")
-		secondCond = getDegradedConditions("reason2", "message1")
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeFalse())
-
-		By("degraded conditions, message not equal")
-		firstCond = getDegradedConditions("reason1", "message1")
-		secondCond = getDegradedConditions("reason1", "message2")
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeFalse())
-
-		By("degraded conditions equal")
-		firstCond = getDegradedConditions("reason1", "message1")
-		secondCond = getDegradedConditions("reason1", "message1")
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeTrue())
-
-		By("available conditions equal")
-		firstCond = getAvailableConditions()
-		secondCond = getAvailableConditions()
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeTrue())
-
-		By("degraded and progressing conditions are not equal")
-		firstCond = getDegradedConditions("reason1", "message1")
-		secondCond = getProgressingConditions("reason1", "message1")
-		res = st.AreConditionsEqual(firstCond, secondCond)
-		Expect(res).To(BeFalse())
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockHelper = NewMockstatusHelperAPI(ctrl)
+		st = &status{
+			helper: mockHelper,
+		}
+	})
+
+	ctx := context.Background()
+
+	It("every enabled component is reported", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools:     []nfdv1.WorkerPool{{Name: "gpu"}},
+				TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+				GC:              nfdv1.GCSpec{Enabled: ptr.To(false)},
+			},
+		}
+		master := nfdv1.ComponentStatus{Name: "master"}
+		gpu := nfdv1.ComponentStatus{Name: "worker-gpu"}
+		topology := nfdv1.ComponentStatus{Name: "topology-updater"}
+		gomock.InOrder(
+			mockHelper.EXPECT().getDeploymentComponentStatus(ctx, &nfdCR, "master", "nfd-master").Return(master),
+			mockHelper.EXPECT().getDaemonSetComponentStatus(ctx, &nfdCR, "worker-gpu", "nfd-worker--gpu").Return(gpu),
+			mockHelper.EXPECT().getDaemonSetComponentStatus(ctx, &nfdCR, "topology-updater", "nfd-topology-updater").Return(topology),
+		)
+
+		Expect(st.GetComponentsStatus(ctx, &nfdCR)).To(Equal([]nfdv1.ComponentStatus{master, gpu, topology}))
 	})
 })
 
-var _ = Describe("getWorkerOrTopologyNotAvailableConditions", func() {
+var _ = Describe("getDeploymentComponentStatus and getDaemonSetComponentStatus", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockDeployment *deployment.MockDeploymentAPI
+		mockDS         *daemonset.MockDaemonsetAPI
+		h              statusHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		h = newStatusHelperAPI(mockDeployment, mockDS, nil, nil, nil, nil, "", "", nil)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+	}
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{{Image: "test-image"}}}
+
+	It("object can not be fetched", func() {
+		mockDeployment.EXPECT().GetDeployment(ctx, "test-namespace", "nfd-master").Return(nil, fmt.Errorf("some error"))
+
+		Expect(h.getDeploymentComponentStatus(ctx, &nfdCR, "master", "nfd-master")).To(Equal(nfdv1.ComponentStatus{
+			Name:      "master",
+			Kind:      "Deployment",
+			LastError: "some error",
+		}))
+	})
+
+	It("deployment is progressing", func() {
+		dep := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](3),
+				Template: corev1.PodTemplateSpec{Spec: podSpec},
+			},
+			Status: appsv1.DeploymentStatus{AvailableReplicas: 2, ReadyReplicas: 2, UpdatedReplicas: 3},
+		}
+		mockDeployment.EXPECT().GetDeployment(ctx, "test-namespace", "nfd-master").Return(dep, nil)
+
+		Expect(h.getDeploymentComponentStatus(ctx, &nfdCR, "master", "nfd-master")).To(Equal(nfdv1.ComponentStatus{
+			Name:      "master",
+			Kind:      "Deployment",
+			Desired:   3,
+			Ready:     2,
+			Updated:   3,
+			Image:     "test-image",
+			LastError: "2 of 3 pods are available",
+		}))
+	})
+
+	It("daemonset is available", func() {
+		ds := &appsv1.DaemonSet{
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 2,
+				CurrentNumberScheduled: 2,
+				NumberReady:            2,
+				UpdatedNumberScheduled: 2,
+			},
+		}
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-worker").Return(ds, nil)
+
+		Expect(h.getDaemonSetComponentStatus(ctx, &nfdCR, "worker", "nfd-worker")).To(Equal(nfdv1.ComponentStatus{
+			Name:    "worker",
+			Kind:    "DaemonSet",
+			Desired: 2,
+			Ready:   2,
+			Updated: 2,
+			Image:   "test-image",
+		}))
+	})
+
+	It("worker pool matching no node is not reported as failing", func() {
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"nfd.kubernetes.io/worker-pool": "gpu"}},
+			Spec:       appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+		}
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-worker--gpu").Return(ds, nil)
+
+		Expect(h.getDaemonSetComponentStatus(ctx, &nfdCR, "worker-gpu", "nfd-worker--gpu")).To(Equal(nfdv1.ComponentStatus{
+			Name:  "worker-gpu",
+			Kind:  "DaemonSet",
+			Image: "test-image",
+		}))
+	})
+})
+
+var _ = Describe("GetWorkerPoolsStatus", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockHelper *MockstatusHelperAPI
+		st         *status
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockHelper = NewMockstatusHelperAPI(ctrl)
+		st = &status{
+			helper: mockHelper,
+		}
+	})
+
+	ctx := context.Background()
+
+	It("no worker pools defined", func() {
+		res := st.GetWorkerPoolsStatus(ctx, &nfdv1.NodeFeatureDiscovery{})
+		Expect(res).To(BeNil())
+	})
+
+	It("status is reported for every pool", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{{Name: "gpu"}, {Name: "smartnic"}},
+			},
+		}
+		gpuStatus := nfdv1.WorkerPoolStatus{Name: "gpu", DesiredNumberScheduled: 2, NumberReady: 2, UpdatedNumberScheduled: 2}
+		smartnicStatus := nfdv1.WorkerPoolStatus{Name: "smartnic"}
+		gomock.InOrder(
+			mockHelper.EXPECT().getWorkerPoolStatus(ctx, &nfdCR, "gpu").Return(gpuStatus),
+			mockHelper.EXPECT().getWorkerPoolStatus(ctx, &nfdCR, "smartnic").Return(smartnicStatus),
+		)
+
+		res := st.GetWorkerPoolsStatus(ctx, &nfdCR)
+		Expect(res).To(Equal([]nfdv1.WorkerPoolStatus{gpuStatus, smartnicStatus}))
+	})
+})
+
+var _ = Describe("getWorkerPoolNotAvailableState and getWorkerPoolStatus", func() {
+	var (
+		ctrl    *gomock.Controller
+		mockDS  *daemonset.MockDaemonsetAPI
+		mockPod *pod.MockPodAPI
+		h       statusHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		// the pods of the daemonsets are diagnosed in diagnosis_test.go
+		mockPod = pod.NewMockPodAPI(ctrl)
+		mockPod.EXPECT().ListPods(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		h = newStatusHelperAPI(nil, mockDS, nil, mockPod, nil, nil, "", "", nil)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+		},
+	}
+
+	It("pool daemonset is missing", func() {
+		err := fmt.Errorf("some error")
+		expectedState := degradedState(conditionFailedGettingNFDWorkerPoolDaemonSet, "worker pool gpu: some error")
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker--gpu").Return(nil, err).Times(2)
+
+		resState := h.getWorkerPoolNotAvailableState(ctx, &nfdCR, "gpu")
+		Expect(resState).To(Equal(expectedState))
+		Expect(h.getWorkerPoolStatus(ctx, &nfdCR, "gpu")).To(Equal(nfdv1.WorkerPoolStatus{Name: "gpu"}))
+	})
+
+	It("pool daemonset is progressing", func() {
+		ds := &appsv1.DaemonSet{
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 3,
+				NumberReady:            1,
+				UpdatedNumberScheduled: 2,
+			},
+		}
+		expectedState := progressingState(conditionNFDWorkerPoolDaemonSetProgressing, "worker pool gpu: 1 of 3 pods are ready")
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker--gpu").Return(ds, nil).Times(2)
+
+		resState := h.getWorkerPoolNotAvailableState(ctx, &nfdCR, "gpu")
+		Expect(resState).To(Equal(expectedState))
+		Expect(h.getWorkerPoolStatus(ctx, &nfdCR, "gpu")).To(Equal(nfdv1.WorkerPoolStatus{
+			Name:                   "gpu",
+			DesiredNumberScheduled: 3,
+			NumberReady:            1,
+			UpdatedNumberScheduled: 2,
+		}))
+	})
+
+	It("pool does not match any node", func() {
+		ds := &appsv1.DaemonSet{}
+		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker--gpu").Return(ds, nil)
+
+		resState := h.getWorkerPoolNotAvailableState(ctx, &nfdCR, "gpu")
+		Expect(resState).To(BeNil())
+	})
+})
+
+var _ = Describe("getWorkerConfigNotAvailableState", func() {
 	var (
 		ctrl   *gomock.Controller
-		mockDS *daemonset.MockDaemonsetAPI
+		mockCM *configmap.MockConfigMapAPI
 		h      statusHelperAPI
 	)
 
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockCM = configmap.NewMockConfigMapAPI(ctrl)
+		h = newStatusHelperAPI(nil, nil, mockCM, nil, nil, nil, "", "", nil)
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+		},
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{
+				ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm"},
+			},
+		},
+	}
+
+	It("no configmap is referenced", func() {
+		resState := h.getWorkerConfigNotAvailableState(ctx, &nfdv1.NodeFeatureDiscovery{})
+		Expect(resState).To(BeNil())
+	})
+
+	It("referenced configmap does not exist", func() {
+		err := fmt.Errorf("some error")
+		expectedState := degradedState(conditionNFDWorkerConfigMapNotFound,
+			"failed to get worker configmap test-namespace/user-cm: some error")
+		mockCM.EXPECT().GetConfigMap(ctx, nfdCR.Namespace, "user-cm").Return(nil, err)
+
+		resState := h.getWorkerConfigNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
+	})
+
+	It("referenced key does not exist", func() {
+		cm := &corev1.ConfigMap{Data: map[string]string{"other-key": ""}}
+		expectedState := degradedState(conditionNFDWorkerConfigMapKeyNotFound,
+			"key nfd-worker.conf not found in worker configmap test-namespace/user-cm")
+		mockCM.EXPECT().GetConfigMap(ctx, nfdCR.Namespace, "user-cm").Return(cm, nil)
+
+		resState := h.getWorkerConfigNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
+	})
+
+	It("referenced configmap and key exist", func() {
+		cm := &corev1.ConfigMap{Data: map[string]string{"nfd-worker.conf": ""}}
+		mockCM.EXPECT().GetConfigMap(ctx, nfdCR.Namespace, "user-cm").Return(cm, nil)
+
+		resState := h.getWorkerConfigNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(BeNil())
+	})
+})
+
+var _ = Describe("MergeConditions", func() {
+	st := &status{}
+	lastWeek := metav1.NewTime(time.Now().Add(-7 * 24 * time.Hour).Truncate(time.Second))
+
+	It("transition time only moves when the status flips", func() {
+		prevConditions := []metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1", LastTransitionTime: lastWeek, ObservedGeneration: 1},
+			{Type: conditionDegraded, Status: metav1.ConditionFalse, Reason: "reason1", LastTransitionTime: lastWeek, ObservedGeneration: 1},
+		}
+		newConditions := []metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason2", Message: "message2"},
+			{Type: conditionDegraded, Status: metav1.ConditionTrue, Reason: "reason2"},
+		}
+
+		conds := st.MergeConditions(prevConditions, newConditions, 2)
+		Expect(conds).To(HaveLen(2))
+		Expect(conds[0].Reason).To(Equal("reason2"))
+		Expect(conds[0].Message).To(Equal("message2"))
+		Expect(conds[0].LastTransitionTime).To(Equal(lastWeek))
+		Expect(conds[0].ObservedGeneration).To(Equal(int64(2)))
+		Expect(conds[1].Status).To(Equal(metav1.ConditionTrue))
+		Expect(conds[1].LastTransitionTime.After(lastWeek.Time)).To(BeTrue())
+		Expect(conds[1].ObservedGeneration).To(Equal(int64(2)))
+	})
+
+	It("conditions that are not reported anymore are removed", func() {
+		prevConditions := []metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1", LastTransitionTime: lastWeek},
+			{Type: conditionTopologyUpdaterAvailable, Status: metav1.ConditionTrue, Reason: "reason1", LastTransitionTime: lastWeek},
+		}
+		newConditions := []metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1"},
+		}
+
+		conds := st.MergeConditions(prevConditions, newConditions, 1)
+		Expect(conds).To(Equal([]metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1", LastTransitionTime: lastWeek, ObservedGeneration: 1},
+		}))
+	})
+
+	It("the pruned condition is kept", func() {
+		prevConditions := []metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1", LastTransitionTime: lastWeek},
+			{Type: conditionPruned, Status: metav1.ConditionTrue, Reason: conditionPruneJobSucceededReason, LastTransitionTime: lastWeek, ObservedGeneration: 1},
+		}
+		newConditions := []metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1"},
+		}
+
+		conds := st.MergeConditions(prevConditions, newConditions, 2)
+		Expect(conds).To(Equal([]metav1.Condition{
+			{Type: conditionAvailable, Status: metav1.ConditionTrue, Reason: "reason1", LastTransitionTime: lastWeek, ObservedGeneration: 2},
+			{Type: conditionPruned, Status: metav1.ConditionTrue, Reason: conditionPruneJobSucceededReason, LastTransitionTime: lastWeek, ObservedGeneration: 1},
+		}))
+	})
+
+	It("new conditions are added", func() {
+		conds := st.MergeConditions(nil, getAvailableConditions(), 1)
+		Expect(conds).To(HaveLen(3))
+		for _, cond := range conds {
+			Expect(cond.LastTransitionTime.IsZero()).To(BeFalse())
+			Expect(cond.ObservedGeneration).To(Equal(int64(1)))
+		}
+	})
+})
+
+var _ = Describe("getWorkerOrTopologyNotAvailableState", func() {
+	var (
+		ctrl    *gomock.Controller
+		mockDS  *daemonset.MockDaemonsetAPI
+		mockPod *pod.MockPodAPI
+		h       statusHelperAPI
+	)
+
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
-		h = newStatusHelperAPI(nil, mockDS)
+		// the pods of the daemonsets are diagnosed in diagnosis_test.go
+		mockPod = pod.NewMockPodAPI(ctrl)
+		mockPod.EXPECT().ListPods(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		h = newStatusHelperAPI(nil, mockDS, nil, mockPod, nil, nil, "", "", nil)
 	})
 
 	nfdCR := nfdv1.NodeFeatureDiscovery{
@@ -174,18 +551,18 @@ var _ = Describe("getWorkerOrTopologyNotAvailableConditions", func() {
 	It("worker of topology ds not available", func() {
 		err := fmt.Errorf("some error")
 		By("checking worker")
-		expectedConds := getDegradedConditions(conditionFailedGettingNFDWorkerDaemonSet, err.Error())
+		expectedState := degradedState(conditionFailedGettingNFDWorkerDaemonSet, err.Error())
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(nil, err)
 
-		resCond := h.getWorkerNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 
 		By("checking topology")
-		expectedConds = getDegradedConditions(conditionFailedGettingNFDTopologyDaemonSet, err.Error())
+		expectedState = degradedState(conditionFailedGettingNFDTopologyDaemonSet, err.Error())
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-topology-updater").Return(nil, err)
 
-		resCond = h.getTopologyNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState = h.getTopologyNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 	})
 
 	It("worker or topology ds number of scheduled is 0", func() {
@@ -195,18 +572,18 @@ var _ = Describe("getWorkerOrTopologyNotAvailableConditions", func() {
 			},
 		}
 		By("checking worker")
-		expectedConds := getDegradedConditions(conditionNFDWorkerDaemonSetDegraded, "number of desired nodes for scheduling is 0")
+		expectedState := degradedState(conditionNFDWorkerDaemonSetDegraded, "number of desired nodes for scheduling is 0")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(ds, nil)
 
-		resCond := h.getWorkerNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 
 		By("checking topology")
-		expectedConds = getDegradedConditions(conditionNFDTopologyDaemonSetDegraded, "number of desired nodes for scheduling is 0")
+		expectedState = degradedState(conditionNFDTopologyDaemonSetDegraded, "number of desired nodes for scheduling is 0")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-topology-updater").Return(ds, nil)
 
-		resCond = h.getTopologyNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState = h.getTopologyNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 	})
 
 	It("worker or topology ds current number of scheduled pods is 0", func() {
@@ -217,18 +594,18 @@ var _ = Describe("getWorkerOrTopologyNotAvailableConditions", func() {
 			},
 		}
 		By("checking worker")
-		expectedConds := getDegradedConditions(conditionNFDWorkerDaemonSetDegraded, "0 nodes have pods scheduled")
+		expectedState := degradedState(conditionNFDWorkerDaemonSetDegraded, "0 nodes have pods scheduled")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(ds, nil)
 
-		resCond := h.getWorkerNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 
 		By("checking topology")
-		expectedConds = getDegradedConditions(conditionNFDTopologyDaemonSetDegraded, "0 nodes have pods scheduled")
+		expectedState = degradedState(conditionNFDTopologyDaemonSetDegraded, "0 nodes have pods scheduled")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-topology-updater").Return(ds, nil)
 
-		resCond = h.getTopologyNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState = h.getTopologyNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 	})
 
 	It("worker or topology ds number of pods has not yet reached desired number", func() {
@@ -240,18 +617,18 @@ var _ = Describe("getWorkerOrTopologyNotAvailableConditions", func() {
 			},
 		}
 		By("worker")
-		expectedConds := getProgressingConditions(conditionNFDWorkerDaemonSetProgressing, "ds is progressing")
+		expectedState := progressingState(conditionNFDWorkerDaemonSetProgressing, "ds is progressing")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(ds, nil)
 
-		resCond := h.getWorkerNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 
 		By("topology")
-		expectedConds = getProgressingConditions(conditionNFDTopologyDaemonSetProgressing, "ds is progressing")
+		expectedState = progressingState(conditionNFDTopologyDaemonSetProgressing, "ds is progressing")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-topology-updater").Return(ds, nil)
 
-		resCond = h.getTopologyNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState = h.getTopologyNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 	})
 
 	It("worker or topology ds all pods are available", func() {
@@ -265,14 +642,14 @@ var _ = Describe("getWorkerOrTopologyNotAvailableConditions", func() {
 		By("worker")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-worker").Return(ds, nil)
 
-		resCond := h.getWorkerNotAvailableConditions(ctx, &nfdCR)
-		Expect(resCond).To(BeNil())
+		resState := h.getWorkerNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(BeNil())
 
 		By("topology")
 		mockDS.EXPECT().GetDaemonSet(ctx, nfdCR.Namespace, "nfd-topology-updater").Return(ds, nil)
 
-		resCond = h.getTopologyNotAvailableConditions(ctx, &nfdCR)
-		Expect(resCond).To(BeNil())
+		resState = h.getTopologyNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(BeNil())
 	})
 })
 
@@ -280,13 +657,17 @@ var _ = Describe("getMasterOrGCNotAvailableCondition", func() {
 	var (
 		ctrl           *gomock.Controller
 		mockDeployment *deployment.MockDeploymentAPI
+		mockPod        *pod.MockPodAPI
 		h              statusHelperAPI
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
-		h = newStatusHelperAPI(mockDeployment, nil)
+		// the pods of the deployments are diagnosed in diagnosis_test.go
+		mockPod = pod.NewMockPodAPI(ctrl)
+		mockPod.EXPECT().ListPods(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		h = newStatusHelperAPI(mockDeployment, nil, nil, mockPod, nil, nil, "", "", nil)
 	})
 
 	nfdCR := nfdv1.NodeFeatureDiscovery{
@@ -300,18 +681,18 @@ var _ = Describe("getMasterOrGCNotAvailableCondition", func() {
 		err := fmt.Errorf("some error")
 
 		By("master")
-		expectedConds := getDegradedConditions(conditionFailedGettingNFDMasterDeployment, err.Error())
+		expectedState := degradedState(conditionFailedGettingNFDMasterDeployment, err.Error())
 		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(nil, err)
 
-		resCond := h.getMasterNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 
 		By("GC")
-		expectedConds = getDegradedConditions(conditionFailedGettingNFDGCDeployment, err.Error())
+		expectedState = degradedState(conditionFailedGettingNFDGCDeployment, err.Error())
 		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-gc").Return(nil, err)
 
-		resCond = h.getGCNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState = h.getGCNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 	})
 
 	It("master or GC deployment available replicas 0", func() {
@@ -321,18 +702,58 @@ var _ = Describe("getMasterOrGCNotAvailableCondition", func() {
 			},
 		}
 		By("master")
-		expectedConds := getDegradedConditions(conditionNFDMasterDeploymentDegraded, "number of available pods is 0")
+		expectedState := degradedState(conditionNFDMasterDeploymentDegraded, "number of available pods is 0")
 		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(dep, nil)
 
-		resCond := h.getMasterNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 
 		By("GC")
-		expectedConds = getDegradedConditions(conditionNFDGCDeploymentDegraded, "number of available pods is 0")
+		expectedState = degradedState(conditionNFDGCDeploymentDegraded, "number of available pods is 0")
 		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-gc").Return(dep, nil)
 
-		resCond = h.getGCNotAvailableConditions(ctx, &nfdCR)
-		compareConditions(resCond, expectedConds)
+		resState = h.getGCNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
+	})
+
+	It("master deployment has less available pods than desired", func() {
+		dep := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](3),
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 2,
+			},
+		}
+		expectedState := progressingState(conditionNFDMasterDeploymentProgressing, "2 of 3 pods are available")
+		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(dep, nil)
+
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
+	})
+
+	It("master deployment rollout exceeded its progress deadline", func() {
+		dep := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](3),
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 2,
+				Conditions: []appsv1.DeploymentCondition{
+					{
+						Type:    appsv1.DeploymentProgressing,
+						Status:  corev1.ConditionFalse,
+						Reason:  "ProgressDeadlineExceeded",
+						Message: "ReplicaSet \"nfd-master-1234\" has timed out progressing.",
+					},
+				},
+			},
+		}
+		expectedState := degradedState(conditionNFDMasterDeploymentDegraded, "ReplicaSet \"nfd-master-1234\" has timed out progressing.")
+		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(dep, nil)
+
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(Equal(expectedState))
 	})
 
 	It("master or GC deployment all pods are available", func() {
@@ -344,14 +765,104 @@ var _ = Describe("getMasterOrGCNotAvailableCondition", func() {
 		By("master")
 		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-master").Return(dep, nil)
 
-		resCond := h.getMasterNotAvailableConditions(ctx, &nfdCR)
-		Expect(resCond).To(BeNil())
+		resState := h.getMasterNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(BeNil())
 
 		By("GC")
 		mockDeployment.EXPECT().GetDeployment(ctx, nfdCR.Namespace, "nfd-gc").Return(dep, nil)
 
-		resCond = h.getGCNotAvailableConditions(ctx, &nfdCR)
-		Expect(resCond).To(BeNil())
+		resState = h.getGCNotAvailableState(ctx, &nfdCR)
+		Expect(resState).To(BeNil())
+	})
+})
+
+var _ = Describe("GetComponentsCondition", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockHelper *MockstatusHelperAPI
+		st         *status
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockHelper = NewMockstatusHelperAPI(ctrl)
+		st = &status{
+			helper: mockHelper,
+		}
+	})
+
+	ctx := context.Background()
+
+	DescribeTable("comparing enabled and present components", func(spec nfdv1.NodeFeatureDiscoverySpec, present []string,
+		expectedStatus metav1.ConditionStatus, expectedMessage string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{Spec: spec}
+		mockHelper.EXPECT().getPresentComponents(ctx, &nfdCR).Return(present)
+
+		cond := st.GetComponentsCondition(ctx, &nfdCR)
+		Expect(cond.Type).To(Equal(conditionComponentsDeployed))
+		Expect(cond.Status).To(Equal(expectedStatus))
+		Expect(cond.Message).To(Equal(expectedMessage))
+	},
+		Entry("default components deployed", nfdv1.NodeFeatureDiscoverySpec{},
+			[]string{"master", "worker", "gc"},
+			metav1.ConditionTrue, "enabled: master, worker, gc; present: master, worker, gc"),
+		Entry("topology updater enabled but not deployed yet", nfdv1.NodeFeatureDiscoverySpec{
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{Enabled: true},
+		},
+			[]string{"master", "worker", "gc"},
+			metav1.ConditionFalse, "enabled: master, worker, topology-updater, gc; present: master, worker, gc"),
+		Entry("gc disabled but still deployed", nfdv1.NodeFeatureDiscoverySpec{
+			GC: nfdv1.GCSpec{Enabled: ptr.To(false)},
+		},
+			[]string{"master", "worker", "gc"},
+			metav1.ConditionFalse, "enabled: master, worker; present: master, worker, gc"),
+	)
+})
+
+var _ = Describe("getPresentComponents", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockDeployment *deployment.MockDeploymentAPI
+		mockDS         *daemonset.MockDaemonsetAPI
+		h              statusHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockDeployment = deployment.NewMockDeploymentAPI(ctrl)
+		mockDS = daemonset.NewMockDaemonsetAPI(ctrl)
+		h = newStatusHelperAPI(mockDeployment, mockDS, nil, nil, nil, nil, "", "", nil)
+	})
+
+	ctx := context.Background()
+	notFound := fmt.Errorf("not found")
+
+	It("components that can not be fetched are absent", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		}
+		mockDeployment.EXPECT().GetDeployment(ctx, "test-namespace", "nfd-master").Return(&appsv1.Deployment{}, nil)
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-worker").Return(&appsv1.DaemonSet{}, nil)
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-topology-updater").Return(&appsv1.DaemonSet{}, nil)
+		mockDeployment.EXPECT().GetDeployment(ctx, "test-namespace", "nfd-gc").Return(nil, notFound)
+
+		Expect(h.getPresentComponents(ctx, &nfdCR)).To(Equal([]string{"master", "worker", "topology-updater"}))
+	})
+
+	It("worker is present only if all the worker pools are", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{{Name: "gpu"}, {Name: "smartnic"}},
+			},
+		}
+		mockDeployment.EXPECT().GetDeployment(ctx, "test-namespace", "nfd-master").Return(&appsv1.Deployment{}, nil)
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-worker--gpu").Return(&appsv1.DaemonSet{}, nil)
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-worker--smartnic").Return(nil, notFound)
+		mockDS.EXPECT().GetDaemonSet(ctx, "test-namespace", "nfd-topology-updater").Return(nil, notFound)
+		mockDeployment.EXPECT().GetDeployment(ctx, "test-namespace", "nfd-gc").Return(&appsv1.Deployment{}, nil)
+
+		Expect(h.getPresentComponents(ctx, &nfdCR)).To(Equal([]string{"master", "gc"}))
 	})
 })
 
@@ -364,3 +875,151 @@ func compareConditions(first, second []metav1.Condition) {
 	}
 	Expect(first).To(Equal(second))
 }
+
+var _ = Describe("GetPruneCondition", func() {
+	st := &status{}
+	failedJob := batchv1.Job{
+		Status: batchv1.JobStatus{
+			Failed: 2,
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded", Message: "Job was active longer than specified deadline"},
+			},
+		},
+	}
+
+	DescribeTable("condition follows the prune job", func(pruneJob *batchv1.Job, onFailure nfdv1.PruneFailureAction,
+		expectedStatus metav1.ConditionStatus, expectedReason, expectedMessage string) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				PrunePolicy: nfdv1.PrunePolicy{OnFailure: onFailure},
+			},
+		}
+
+		condition := st.GetPruneCondition(&nfdCR, pruneJob)
+
+		Expect(condition.Type).To(Equal(conditionPruned))
+		Expect(condition.Status).To(Equal(expectedStatus))
+		Expect(condition.Reason).To(Equal(expectedReason))
+		Expect(condition.Message).To(Equal(expectedMessage))
+	},
+		Entry("job just created", nil, nfdv1.PruneFailureAction(""),
+			metav1.ConditionFalse, conditionPruneJobRunningReason, "prune job test-namespace/nfd-prune is running"),
+		Entry("job retrying a failed pod", &batchv1.Job{Status: batchv1.JobStatus{Failed: 1}}, nfdv1.PruneFailureAction(""),
+			metav1.ConditionFalse, conditionPruneJobRunningReason, "prune job test-namespace/nfd-prune is running, 1 of 3 retries used"),
+		Entry("job succeeded", &batchv1.Job{Status: batchv1.JobStatus{Failed: 1, Succeeded: 1}}, nfdv1.PruneFailureAction(""),
+			metav1.ConditionTrue, conditionPruneJobSucceededReason, "prune job test-namespace/nfd-prune removed the NFD labels from the nodes"),
+		Entry("job failed, the deletion is blocked", &failedJob, nfdv1.PruneFailureActionBlock,
+			metav1.ConditionFalse, conditionPruneJobFailedReason,
+			"prune job test-namespace/nfd-prune failed: DeadlineExceeded: Job was active longer than specified deadline; "+
+				"the deletion is blocked until a new job succeeds, delete the job to run it again or set prunePolicy.onFailure to RemoveFinalizer to give up"),
+		Entry("job failed, pruning is abandoned", &failedJob, nfdv1.PruneFailureActionRemoveFinalizer,
+			metav1.ConditionFalse, conditionPruneAbandonedReason,
+			"prune job test-namespace/nfd-prune failed: DeadlineExceeded: Job was active longer than specified deadline; the NFD labels are left on the nodes"),
+	)
+
+	It("requested prune failed, the workers are restored", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test-namespace",
+				Annotations: map[string]string{nfdv1.PruneRequestedAnnotation: "1"},
+			},
+		}
+
+		condition := st.GetPruneCondition(&nfdCR, &failedJob)
+
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(conditionPruneJobFailedReason))
+		Expect(condition.Message).To(Equal("prune job test-namespace/nfd-prune-6b86b273ff failed: DeadlineExceeded: Job was active longer than specified deadline; " +
+			"the workers are restored, request a new prune to run it again"))
+	})
+
+	It("CR deleted during a requested prune, the condition reports the deletion prune job", func() {
+		deletedAt := metav1.Now()
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test-namespace",
+				Annotations:       map[string]string{nfdv1.PruneRequestedAnnotation: "1"},
+				DeletionTimestamp: &deletedAt,
+			},
+		}
+
+		condition := st.GetPruneCondition(&nfdCR, nil)
+
+		Expect(condition.Message).To(Equal("prune job test-namespace/nfd-prune is running"))
+	})
+})
+
+var _ = Describe("GetPruneDelayedCondition", func() {
+	It("condition tells when the prune job starts and how to cancel it", func() {
+		st := &status{}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Instance: "blue",
+			},
+		}
+		pruneAt := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+		condition := st.GetPruneDelayedCondition(&nfdCR, pruneAt)
+
+		Expect(condition.Type).To(Equal(conditionPruned))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(conditionPruneDelayedReason))
+		Expect(condition.Message).To(Equal("prune job test-namespace/nfd-prune-blue starts at 2024-03-01T12:30:00Z; " +
+			"set deletionPolicy to Orphan to keep the NFD labels, " +
+			"or create a NodeFeatureDiscovery with instance \"blue\" in namespace test-namespace to take them over"))
+	})
+})
+
+var _ = Describe("GetOperandNamespaceConflictConditions", func() {
+	It("conditions report the CR using the operand namespace", func() {
+		st := &status{}
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "nfd-cr"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+			},
+		}
+		owner := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "other-cr"},
+		}
+
+		conditions := st.GetOperandNamespaceConflictConditions(&nfdCR, &owner)
+
+		Expect(meta.IsStatusConditionFalse(conditions, conditionAvailable)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(conditions, conditionProgressing)).To(BeTrue())
+		degraded := meta.FindStatusCondition(conditions, conditionDegraded)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(conditionOperandNamespaceConflictReason))
+		Expect(degraded.Message).To(Equal("operand namespace nfd-operands is used by NodeFeatureDiscovery other-namespace/other-cr, " +
+			"set another operand namespace"))
+	})
+})
+
+var _ = Describe("IsPruneJobSucceededSince", func() {
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("the Pruned condition records a prune job succeeded after the deletion", func(condition *metav1.Condition, expected bool) {
+		conditions := []metav1.Condition{}
+		if condition != nil {
+			conditions = append(conditions, *condition)
+		}
+		Expect(IsPruneJobSucceededSince(conditions, deletedAt)).To(Equal(expected))
+	},
+		Entry("no Pruned condition", nil, false),
+		Entry("prune job running", &metav1.Condition{
+			Type: conditionPruned, Status: metav1.ConditionFalse, Reason: conditionPruneJobRunningReason,
+			LastTransitionTime: metav1.NewTime(deletedAt.Add(time.Minute)),
+		}, false),
+		Entry("prune job succeeded before the deletion", &metav1.Condition{
+			Type: conditionPruned, Status: metav1.ConditionTrue, Reason: conditionPruneJobSucceededReason,
+			LastTransitionTime: metav1.NewTime(deletedAt.Add(-time.Minute)),
+		}, false),
+		Entry("prune job succeeded after the deletion", &metav1.Condition{
+			Type: conditionPruned, Status: metav1.ConditionTrue, Reason: conditionPruneJobSucceededReason,
+			LastTransitionTime: metav1.NewTime(deletedAt.Add(time.Minute)),
+		}, true),
+	)
+})
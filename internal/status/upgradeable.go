@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+)
+
+// reasons of the Upgradeable condition
+const (
+	conditionCanBeUpgradedReason                = "CanBeUpgraded"
+	conditionRolloutInProgressReason            = "RolloutInProgress"
+	conditionOperandImageMismatchReason         = "OperandImageMismatch"
+	conditionPruneJobPendingReason              = "PruneJobPending"
+	conditionUnsupportedKubernetesVersionReason = "UnsupportedKubernetesVersion"
+)
+
+// minSupportedKubernetesVersion is the oldest Kubernetes minor version the
+// operand is supported on. There is no built-in upper bound, since newer
+// Kubernetes releases keep serving the APIs the operand uses; the newest
+// version can be set with the --max-kubernetes-version flag of the operator
+var minSupportedKubernetesVersion = version.MajorMinor(1, 24)
+
+// getUpgradeableCondition reports whether it is safe to upgrade the operand,
+// regardless of whether it is currently available
+func (s *status) getUpgradeableCondition(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) metav1.Condition {
+	reason, message := s.helper.getNotUpgradeableReason(ctx, nfdInstance, s.GetComponentsStatus(ctx, nfdInstance))
+	if reason == "" {
+		return metav1.Condition{
+			Type:   conditionUpgradeable,
+			Status: metav1.ConditionTrue,
+			Reason: conditionCanBeUpgradedReason,
+		}
+	}
+	return metav1.Condition{
+		Type:    conditionUpgradeable,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// getNotUpgradeableReason returns why the operand should not be upgraded now:
+// a component is still rolling out, a component does not run the image of
+// the spec, the prune job has not finished, or the cluster runs a version of
+// Kubernetes the operand is not supported on. The reason is empty otherwise
+func (sh *statusHelper) getNotUpgradeableReason(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery,
+	components []nfdv1.ComponentStatus) (string, string) {
+
+	if message := getRolloutsInProgress(components); message != "" {
+		return conditionRolloutInProgressReason, message + "; wait for the rollout to complete before upgrading"
+	}
+	if message := getImageMismatches(components, nfdInstance.Spec.Operand.ImagePath(sh.operandImage)); message != "" {
+		return conditionOperandImageMismatchReason, message + "; wait for the operand to be updated before upgrading"
+	}
+	if sh.isPruneJobPending(ctx, nfdInstance) {
+		return conditionPruneJobPendingReason,
+			fmt.Sprintf("prune job %s/%s has not completed; wait for it before upgrading", nfdInstance.GetOperandNamespace(), getPruneJobName(nfdInstance))
+	}
+	if message := getUnsupportedKubernetesVersion(sh.kubernetesVersion, sh.maxKubernetesVersion); message != "" {
+		return conditionUnsupportedKubernetesVersionReason, message
+	}
+	return "", ""
+}
+
+// getRolloutsInProgress lists the components that still run pods of a
+// previous revision
+func getRolloutsInProgress(components []nfdv1.ComponentStatus) string {
+	messages := make([]string, 0, len(components))
+	for _, c := range components {
+		if c.Updated < c.Desired {
+			messages = append(messages, fmt.Sprintf("%s: %d of %d pods are updated", c.Name, c.Updated, c.Desired))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// getImageMismatches lists the components whose image differs from the one
+// of the spec, i.e. that have not been reconciled since the spec changed
+func getImageMismatches(components []nfdv1.ComponentStatus, image string) string {
+	messages := make([]string, 0, len(components))
+	for _, c := range components {
+		if c.Image != "" && c.Image != image {
+			messages = append(messages, fmt.Sprintf("%s: runs image %s instead of %s", c.Name, c.Image, image))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// isPruneJobPending checks whether the prune job, which runs when the NFD CR
+// is deleted or when a prune is requested, exists and has neither succeeded
+// nor failed for good yet
+func (sh *statusHelper) isPruneJobPending(ctx context.Context, nfdInstance *nfdv1.NodeFeatureDiscovery) bool {
+	if !isPruneRequested(nfdInstance) && nfdInstance.Spec.GetDeletionPolicy() == nfdv1.DeletionPolicyOrphan {
+		return false
+	}
+	pruneJob, err := sh.jobAPI.GetJob(ctx, nfdInstance.GetOperandNamespace(), getPruneJobName(nfdInstance))
+	if err != nil {
+		return false
+	}
+	return !job.IsSucceeded(pruneJob) && job.GetFailedCondition(pruneJob) == nil
+}
+
+// getUnsupportedKubernetesVersion returns a message when the Kubernetes
+// version is older than the supported one, or newer than maxVersion when it
+// is set. An unknown version is not reported
+func getUnsupportedKubernetesVersion(kubernetesVersion string, maxVersion *version.Version) string {
+	if kubernetesVersion == "" {
+		return ""
+	}
+	v, err := version.ParseGeneric(kubernetesVersion)
+	if err != nil {
+		return ""
+	}
+	minor := version.MajorMinor(v.Major(), v.Minor())
+	if minor.LessThan(minSupportedKubernetesVersion) {
+		return fmt.Sprintf("Kubernetes %s is older than v%s, the oldest version supported by the operand",
+			kubernetesVersion, minSupportedKubernetesVersion)
+	}
+	if maxVersion != nil {
+		maxMinor := version.MajorMinor(maxVersion.Major(), maxVersion.Minor())
+		if maxMinor.LessThan(minor) {
+			return fmt.Sprintf("Kubernetes %s is newer than v%s, the newest version supported by the operand",
+				kubernetesVersion, maxMinor)
+		}
+	}
+	return ""
+}
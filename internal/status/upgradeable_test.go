@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+)
+
+var _ = Describe("getUpgradeableCondition", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockHelper *MockstatusHelperAPI
+		st         *status
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockHelper = NewMockstatusHelperAPI(ctrl)
+		st = &status{
+			helper: mockHelper,
+		}
+	})
+
+	ctx := context.Background()
+	nfdCR := nfdv1.NodeFeatureDiscovery{}
+	masterStatus := nfdv1.ComponentStatus{Name: componentMaster, Kind: kindDeployment, Desired: 1}
+	workerStatus := nfdv1.ComponentStatus{Name: componentWorker, Kind: kindDaemonSet, Desired: 3}
+
+	It("components status is checked", func() {
+		gomock.InOrder(
+			mockHelper.EXPECT().getDeploymentComponentStatus(ctx, &nfdCR, componentMaster, "nfd-master").Return(masterStatus),
+			mockHelper.EXPECT().getDaemonSetComponentStatus(ctx, &nfdCR, componentWorker, "nfd-worker").Return(workerStatus),
+			mockHelper.EXPECT().getDeploymentComponentStatus(ctx, &nfdCR, componentGC, "nfd-gc").Return(nfdv1.ComponentStatus{}),
+			mockHelper.EXPECT().getNotUpgradeableReason(ctx, &nfdCR,
+				[]nfdv1.ComponentStatus{masterStatus, workerStatus, {}}).Return("", ""),
+		)
+
+		Expect(st.getUpgradeableCondition(ctx, &nfdCR)).To(Equal(metav1.Condition{
+			Type:   conditionUpgradeable,
+			Status: metav1.ConditionTrue,
+			Reason: conditionCanBeUpgradedReason,
+		}))
+	})
+
+	It("not upgradeable", func() {
+		mockHelper.EXPECT().getDeploymentComponentStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		mockHelper.EXPECT().getDaemonSetComponentStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		mockHelper.EXPECT().getNotUpgradeableReason(ctx, &nfdCR, gomock.Any()).Return("some reason", "some message")
+
+		Expect(st.getUpgradeableCondition(ctx, &nfdCR)).To(Equal(metav1.Condition{
+			Type:    conditionUpgradeable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "some reason",
+			Message: "some message",
+		}))
+	})
+})
+
+var _ = Describe("getNotUpgradeableReason", func() {
+	var (
+		ctrl    *gomock.Controller
+		mockJob *job.MockJobAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockJob = job.NewMockJobAPI(ctrl)
+	})
+
+	ctx := context.Background()
+	image := "registry.k8s.io/nfd/node-feature-discovery:v0.16.0"
+	nfdCR := nfdv1.NodeFeatureDiscovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+		},
+		Spec: nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{Image: image},
+		},
+	}
+	pruneCR := nfdCR.DeepCopy()
+	pruneCR.Spec.PruneOnDelete = true
+	upToDate := []nfdv1.ComponentStatus{
+		{Name: componentMaster, Desired: 1, Ready: 1, Updated: 1, Image: image},
+		{Name: componentWorker, Desired: 3, Ready: 3, Updated: 3, Image: image},
+	}
+
+	It("up to date components on a supported Kubernetes version", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", "v1.29.1", nil)
+
+		reason, message := h.getNotUpgradeableReason(ctx, &nfdCR, upToDate)
+		Expect(reason).To(BeEmpty())
+		Expect(message).To(BeEmpty())
+	})
+
+	It("rollout in progress", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", "", nil)
+		components := []nfdv1.ComponentStatus{
+			{Name: componentMaster, Desired: 3, Ready: 3, Updated: 1, Image: image},
+			{Name: componentWorker, Desired: 3, Ready: 2, Updated: 2, Image: image},
+		}
+
+		reason, message := h.getNotUpgradeableReason(ctx, &nfdCR, components)
+		Expect(reason).To(Equal(conditionRolloutInProgressReason))
+		Expect(message).To(Equal("master: 1 of 3 pods are updated; worker: 2 of 3 pods are updated; " +
+			"wait for the rollout to complete before upgrading"))
+	})
+
+	It("component runs another image than the spec", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", "", nil)
+		components := []nfdv1.ComponentStatus{
+			{Name: componentMaster, Desired: 1, Ready: 1, Updated: 1, Image: "nfd:old"},
+			{Name: componentGC, LastError: "not found"},
+		}
+
+		reason, message := h.getNotUpgradeableReason(ctx, &nfdCR, components)
+		Expect(reason).To(Equal(conditionOperandImageMismatchReason))
+		Expect(message).To(Equal(fmt.Sprintf("master: runs image nfd:old instead of %s; "+
+			"wait for the operand to be updated before upgrading", image)))
+	})
+
+	It("components run the operator image of a CR that does not set one", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, image, "", nil)
+		defaultCR := nfdCR.DeepCopy()
+		defaultCR.Spec.Operand.Image = ""
+
+		reason, _ := h.getNotUpgradeableReason(ctx, defaultCR, upToDate)
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("prune job is pending", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", "", nil)
+		mockJob.EXPECT().GetJob(ctx, nfdCR.Namespace, "nfd-prune").Return(&batchv1.Job{}, nil)
+
+		reason, message := h.getNotUpgradeableReason(ctx, pruneCR, upToDate)
+		Expect(reason).To(Equal(conditionPruneJobPendingReason))
+		Expect(message).To(Equal("prune job test-namespace/nfd-prune has not completed; wait for it before upgrading"))
+	})
+
+	It("prune job is retrying a failed pod", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", "", nil)
+		mockJob.EXPECT().GetJob(ctx, nfdCR.Namespace, "nfd-prune").Return(&batchv1.Job{Status: batchv1.JobStatus{Failed: 1}}, nil)
+
+		reason, _ := h.getNotUpgradeableReason(ctx, pruneCR, upToDate)
+		Expect(reason).To(Equal(conditionPruneJobPendingReason))
+	})
+
+	It("prune job is completed or missing", func() {
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", "", nil)
+		gomock.InOrder(
+			mockJob.EXPECT().GetJob(ctx, nfdCR.Namespace, "nfd-prune").Return(&batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1}}, nil),
+			mockJob.EXPECT().GetJob(ctx, nfdCR.Namespace, "nfd-prune").Return(nil, fmt.Errorf("some error")),
+		)
+
+		reason, _ := h.getNotUpgradeableReason(ctx, pruneCR, upToDate)
+		Expect(reason).To(BeEmpty())
+		reason, _ = h.getNotUpgradeableReason(ctx, pruneCR, upToDate)
+		Expect(reason).To(BeEmpty())
+	})
+
+	DescribeTable("Kubernetes version", func(kubernetesVersion, maxVersion, expectedMessage string) {
+		var maxKubernetesVersion *version.Version
+		if maxVersion != "" {
+			maxKubernetesVersion = version.MustParseGeneric(maxVersion)
+		}
+		h := newStatusHelperAPI(nil, nil, nil, nil, nil, mockJob, "", kubernetesVersion, maxKubernetesVersion)
+
+		reason, message := h.getNotUpgradeableReason(ctx, &nfdCR, upToDate)
+		if expectedMessage == "" {
+			Expect(reason).To(BeEmpty())
+			return
+		}
+		Expect(reason).To(Equal(conditionUnsupportedKubernetesVersionReason))
+		Expect(message).To(Equal(expectedMessage))
+	},
+		Entry("unknown version", "", "", ""),
+		Entry("unparsable version", "latest", "", ""),
+		Entry("oldest supported version", "v1.24.0", "", ""),
+		Entry("too old", "v1.23.17", "", "Kubernetes v1.23.17 is older than v1.24, the oldest version supported by the operand"),
+		Entry("new version without upper bound", "v1.35.0", "", ""),
+		Entry("newest supported version with build metadata", "v1.30.2+k3s1", "1.30", ""),
+		Entry("newer than the newest supported version", "v1.31.0", "v1.30.0",
+			"Kubernetes v1.31.0 is newer than v1.30, the newest version supported by the operand"),
+	)
+})
@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+// SetupWebhookWithManager registers the NodeFeatureDiscovery webhooks
+// with the webhook server of the manager. operandImage is the image
+// defaulted into CRs that do not specify one
+func SetupWebhookWithManager(mgr ctrl.Manager, operandImage string) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&nfdv1.NodeFeatureDiscovery{}).
+		WithDefaulter(NewNodeFeatureDiscoveryDefaulter(operandImage)).
+		WithValidator(NewNodeFeatureDiscoveryValidator()).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nfd-kubernetes-io-v1-nodefeaturediscovery,mutating=true,failurePolicy=fail,sideEffects=None,groups=nfd.kubernetes.io,resources=nodefeaturediscoveries,verbs=create;update,versions=v1,name=mnodefeaturediscovery.nfd.kubernetes.io,admissionReviewVersions=v1
+
+type nodeFeatureDiscoveryDefaulter struct {
+	operandImage string
+}
+
+func NewNodeFeatureDiscoveryDefaulter(operandImage string) admission.CustomDefaulter {
+	return &nodeFeatureDiscoveryDefaulter{
+		operandImage: operandImage,
+	}
+}
+
+// Default fills the unset operand fields with the values the operator
+// would use anyway, so that the stored CR shows what is actually deployed.
+// The patch is computed from the decoded CR, so a deprecated boolean
+// topologyUpdater is also stored in its object form
+func (d *nodeFeatureDiscoveryDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	nfdInstance, err := toNodeFeatureDiscovery(obj)
+	if err != nil {
+		return err
+	}
+
+	operand := &nfdInstance.Spec.Operand
+	if operand.Image == "" {
+		operand.Image = d.operandImage
+	}
+	if operand.ImagePullPolicy == "" {
+		operand.ImagePullPolicy = string(nfdv1.DefaultImagePullPolicy)
+	}
+	if operand.ServicePort == 0 {
+		operand.ServicePort = nfdv1.DefaultServicePort
+	}
+	defaultResources(&operand.Resources.Master, &nfdv1.DefaultMasterResources)
+	defaultResources(&operand.Resources.Worker, &nfdv1.DefaultWorkerResources)
+	defaultResources(&operand.Resources.TopologyUpdater, &nfdv1.DefaultTopologyUpdaterResources)
+	defaultResources(&operand.Resources.GC, &nfdv1.DefaultGCResources)
+	defaultResources(&operand.Resources.Prune, &nfdv1.DefaultPruneResources)
+	defaultWorkerConfig(&nfdInstance.Spec.WorkerConfig)
+	for i := range nfdInstance.Spec.WorkerPools {
+		defaultWorkerConfig(&nfdInstance.Spec.WorkerPools[i].Config)
+	}
+	return nil
+}
+
+func defaultResources(resources **corev1.ResourceRequirements, defaultResources *corev1.ResourceRequirements) {
+	if *resources == nil {
+		*resources = defaultResources.DeepCopy()
+	}
+}
+
+// defaultWorkerConfig leaves an unset worker configuration empty, the
+// operator renders DefaultWorkerConfig for it. The DefaultWorkerConfig that
+// previous versions stored in ConfigData is dropped once the typed
+// configuration is set, otherwise it would take precedence over it
+func defaultWorkerConfig(workerConfig *nfdv1.ConfigMap) {
+	hasTypedConfig := workerConfig.Core != nil || workerConfig.Sources != nil
+	if workerConfig.ConfigData == nfdv1.DefaultWorkerConfig && hasTypedConfig {
+		workerConfig.ConfigData = ""
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-nfd-kubernetes-io-v1-nodefeaturediscovery,mutating=false,failurePolicy=fail,sideEffects=None,groups=nfd.kubernetes.io,resources=nodefeaturediscoveries,verbs=create;update,versions=v1,name=vnodefeaturediscovery.nfd.kubernetes.io,admissionReviewVersions=v1
+
+type nodeFeatureDiscoveryValidator struct{}
+
+func NewNodeFeatureDiscoveryValidator() admission.CustomValidator {
+	return &nodeFeatureDiscoveryValidator{}
+}
+
+func (v *nodeFeatureDiscoveryValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	nfdInstance, err := toNodeFeatureDiscovery(obj)
+	if err != nil {
+		return nil, err
+	}
+	return getWarnings(nfdInstance), validateNodeFeatureDiscovery(nfdInstance)
+}
+
+func (v *nodeFeatureDiscoveryValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldInstance, err := toNodeFeatureDiscovery(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newInstance, err := toNodeFeatureDiscovery(newObj)
+	if err != nil {
+		return nil, err
+	}
+	// updates that do not touch the spec (finalizers, labels, etc) must always be
+	// accepted, otherwise a CR created before the webhook was deployed could
+	// never be deleted
+	if equality.Semantic.DeepEqual(oldInstance.Spec, newInstance.Spec) {
+		return nil, nil
+	}
+	return getWarnings(newInstance), validateNodeFeatureDiscovery(newInstance)
+}
+
+func (v *nodeFeatureDiscoveryValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func toNodeFeatureDiscovery(obj runtime.Object) (*nfdv1.NodeFeatureDiscovery, error) {
+	nfdInstance, ok := obj.(*nfdv1.NodeFeatureDiscovery)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodeFeatureDiscovery object, got %T", obj)
+	}
+	return nfdInstance, nil
+}
+
+func getWarnings(nfdInstance *nfdv1.NodeFeatureDiscovery) admission.Warnings {
+	specPath := field.NewPath("spec")
+
+	warnings := getWorkerConfigWarnings(&nfdInstance.Spec.WorkerConfig, specPath.Child("workerConfig"))
+	pools := nfdInstance.Spec.WorkerPools
+	for i := range pools {
+		poolPath := specPath.Child("workerPools").Index(i)
+		warnings = append(warnings, getWorkerConfigWarnings(&pools[i].Config, poolPath.Child("config"))...)
+		if len(pools) > 1 && len(pools[i].NodeSelector) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s has no nodeSelector, its workers will overlap with the ones of the other worker pools", poolPath))
+		}
+	}
+	return warnings
+}
+
+func getWorkerConfigWarnings(workerConfig *nfdv1.ConfigMap, fldPath *field.Path) admission.Warnings {
+	hasTypedConfig := workerConfig.Core != nil || workerConfig.Sources != nil
+	if workerConfig.ConfigMapRef != nil {
+		if workerConfig.ConfigData != "" || hasTypedConfig {
+			return admission.Warnings{fmt.Sprintf("%s is set, %s and the typed worker configuration are ignored",
+				fldPath.Child("configMapRef"), fldPath.Child("configData"))}
+		}
+	} else if workerConfig.ConfigData != "" && hasTypedConfig {
+		return admission.Warnings{fmt.Sprintf("%s is set, the typed %s and %s fields are ignored",
+			fldPath.Child("configData"), fldPath.Child("core"), fldPath.Child("sources"))}
+	}
+	return nil
+}
+
+func validateNodeFeatureDiscovery(nfdInstance *nfdv1.NodeFeatureDiscovery) error {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateOwnerName(nfdInstance)
+	allErrs = append(allErrs, validateLabelWhiteList(nfdInstance.Spec.LabelWhiteList, specPath.Child("labelWhiteList"))...)
+	allErrs = append(allErrs, validateExtraLabelNs(nfdInstance.Spec.ExtraLabelNs, specPath.Child("extraLabelNs"))...)
+	allErrs = append(allErrs, validateOperand(&nfdInstance.Spec.Operand, specPath.Child("operand"))...)
+	allErrs = append(allErrs, validateWorkerConfig(&nfdInstance.Spec.WorkerConfig, specPath.Child("workerConfig"))...)
+	allErrs = append(allErrs, validateWorkerPools(nfdInstance.Spec.WorkerPools, specPath.Child("workerPools"))...)
+	allErrs = append(allErrs, validateTopologyUpdater(&nfdInstance.Spec.TopologyUpdater, specPath.Child("topologyUpdater"))...)
+	allErrs = append(allErrs, validatePrunePolicy(&nfdInstance.Spec.PrunePolicy, specPath.Child("prunePolicy"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(nfdv1.GroupVersion.WithKind("NodeFeatureDiscovery").GroupKind(), nfdInstance.Name, allErrs)
+}
+
+// validateOwnerName checks that the name of a CR deploying its operands to
+// another namespace fits in the owner label set on the operand objects
+func validateOwnerName(nfdInstance *nfdv1.NodeFeatureDiscovery) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if nfdInstance.GetOperandNamespace() == nfdInstance.Namespace {
+		return allErrs
+	}
+	for _, msg := range validation.IsValidLabelValue(nfdInstance.Name) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), nfdInstance.Name,
+			fmt.Sprintf("must be a valid label value when spec.operand.namespace is set to another namespace: %s", msg)))
+	}
+	return allErrs
+}
+
+func validateLabelWhiteList(labelWhiteList string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := regexp.Compile(labelWhiteList); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, labelWhiteList, fmt.Sprintf("invalid regular expression: %v", err)))
+	}
+	return allErrs
+}
+
+func validateExtraLabelNs(extraLabelNs []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ns := range extraLabelNs {
+		for _, msg := range validation.IsDNS1123Subdomain(ns) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), ns, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateOperand(operand *nfdv1.OperandSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if operand.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(operand.Namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), operand.Namespace, msg))
+		}
+	}
+
+	// 0 means that the default port is used
+	if operand.ServicePort != 0 {
+		for _, msg := range validation.IsValidPortNum(operand.ServicePort) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePort"), operand.ServicePort, msg))
+		}
+	}
+
+	switch corev1.PullPolicy(operand.ImagePullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		supported := []string{string(corev1.PullAlways), string(corev1.PullIfNotPresent), string(corev1.PullNever)}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("imagePullPolicy"), operand.ImagePullPolicy, supported))
+	}
+
+	resourcesPath := fldPath.Child("resources")
+	allErrs = append(allErrs, validateResources(operand.Resources.Master, resourcesPath.Child("master"))...)
+	allErrs = append(allErrs, validateResources(operand.Resources.Worker, resourcesPath.Child("worker"))...)
+	allErrs = append(allErrs, validateResources(operand.Resources.TopologyUpdater, resourcesPath.Child("topologyUpdater"))...)
+	allErrs = append(allErrs, validateResources(operand.Resources.GC, resourcesPath.Child("gc"))...)
+	allErrs = append(allErrs, validateResources(operand.Resources.Prune, resourcesPath.Child("prune"))...)
+
+	schedulingPath := fldPath.Child("scheduling")
+	allErrs = append(allErrs, metav1validation.ValidateLabels(operand.Scheduling.Master.NodeSelector, schedulingPath.Child("master", "nodeSelector"))...)
+	allErrs = append(allErrs, metav1validation.ValidateLabels(operand.Scheduling.Worker.NodeSelector, schedulingPath.Child("worker", "nodeSelector"))...)
+	allErrs = append(allErrs, metav1validation.ValidateLabels(operand.Scheduling.GC.NodeSelector, schedulingPath.Child("gc", "nodeSelector"))...)
+	return allErrs
+}
+
+func validateWorkerConfig(workerConfig *nfdv1.ConfigMap, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(workerConfig.ConfigData), &config); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("configData"), "<config data>", fmt.Sprintf("invalid YAML: %v", err)))
+	}
+	if workerConfig.Core != nil {
+		allErrs = append(allErrs, validateLabelWhiteList(workerConfig.Core.LabelWhiteList, fldPath.Child("core", "labelWhiteList"))...)
+	}
+	if workerConfig.Sources != nil {
+		customPath := fldPath.Child("sources", "custom")
+		for i, rule := range workerConfig.Sources.Custom {
+			rulePath := customPath.Index(i)
+			parsed := map[string]interface{}{}
+			if err := yaml.Unmarshal(rule.Raw, &parsed); err != nil {
+				allErrs = append(allErrs, field.Invalid(rulePath, "<custom rule>", fmt.Sprintf("invalid rule: %v", err)))
+				continue
+			}
+			if _, ok := parsed["name"]; !ok {
+				allErrs = append(allErrs, field.Required(rulePath.Child("name"), "custom rules must have a name"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateWorkerPools(pools []nfdv1.WorkerPool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i := range pools {
+		poolPath := fldPath.Index(i)
+		allErrs = append(allErrs, metav1validation.ValidateLabels(pools[i].NodeSelector, poolPath.Child("nodeSelector"))...)
+		allErrs = append(allErrs, validateWorkerConfig(&pools[i].Config, poolPath.Child("config"))...)
+	}
+	return allErrs
+}
+
+// topologyUpdaterConfigURISchemes are the kubelet config URI schemes
+// understood by nfd-topology-updater
+var topologyUpdaterConfigURISchemes = []string{"file", "https"}
+
+func validateTopologyUpdater(topologyUpdater *nfdv1.TopologyUpdaterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if topologyUpdater.SleepInterval != nil && topologyUpdater.SleepInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sleepInterval"), topologyUpdater.SleepInterval.Duration.String(), "must be greater than 0"))
+	}
+	allErrs = append(allErrs, validateHostPath(topologyUpdater.KubeletStateDir, fldPath.Child("kubeletStateDir"))...)
+	allErrs = append(allErrs, validateHostPath(topologyUpdater.PodResourcesSocketPath, fldPath.Child("podResourcesSocketPath"))...)
+	// the URI is not parsed as a whole, its host usually is the
+	// ${NODE_ADDRESS} placeholder expanded by nfd-topology-updater
+	if uri := topologyUpdater.KubeletConfigURI; uri != "" {
+		scheme, _, found := strings.Cut(uri, "://")
+		if !found {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubeletConfigURI"), uri, "must be a file:// or https:// URI"))
+		} else if !slices.Contains(topologyUpdaterConfigURISchemes, scheme) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("kubeletConfigURI"), scheme, topologyUpdaterConfigURISchemes))
+		}
+	}
+	return allErrs
+}
+
+// validatePrunePolicy rejects the durations the prune job cannot be created
+// with, which would keep a terminating CR from ever being pruned
+func validatePrunePolicy(prunePolicy *nfdv1.PrunePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if prunePolicy.Timeout != nil && prunePolicy.Timeout.Duration < time.Second {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), prunePolicy.Timeout.Duration.String(), "must be at least 1s"))
+	}
+	if prunePolicy.TTLAfterFinished != nil && prunePolicy.TTLAfterFinished.Duration < nfdv1.MinPruneTTLAfterFinished {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttlAfterFinished"), prunePolicy.TTLAfterFinished.Duration.String(), "must be at least 1m"))
+	}
+	return allErrs
+}
+
+func validateHostPath(hostPath string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hostPath != "" && !path.IsAbs(hostPath) {
+		allErrs = append(allErrs, field.Invalid(fldPath, hostPath, "must be an absolute path"))
+	}
+	return allErrs
+}
+
+// validateResources rejects requests that exceed the matching limit, which
+// the API server would only report when creating the operand pods
+func validateResources(resources *corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if resources == nil {
+		return allErrs
+	}
+	for name, request := range resources.Requests {
+		limit, ok := resources.Limits[name]
+		if ok && request.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(string(name)), request.String(),
+				fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,483 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nfdv1 "sigs.k8s.io/node-feature-discovery-operator/api/v1"
+)
+
+var _ = Describe("Default", func() {
+	const operandImage = "registry.k8s.io/nfd/node-feature-discovery:v0.16.0"
+
+	var (
+		defaulter admission.CustomDefaulter
+	)
+
+	BeforeEach(func() {
+		defaulter = NewNodeFeatureDiscoveryDefaulter(operandImage)
+	})
+
+	ctx := context.Background()
+
+	It("empty spec is fully defaulted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.Operand.Image).To(Equal(operandImage))
+		Expect(nfdCR.Spec.Operand.ImagePullPolicy).To(Equal(string(corev1.PullAlways)))
+		Expect(nfdCR.Spec.Operand.ServicePort).To(Equal(12000))
+		Expect(nfdCR.Spec.WorkerConfig).To(Equal(nfdv1.ConfigMap{}))
+		Expect(nfdCR.Spec.Operand.Resources.Master).To(Equal(&nfdv1.DefaultMasterResources))
+		Expect(nfdCR.Spec.Operand.Resources.Worker).To(Equal(&nfdv1.DefaultWorkerResources))
+		Expect(nfdCR.Spec.Operand.Resources.TopologyUpdater).To(Equal(&nfdv1.DefaultTopologyUpdaterResources))
+		Expect(nfdCR.Spec.Operand.Resources.GC).To(Equal(&nfdv1.DefaultGCResources))
+		Expect(nfdCR.Spec.Operand.Resources.Prune).To(Equal(&nfdv1.DefaultPruneResources))
+	})
+
+	It("fields set by the user are not overridden", func() {
+		spec := nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Image:           "test-image",
+				ImagePullPolicy: string(corev1.PullIfNotPresent),
+				ServicePort:     13000,
+				Resources: nfdv1.OperandResources{
+					Master:          &corev1.ResourceRequirements{},
+					Worker:          &corev1.ResourceRequirements{},
+					TopologyUpdater: &corev1.ResourceRequirements{},
+					GC:              &corev1.ResourceRequirements{},
+					Prune:           &corev1.ResourceRequirements{},
+				},
+			},
+			WorkerConfig: nfdv1.ConfigMap{ConfigData: "sources: {}\n"},
+		}
+		nfdCR := nfdv1.NodeFeatureDiscovery{Spec: *spec.DeepCopy()}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec).To(Equal(spec))
+	})
+
+	It("typed worker config is set, raw worker config is not defaulted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					Core: &nfdv1.WorkerCoreConfig{NoPublish: true},
+				},
+			},
+		}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.WorkerConfig.ConfigData).To(BeEmpty())
+	})
+
+	It("worker configmap is referenced, raw worker config is not defaulted", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm"},
+				},
+			},
+		}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.WorkerConfig.ConfigData).To(BeEmpty())
+	})
+
+	It("worker pool config is left empty like the default worker config", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{
+					{Name: "gpu"},
+					{Name: "smartnic", Config: nfdv1.ConfigMap{ConfigData: "sources: {}\n"}},
+				},
+			},
+		}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.WorkerPools[0].Config).To(Equal(nfdv1.ConfigMap{}))
+		Expect(nfdCR.Spec.WorkerPools[1].Config.ConfigData).To(Equal("sources: {}\n"))
+	})
+
+	It("defaulted CR, then typed config added on update", func() {
+		oldCR := nfdv1.NodeFeatureDiscovery{}
+		err := defaulter.Default(ctx, &oldCR)
+		Expect(err).To(BeNil())
+
+		newCR := oldCR.DeepCopy()
+		newCR.Spec.WorkerConfig.Core = &nfdv1.WorkerCoreConfig{NoPublish: true}
+		err = defaulter.Default(ctx, newCR)
+		Expect(err).To(BeNil())
+		Expect(newCR.Spec.WorkerConfig.IsTyped()).To(BeTrue())
+
+		warnings, err := NewNodeFeatureDiscoveryValidator().ValidateUpdate(ctx, &oldCR, newCR)
+		Expect(err).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("default worker config stored by a previous version is dropped once typed config is added", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigData: nfdv1.DefaultWorkerConfig,
+					Sources:    &nfdv1.WorkerSourcesConfig{},
+				},
+			},
+		}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.WorkerConfig.ConfigData).To(BeEmpty())
+		Expect(nfdCR.Spec.WorkerConfig.IsTyped()).To(BeTrue())
+	})
+
+	It("deprecated boolean topologyUpdater is converted to the object form", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+		err := json.Unmarshal([]byte(`{"spec":{"topologyUpdater":true}}`), &nfdCR)
+		Expect(err).To(BeNil())
+
+		err = defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.TopologyUpdater.Enabled).To(BeTrue())
+
+		data, err := json.Marshal(&nfdCR.Spec)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(`"topologyUpdater":{"enabled":true}`))
+	})
+
+	It("operator has no default image configured", func() {
+		defaulter = NewNodeFeatureDiscoveryDefaulter("")
+		nfdCR := nfdv1.NodeFeatureDiscovery{}
+
+		err := defaulter.Default(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(nfdCR.Spec.Operand.Image).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ValidateCreate", func() {
+	var (
+		validator admission.CustomValidator
+	)
+
+	BeforeEach(func() {
+		validator = NewNodeFeatureDiscoveryValidator()
+	})
+
+	ctx := context.Background()
+
+	DescribeTable("validating the NFD CR spec", func(spec nfdv1.NodeFeatureDiscoverySpec, expectError bool) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "nfd-cr", Namespace: "test-namespace"},
+			Spec:       spec,
+		}
+
+		_, err := validator.ValidateCreate(ctx, &nfdCR)
+		if expectError {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).To(BeNil())
+		}
+	},
+		Entry("empty spec", nfdv1.NodeFeatureDiscoverySpec{}, false),
+		Entry("valid spec", nfdv1.NodeFeatureDiscoverySpec{
+			LabelWhiteList: "^cpu-.*$",
+			ExtraLabelNs:   []string{"example.com", "vendor.example.com"},
+			Operand: nfdv1.OperandSpec{
+				ServicePort:     12000,
+				ImagePullPolicy: string(corev1.PullIfNotPresent),
+			},
+			WorkerConfig: nfdv1.ConfigMap{
+				ConfigData: "core:\n  sleepInterval: 60s\n",
+			},
+		}, false),
+		Entry("worker config with comments only", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{ConfigData: "#core:\n#  sleepInterval: 60s\n"},
+		}, false),
+		Entry("invalid label whitelist regex", nfdv1.NodeFeatureDiscoverySpec{
+			LabelWhiteList: "cpu-(",
+		}, true),
+		Entry("service port out of range", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{ServicePort: 70000},
+		}, true),
+		Entry("negative service port", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{ServicePort: -1},
+		}, true),
+		Entry("operand namespace", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{Namespace: "nfd-operands"},
+		}, false),
+		Entry("operand namespace is not a DNS label", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{Namespace: "nfd.operands"},
+		}, true),
+		Entry("unknown image pull policy", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{ImagePullPolicy: "Sometimes"},
+		}, true),
+		Entry("extra label namespace is not a DNS subdomain", nfdv1.NodeFeatureDiscoverySpec{
+			ExtraLabelNs: []string{"example.com", "Not_A_Domain"},
+		}, true),
+		Entry("worker config is not valid YAML", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{ConfigData: "core:\n  sleepInterval: [60s\n"},
+		}, true),
+		Entry("typed worker config with invalid label whitelist", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{
+				Core: &nfdv1.WorkerCoreConfig{LabelWhiteList: "cpu-("},
+			},
+		}, true),
+		Entry("typed worker config with a valid custom rule", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{
+				Sources: &nfdv1.WorkerSourcesConfig{
+					Custom: []runtime.RawExtension{{Raw: []byte(`{"name":"my-rule"}`)}},
+				},
+			},
+		}, false),
+		Entry("typed worker config with an unnamed custom rule", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{
+				Sources: &nfdv1.WorkerSourcesConfig{
+					Custom: []runtime.RawExtension{{Raw: []byte(`{"labels":{"my-label":"true"}}`)}},
+				},
+			},
+		}, true),
+		Entry("worker config is not a YAML mapping", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerConfig: nfdv1.ConfigMap{ConfigData: "- core\n- sources\n"},
+		}, true),
+		Entry("worker resources requests within the limits", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Resources: nfdv1.OperandResources{
+					Worker: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				},
+			},
+		}, false),
+		Entry("master resources requests exceed the limits", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Resources: nfdv1.OperandResources{
+					Master: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+					},
+				},
+			},
+		}, true),
+		Entry("valid operand scheduling", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Scheduling: nfdv1.OperandScheduling{
+					Master: nfdv1.PodScheduling{NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""}},
+					Worker: nfdv1.PodScheduling{TolerationsPolicy: nfdv1.TolerationsPolicyReplace},
+				},
+			},
+		}, false),
+		Entry("gc node selector is not a valid label", nfdv1.NodeFeatureDiscoverySpec{
+			Operand: nfdv1.OperandSpec{
+				Scheduling: nfdv1.OperandScheduling{
+					GC: nfdv1.PodScheduling{NodeSelector: map[string]string{"not a label": ""}},
+				},
+			},
+		}, true),
+		Entry("valid worker pools", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerPools: []nfdv1.WorkerPool{
+				{Name: "gpu", NodeSelector: map[string]string{"node-role.kubernetes.io/gpu": ""}},
+				{Name: "smartnic", NodeSelector: map[string]string{"example.com/smartnic": "true"}},
+			},
+		}, false),
+		Entry("worker pool with an invalid node selector", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerPools: []nfdv1.WorkerPool{
+				{Name: "gpu", NodeSelector: map[string]string{"not a label": "true"}},
+			},
+		}, true),
+		Entry("worker pool config is not valid YAML", nfdv1.NodeFeatureDiscoverySpec{
+			WorkerPools: []nfdv1.WorkerPool{
+				{Name: "gpu", Config: nfdv1.ConfigMap{ConfigData: "core:\n  sleepInterval: [60s\n"}},
+			},
+		}, true),
+		Entry("valid topology updater", nfdv1.NodeFeatureDiscoverySpec{
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{
+				Enabled:                true,
+				SleepInterval:          &metav1.Duration{Duration: time.Minute},
+				KubeletStateDir:        "/var/data/kubelet",
+				PodResourcesSocketPath: "/var/data/kubelet/pod-resources/kubelet.sock",
+				KubeletConfigURI:       "https://${NODE_ADDRESS}:10250/configz",
+			},
+		}, false),
+		Entry("topology updater sleep interval is not positive", nfdv1.NodeFeatureDiscoverySpec{
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{SleepInterval: &metav1.Duration{}},
+		}, true),
+		Entry("topology updater kubelet state dir is relative", nfdv1.NodeFeatureDiscoverySpec{
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{KubeletStateDir: "var/lib/kubelet"},
+		}, true),
+		Entry("topology updater kubelet config URI has an unsupported scheme", nfdv1.NodeFeatureDiscoverySpec{
+			TopologyUpdater: nfdv1.TopologyUpdaterSpec{KubeletConfigURI: "http://localhost:10255/configz"},
+		}, true),
+		Entry("valid prune policy", nfdv1.NodeFeatureDiscoverySpec{
+			PrunePolicy: nfdv1.PrunePolicy{
+				Timeout:          &metav1.Duration{Duration: time.Second},
+				TTLAfterFinished: &metav1.Duration{Duration: time.Minute},
+			},
+		}, false),
+		Entry("prune timeout under a second", nfdv1.NodeFeatureDiscoverySpec{
+			PrunePolicy: nfdv1.PrunePolicy{Timeout: &metav1.Duration{Duration: 500 * time.Millisecond}},
+		}, true),
+		Entry("negative prune TTL", nfdv1.NodeFeatureDiscoverySpec{
+			PrunePolicy: nfdv1.PrunePolicy{TTLAfterFinished: &metav1.Duration{Duration: -time.Minute}},
+		}, true),
+		Entry("prune TTL removing the job before its outcome is read", nfdv1.NodeFeatureDiscoverySpec{
+			PrunePolicy: nfdv1.PrunePolicy{TTLAfterFinished: &metav1.Duration{}},
+		}, true),
+	)
+
+	DescribeTable("the name of the CR must fit in the owner label of the operand objects", func(operandNamespace string, expectError bool) {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("n", 64), Namespace: "test-namespace"},
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				Operand: nfdv1.OperandSpec{Namespace: operandNamespace},
+			},
+		}
+
+		_, err := validator.ValidateCreate(ctx, &nfdCR)
+		if expectError {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).To(BeNil())
+		}
+	},
+		Entry("operands in the namespace of the CR", "", false),
+		Entry("operand namespace is the namespace of the CR", "test-namespace", false),
+		Entry("operands in another namespace", "nfd-operands", true),
+	)
+})
+
+var _ = Describe("warnings", func() {
+	var (
+		validator admission.CustomValidator
+	)
+
+	BeforeEach(func() {
+		validator = NewNodeFeatureDiscoveryValidator()
+	})
+
+	ctx := context.Background()
+
+	It("both raw and typed worker config are set", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigData: "core:\n  sleepInterval: 60s\n",
+					Core:       &nfdv1.WorkerCoreConfig{NoPublish: true},
+				},
+			},
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(warnings).To(HaveLen(1))
+	})
+
+	It("worker configmap is referenced together with an inline config", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					ConfigMapRef: &nfdv1.WorkerConfigMapRef{Name: "user-cm"},
+					ConfigData:   "core:\n  sleepInterval: 60s\n",
+				},
+			},
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(warnings).To(HaveLen(1))
+	})
+
+	It("several worker pools and one of them selects all the nodes", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerPools: []nfdv1.WorkerPool{
+					{Name: "gpu", NodeSelector: map[string]string{"node-role.kubernetes.io/gpu": ""}},
+					{Name: "default"},
+				},
+			},
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(warnings).To(ConsistOf(ContainSubstring("spec.workerPools[1]")))
+	})
+
+	It("only the typed worker config is set", func() {
+		nfdCR := nfdv1.NodeFeatureDiscovery{
+			Spec: nfdv1.NodeFeatureDiscoverySpec{
+				WorkerConfig: nfdv1.ConfigMap{
+					Core: &nfdv1.WorkerCoreConfig{NoPublish: true},
+				},
+			},
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, &nfdCR)
+		Expect(err).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ValidateUpdate", func() {
+	var (
+		validator admission.CustomValidator
+	)
+
+	BeforeEach(func() {
+		validator = NewNodeFeatureDiscoveryValidator()
+	})
+
+	ctx := context.Background()
+	invalidSpec := nfdv1.NodeFeatureDiscoverySpec{
+		LabelWhiteList: "cpu-(",
+	}
+
+	It("spec was not changed, update is accepted even if the spec is invalid", func() {
+		oldCR := nfdv1.NodeFeatureDiscovery{Spec: invalidSpec}
+		newCR := nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"nfd-finalizer"}},
+			Spec:       invalidSpec,
+		}
+
+		_, err := validator.ValidateUpdate(ctx, &oldCR, &newCR)
+		Expect(err).To(BeNil())
+	})
+
+	It("spec was changed to an invalid one", func() {
+		oldCR := nfdv1.NodeFeatureDiscovery{}
+		newCR := nfdv1.NodeFeatureDiscovery{Spec: invalidSpec}
+
+		_, err := validator.ValidateUpdate(ctx, &oldCR, &newCR)
+		Expect(err).To(HaveOccurred())
+	})
+})
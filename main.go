@@ -26,10 +26,14 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -38,8 +42,20 @@ import (
 	"sigs.k8s.io/node-feature-discovery-operator/internal/controllers"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/daemonset"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/deployment"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/events"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/job"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/metrics"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/namespace"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/pod"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/poddisruptionbudget"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/prometheusrule"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/rbac"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/service"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/serviceaccount"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/servicemonitor"
+	"sigs.k8s.io/node-feature-discovery-operator/internal/snapshot"
 	"sigs.k8s.io/node-feature-discovery-operator/internal/status"
+	nfdwebhook "sigs.k8s.io/node-feature-discovery-operator/internal/webhook"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -53,6 +69,7 @@ const (
 	// ProgramName is the canonical name of this program
 	ProgramName          = "nfd-operator"
 	watchNamespaceEnvVar = "WATCH_NAMESPACE"
+	operandImageEnvVar   = "NODE_FEATURE_DISCOVERY_IMAGE"
 )
 
 // operatorArgs holds command line arguments
@@ -60,6 +77,9 @@ type operatorArgs struct {
 	metricsAddr          string
 	enableLeaderElection bool
 	probeAddr            string
+	enableWebhooks       bool
+	watchNamespaces      string
+	maxKubernetesVersion string
 }
 
 func init() {
@@ -96,14 +116,34 @@ func main() {
 		os.Exit(0)
 	}
 
-	watchNamespace, err := getWatchNamespace()
-	if err != nil {
-		setupLogger.Error(err, "WatchNamespaceEnvVar is not set")
-		os.Exit(1)
+	var maxKubernetesVersion *utilversion.Version
+	if args.maxKubernetesVersion != "" {
+		v, err := utilversion.ParseGeneric(args.maxKubernetesVersion)
+		if err != nil {
+			setupLogger.Error(err, "invalid --max-kubernetes-version")
+			os.Exit(1)
+		}
+		maxKubernetesVersion = v
+	}
+
+	// the namespaced objects are only cached in the watched namespaces,
+	// and in all of them when the list is empty
+	watchNamespaces := namespace.ParseWatchNamespaces(args.watchNamespaces)
+	cacheOptions := cache.Options{}
+	if len(watchNamespaces) > 0 {
+		setupLogger.Info("watching namespaces", "namespaces", watchNamespaces)
+		cacheOptions.DefaultNamespaces = make(map[string]cache.Config, len(watchNamespaces))
+		for _, ns := range watchNamespaces {
+			cacheOptions.DefaultNamespaces[ns] = cache.Config{}
+		}
+	} else {
+		setupLogger.Info("watching all namespaces")
 	}
 
+	restConfig := ctrl.GetConfigOrDie()
+
 	// Create a new manager to manage the operator
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
 			BindAddress: args.metricsAddr,
@@ -114,11 +154,7 @@ func main() {
 		HealthProbeBindAddress: args.probeAddr,
 		LeaderElection:         args.enableLeaderElection,
 		LeaderElectionID:       "39f5e5c3.nodefeaturediscoveries.nfd.kubernetes.io",
-		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				watchNamespace: cache.Config{},
-			},
-		},
+		Cache:                  cacheOptions,
 	})
 
 	if err != nil {
@@ -129,22 +165,65 @@ func main() {
 	client := mgr.GetClient()
 	scheme := mgr.GetScheme()
 
-	deploymentAPI := deployment.NewDeploymentAPI(client, scheme)
-	daemonsetAPI := daemonset.NewDaemonsetAPI(client, scheme)
+	// the operands of the CRs that do not set an image run the one the
+	// operator was configured with, whether the defaulting webhook is
+	// enabled or not
+	operandImage := os.Getenv(operandImageEnvVar)
+
+	namespaceAPI := namespace.NewNamespaceAPI(client, scheme)
+	rbacAPI := rbac.NewRBACAPI(client, scheme)
+	deploymentAPI := deployment.NewDeploymentAPI(client, scheme, operandImage)
+	daemonsetAPI := daemonset.NewDaemonsetAPI(client, scheme, operandImage)
 	configmapAPI := configmap.NewConfigMapAPI(client, scheme)
-	jobAPI := job.NewJobAPI(client, scheme)
-	statusAPI := status.NewStatusAPI(deploymentAPI, daemonsetAPI)
+	jobAPI := job.NewJobAPI(client, scheme, operandImage)
+	snapshotAPI := snapshot.NewSnapshotAPI(client, scheme)
+	pdbAPI := poddisruptionbudget.NewPodDisruptionBudgetAPI(client, scheme)
+	serviceAPI := service.NewServiceAPI(client, scheme)
+	serviceMonitorAPI := servicemonitor.NewServiceMonitorAPI(client, scheme)
+	prometheusRuleAPI := prometheusrule.NewPrometheusRuleAPI(client, scheme)
+	podAPI := pod.NewPodAPI(client, scheme)
+	serviceAccountAPI := serviceaccount.NewServiceAccountAPI(client, scheme)
+	kubernetesVersion, err := getKubernetesVersion(restConfig)
+	if err != nil {
+		// the Upgradeable condition does not check the Kubernetes version then
+		setupLogger.Error(err, "unable to get the Kubernetes version")
+	}
+	statusAPI := status.NewStatusAPI(deploymentAPI,
+		daemonsetAPI,
+		configmapAPI,
+		podAPI,
+		serviceAccountAPI,
+		jobAPI,
+		operandImage,
+		kubernetesVersion,
+		maxKubernetesVersion)
 
 	if err = new_controllers.NewNodeFeatureDiscoveryReconciler(client,
+		namespaceAPI,
+		rbacAPI,
 		deploymentAPI,
 		daemonsetAPI,
 		configmapAPI,
 		jobAPI,
+		snapshotAPI,
+		pdbAPI,
+		serviceAPI,
+		serviceMonitorAPI,
+		prometheusRuleAPI,
 		statusAPI,
-		scheme).SetupWithManager(mgr); err != nil {
+		metrics.NewMetricsAPI(ctrlmetrics.Registry),
+		events.NewAggregatingRecorder(mgr.GetEventRecorderFor(ProgramName), events.DefaultAggregationInterval),
+		scheme,
+		watchNamespaces).SetupWithManager(mgr); err != nil {
 		setupLogger.Error(err, "unable to create controller", "controller", "NodeFeatureDiscovery")
 		os.Exit(1)
 	}
+	if args.enableWebhooks {
+		if err = nfdwebhook.SetupWebhookWithManager(mgr, operandImage); err != nil {
+			setupLogger.Error(err, "unable to create webhook", "webhook", "NodeFeatureDiscovery")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	// Next, add a Healthz checker to the manager. Healthz is a health and liveness package
@@ -181,15 +260,29 @@ func initFlags(flagset *flag.FlagSet) *operatorArgs {
 	flagset.BoolVar(&args.enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flagset.BoolVar(&args.enableWebhooks, "enable-webhooks", false,
+		"Enable the admission webhooks for NodeFeatureDiscovery objects. "+
+			"Requires a serving certificate to be mounted into the operator pod.")
+	flagset.StringVar(&args.maxKubernetesVersion, "max-kubernetes-version", "",
+		"Newest Kubernetes version (e.g. 1.31) the operand is supported on. A newer cluster "+
+			"reports the NodeFeatureDiscovery objects as not upgradeable. No upper bound when empty.")
+	flagset.StringVar(&args.watchNamespaces, "watch-namespaces", os.Getenv(watchNamespaceEnvVar),
+		"Comma-separated list of the namespaces to watch for NodeFeatureDiscovery objects "+
+			"and their operands. All namespaces are watched when empty. "+
+			"Defaults to the value of the "+watchNamespaceEnvVar+" environment variable.")
 
 	return &args
 }
 
-// getWatchNamespace returns the Namespace the operator should be watching for changes
-func getWatchNamespace() (string, error) {
-	value, present := os.LookupEnv(watchNamespaceEnvVar)
-	if !present {
-		return "", fmt.Errorf("environment variable %s is not defined", watchNamespaceEnvVar)
+// getKubernetesVersion returns the version of the API server
+func getKubernetesVersion(config *rest.Config) (string, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
 	}
-	return value, nil
+	return serverVersion.GitVersion, nil
 }